@@ -0,0 +1,142 @@
+/*
+Package alphabet describes the characters that string keys are made of.
+Many string-processing algorithms (tries, radix sorts) take time and space proportional
+to the alphabet size R (radix), so a small alphabet can save a huge amount of space,
+e.g., a DNA sequence needs only 4 characters instead of 256.
+
+An Alphabet converts between characters and indices in [0; R-1] range,
+so a character can be used to index an array of size R.
+The index order defines the order of the characters (and the keys).
+*/
+package alphabet
+
+import (
+	"fmt"
+	"math/bits"
+	"unicode/utf8"
+)
+
+// Standard alphabets.
+var (
+	// Binary is a binary alphabet {0, 1}.
+	Binary = New("01")
+	// DNA is an alphabet of nucleotides {A, C, G, T}.
+	DNA = New("ACGT")
+	// Decimal is an alphabet of decimal digits.
+	Decimal = New("0123456789")
+	// Lowercase is an alphabet of lowercase letters a-z.
+	Lowercase = New("abcdefghijklmnopqrstuvwxyz")
+	// Uppercase is an alphabet of uppercase letters A-Z.
+	Uppercase = New("ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	// ASCII is an alphabet of 128 ASCII characters.
+	ASCII = Radix(128)
+	// ExtendedASCII is an alphabet of 256 characters, an index is a byte value.
+	ExtendedASCII = Radix(256)
+	// Unicode is an alphabet of all Unicode code points, an index is a code point.
+	Unicode = Radix(utf8.MaxRune + 1)
+)
+
+// Alphabet is a set of characters where each character has an index in [0; R-1] range.
+// Zero value is unusable, please use New or Radix.
+type Alphabet struct {
+	radix int
+	// chars maps an index to a character, it's nil when an index is the character itself.
+	chars []rune
+	// indices maps a character to its index, -1 means that the character is not in the alphabet.
+	// It's nil when a character is its own index.
+	indices []int
+}
+
+// New creates an alphabet from the characters in the given order.
+// It panics if a character repeats.
+func New(chars string) *Alphabet {
+	a := Alphabet{
+		chars: []rune(chars),
+	}
+	a.radix = len(a.chars)
+
+	var max rune
+	for _, c := range a.chars {
+		if c > max {
+			max = c
+		}
+	}
+	a.indices = make([]int, max+1)
+	for c := range a.indices {
+		a.indices[c] = -1
+	}
+	for i, c := range a.chars {
+		if a.indices[c] != -1 {
+			panic(fmt.Sprintf("alphabet: character %q repeats", c))
+		}
+		a.indices[c] = i
+	}
+	return &a
+}
+
+// Radix creates an alphabet of the first r code points where a character is its own index,
+// e.g., Radix(256) is the extended ASCII.
+func Radix(r int) *Alphabet {
+	return &Alphabet{radix: r}
+}
+
+// Radix returns the number of characters in the alphabet.
+func (a *Alphabet) Radix() int {
+	return a.radix
+}
+
+// LgR returns the number of bits needed to represent an index, e.g., 2 bits for DNA.
+func (a *Alphabet) LgR() int {
+	return bits.Len(uint(a.radix - 1))
+}
+
+// Contains reports whether the character c is in the alphabet.
+func (a *Alphabet) Contains(c rune) bool {
+	return a.ToIndex(c) != -1
+}
+
+// ToIndex converts the character c to its index in [0; R-1] range.
+// It returns -1 if c is not in the alphabet.
+func (a *Alphabet) ToIndex(c rune) int {
+	if a.indices == nil {
+		if c < 0 || int(c) >= a.radix {
+			return -1
+		}
+		return int(c)
+	}
+	if c < 0 || int(c) >= len(a.indices) {
+		return -1
+	}
+	return a.indices[c]
+}
+
+// ToChar converts the index i in [0; R-1] range to a character.
+func (a *Alphabet) ToChar(i int) rune {
+	if a.chars == nil {
+		return rune(i)
+	}
+	return a.chars[i]
+}
+
+// ToIndices converts the string s into indices.
+// It returns an error if a character is not in the alphabet.
+func (a *Alphabet) ToIndices(s string) ([]int, error) {
+	indices := make([]int, 0, len(s))
+	for _, c := range s {
+		i := a.ToIndex(c)
+		if i == -1 {
+			return nil, fmt.Errorf("alphabet: character %q is not in alphabet", c)
+		}
+		indices = append(indices, i)
+	}
+	return indices, nil
+}
+
+// ToChars converts the indices into a string.
+func (a *Alphabet) ToChars(indices []int) string {
+	b := make([]byte, 0, len(indices))
+	for _, i := range indices {
+		b = utf8.AppendRune(b, a.ToChar(i))
+	}
+	return string(b)
+}
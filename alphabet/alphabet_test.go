@@ -0,0 +1,88 @@
+package alphabet
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestAlphabet(t *testing.T) {
+	tests := []struct {
+		name  string
+		a     *Alphabet
+		radix int
+		lgR   int
+		char  rune
+		index int
+	}{
+		{"Binary", Binary, 2, 1, '1', 1},
+		{"DNA", DNA, 4, 2, 'G', 2},
+		{"Decimal", Decimal, 10, 4, '7', 7},
+		{"Lowercase", Lowercase, 26, 5, 'z', 25},
+		{"Uppercase", Uppercase, 26, 5, 'B', 1},
+		{"ASCII", ASCII, 128, 7, 'a', 97},
+		{"ExtendedASCII", ExtendedASCII, 256, 8, 'ÿ', 255},
+		{"Unicode", Unicode, 1114112, 21, 'ж', 1078},
+	}
+	for _, tc := range tests {
+		if got := tc.a.Radix(); got != tc.radix {
+			t.Errorf("%s.Radix() = %d, want %d", tc.name, got, tc.radix)
+		}
+		if got := tc.a.LgR(); got != tc.lgR {
+			t.Errorf("%s.LgR() = %d, want %d", tc.name, got, tc.lgR)
+		}
+		if got := tc.a.ToIndex(tc.char); got != tc.index {
+			t.Errorf("%s.ToIndex(%q) = %d, want %d", tc.name, tc.char, got, tc.index)
+		}
+		if got := tc.a.ToChar(tc.index); got != tc.char {
+			t.Errorf("%s.ToChar(%d) = %q, want %q", tc.name, tc.index, got, tc.char)
+		}
+	}
+}
+
+func TestAlphabetContains(t *testing.T) {
+	tests := []struct {
+		name string
+		a    *Alphabet
+		char rune
+		want bool
+	}{
+		{"DNA", DNA, 'A', true},
+		{"DNA", DNA, 'B', false},
+		{"DNA", DNA, 'ж', false},
+		{"DNA", DNA, -1, false},
+		{"ASCII", ASCII, 127, true},
+		{"ASCII", ASCII, 128, false},
+		{"Lowercase", Lowercase, 'A', false},
+	}
+	for _, tc := range tests {
+		if got := tc.a.Contains(tc.char); got != tc.want {
+			t.Errorf("%s.Contains(%q) = %v, want %v", tc.name, tc.char, got, tc.want)
+		}
+	}
+}
+
+func TestAlphabetIndices(t *testing.T) {
+	indices, err := DNA.ToIndices("GATTACA")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{2, 0, 3, 3, 0, 1, 0}; !slices.Equal(indices, want) {
+		t.Errorf("ToIndices(GATTACA) = %v, want %v", indices, want)
+	}
+	if got := DNA.ToChars(indices); got != "GATTACA" {
+		t.Errorf("ToChars(%v) = %q, want GATTACA", indices, got)
+	}
+
+	if _, err = DNA.ToIndices("GATTAXA"); err == nil {
+		t.Errorf("ToIndices(GATTAXA) expected error")
+	}
+}
+
+func TestNewRepeats(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("New(aba) expected panic")
+		}
+	}()
+	New("aba")
+}
@@ -0,0 +1,49 @@
+package bwt
+
+import "math/bits"
+
+// bitvector is an array of bits that answers rank queries in constant time
+// using the precomputed number of set bits before each 64-bit word.
+type bitvector struct {
+	words []uint64
+	// ranks[i] is the number of set bits in words[:i].
+	ranks []uint32
+}
+
+func newBitvector(n int) *bitvector {
+	return &bitvector{
+		words: make([]uint64, (n+63)/64),
+	}
+}
+
+// set sets the i-th bit.
+func (bv *bitvector) set(i int) {
+	bv.words[i/64] |= 1 << (i % 64)
+}
+
+// get reports whether the i-th bit is set.
+func (bv *bitvector) get(i int) bool {
+	return bv.words[i/64]&(1<<(i%64)) != 0
+}
+
+// build precomputes the ranks, it must be called after all the bits are set.
+func (bv *bitvector) build() {
+	bv.ranks = make([]uint32, len(bv.words)+1)
+	for i, w := range bv.words {
+		bv.ranks[i+1] = bv.ranks[i] + uint32(bits.OnesCount64(w))
+	}
+}
+
+// rank1 returns the number of set bits before the i-th bit.
+func (bv *bitvector) rank1(i int) int {
+	r := int(bv.ranks[i/64])
+	if i%64 != 0 {
+		r += bits.OnesCount64(bv.words[i/64] << (64 - i%64))
+	}
+	return r
+}
+
+// rank0 returns the number of unset bits before the i-th bit.
+func (bv *bitvector) rank0(i int) int {
+	return i - bv.rank1(i)
+}
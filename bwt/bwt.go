@@ -0,0 +1,107 @@
+/*
+Package bwt implements the Burrows-Wheeler transform (BWT) and FM-index, a compressed full-text index based on it.
+
+The BWT of a text is the last column of the matrix of all the text's rotations sorted lexicographically.
+The text is terminated with a unique sentinel character $ which is smaller than any other character,
+so the sorted rotations correspond to the sorted suffixes, and the BWT is obtained from the suffix array:
+the i-th character of the BWT is the one preceding the i-th smallest suffix.
+For example, the BWT of "banana$" is "annb$aa".
+
+The transform tends to group equal characters together (characters followed by the same context),
+so the transformed text is easier to compress, and yet it's reversible.
+*/
+package bwt
+
+import (
+	"errors"
+
+	"github.com/marselester/alg/suffixarray"
+)
+
+// ErrInvalid indicates that the data isn't a valid BWT, e.g., it was corrupted.
+var ErrInvalid = errors.New("bwt: invalid transform")
+
+// Encode returns the BWT of the text terminated with a sentinel.
+// The sentinel isn't stored in the result, instead its index in the BWT is returned as primary,
+// e.g., "annbaa" and 4 for "banana".
+func Encode(text []byte) (bwt []byte, primary int) {
+	n := len(text)
+	bwt = make([]byte, 0, n)
+	// The first row is the sentinel suffix "$" preceded by the last text character.
+	if n > 0 {
+		bwt = append(bwt, text[n-1])
+	}
+	for i, suffix := range suffixarray.SAIS(string(text)) {
+		if suffix == 0 {
+			primary = i + 1
+			continue
+		}
+		bwt = append(bwt, text[suffix-1])
+	}
+	return bwt, primary
+}
+
+/*
+Decode returns the text from its BWT and the index of the sentinel.
+It relies on the last-to-first (LF) mapping: the k-th occurrence of character c in the last column
+and the k-th occurrence of c in the first column are the same text character,
+because the rows starting with c are sorted by what follows c.
+The first column is the sorted BWT, so the row of the LF mapping is C[c]+k
+where C[c] is the number of characters smaller than c.
+Starting from the sentinel row whose last character is the last text character,
+the LF mapping walks the text backwards in linear time.
+
+The walk must reach the sentinel row (primary) exactly after reading n characters,
+otherwise the data isn't a valid BWT and ErrInvalid is returned.
+*/
+func Decode(bwt []byte, primary int) ([]byte, error) {
+	n := len(bwt)
+	if primary < 0 || primary > n {
+		return nil, ErrInvalid
+	}
+	// rows is the number of rows including the sentinel one.
+	rows := n + 1
+
+	// occ[i] is the number of occurrences of the i-th row's last character in the rows before i.
+	var count [256]int
+	occ := make([]int, rows)
+	for i := 0; i < rows; i++ {
+		if i == primary {
+			continue
+		}
+		c := bwt[row(i, primary)]
+		occ[i] = count[c]
+		count[c]++
+	}
+
+	// The sentinel goes first.
+	var c [256]int
+	sum := 1
+	for ch := range c {
+		c[ch] = sum
+		sum += count[ch]
+	}
+
+	text := make([]byte, n)
+	r := 0
+	for i := n - 1; i >= 0; i-- {
+		if r == primary {
+			return nil, ErrInvalid
+		}
+		ch := bwt[row(r, primary)]
+		text[i] = ch
+		r = c[ch] + occ[r]
+	}
+	if r != primary {
+		return nil, ErrInvalid
+	}
+	return text, nil
+}
+
+// row returns the index in the BWT without the sentinel of the i-th row's last character.
+func row(i, primary int) int {
+	if i > primary {
+		return i - 1
+	}
+	return i
+}
@@ -0,0 +1,110 @@
+package bwt
+
+import (
+	"bytes"
+	"math/rand"
+	"testing"
+)
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		text    string
+		bwt     string
+		primary int
+	}{
+		{"", "", 0},
+		{"a", "a", 1},
+		{"banana", "annbaa", 4},
+		{"abracadabra", "ardrcaaaabb", 3},
+		{"aaaa", "aaaa", 4},
+		{"baa", "aab", 3},
+	}
+
+	for _, tc := range tests {
+		bwt, primary := Encode([]byte(tc.text))
+		if string(bwt) != tc.bwt || primary != tc.primary {
+			t.Errorf("Encode(%q) = %q, %d, want %q, %d", tc.text, bwt, primary, tc.bwt, tc.primary)
+		}
+		if got, err := Decode(bwt, primary); err != nil || string(got) != tc.text {
+			t.Errorf("Decode(%q, %d) = %q, %v, want %q", bwt, primary, got, err, tc.text)
+		}
+	}
+}
+
+func TestDecodeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 500; i++ {
+		text := make([]byte, r.Intn(200))
+		sigma := 1 + r.Intn(256)
+		for j := range text {
+			text[j] = byte(r.Intn(sigma))
+		}
+
+		bwt, primary := Encode(text)
+		if got, err := Decode(bwt, primary); err != nil || !bytes.Equal(got, text) {
+			t.Fatalf("Decode(Encode(%q)) = %q, %v", text, got, err)
+		}
+	}
+}
+
+func TestDecodeInvalid(t *testing.T) {
+	tests := []struct {
+		bwt     string
+		primary int
+	}{
+		{"ba", 2},
+		{"a", 0},
+		{"a", 2},
+		{"a", -1},
+		{"abab", 2},
+	}
+	for _, tc := range tests {
+		if got, err := Decode([]byte(tc.bwt), tc.primary); err != ErrInvalid {
+			t.Errorf("Decode(%q, %d) = %q, %v, want %v", tc.bwt, tc.primary, got, err, ErrInvalid)
+		}
+	}
+
+	// Arbitrary data is either rejected or is a valid BWT of the decoded text.
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		data := make([]byte, r.Intn(8))
+		for j := range data {
+			data[j] = byte('a' + r.Intn(3))
+		}
+		primary := r.Intn(len(data) + 1)
+
+		text, err := Decode(data, primary)
+		if err != nil {
+			continue
+		}
+		if bwt, p := Encode(text); !bytes.Equal(bwt, data) || p != primary {
+			t.Fatalf("Decode(%q, %d) = %q, but its BWT is %q, %d", data, primary, text, bwt, p)
+		}
+	}
+}
+
+func TestWaveletTree(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		s := make([]byte, r.Intn(300))
+		for j := range s {
+			s[j] = "ACGTN$"[r.Intn(6)]
+		}
+		wt := newWaveletTree(s)
+
+		var count [256]int
+		for j := 0; j <= len(s); j++ {
+			for _, c := range []byte("ACGTNX") {
+				if got := wt.rank(c, j); got != count[c] {
+					t.Fatalf("rank(%q, %d) = %d, want %d in %q", c, j, got, count[c], s)
+				}
+			}
+			if j < len(s) {
+				if got := wt.access(j); got != s[j] {
+					t.Fatalf("access(%d) = %q, want %q in %q", j, got, s[j], s)
+				}
+				count[s[j]]++
+			}
+		}
+	}
+}
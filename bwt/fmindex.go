@@ -0,0 +1,163 @@
+package bwt
+
+import (
+	"sort"
+
+	"github.com/marselester/alg/suffixarray"
+)
+
+// DefaultSampleRate is the default distance between the text offsets whose suffix array values are kept.
+const DefaultSampleRate = 32
+
+/*
+FMIndex is a compressed full-text index (Ferragina and Manzini) that supports counting and locating
+occurrences of a pattern without keeping the text or the full suffix array.
+It consists of the BWT of the text stored in a wavelet tree that answers rank queries,
+the counts C[c] of characters smaller than c, and a sample of the suffix array.
+
+Backward search finds the range of the sorted rotations (rows) that start with the pattern
+processing the pattern from the last character to the first one:
+given the rows [sp, ep) starting with a pattern suffix P, the rows starting with cP are
+[C[c] + rank(c, sp), C[c] + rank(c, ep)) by the LF mapping.
+It takes m*log σ time to count the occurrences of a pattern of length m.
+
+To locate an occurrence, the LF mapping walks the text backwards from the row
+until it reaches a row whose suffix array value was sampled, so it takes at most sample rate steps.
+
+The index takes about n*log σ bits for the BWT plus n/rate integers for the samples,
+which is much smaller than the suffix array (a 64-bit integer per text character).
+
+Zero value is unusable, please use New.
+*/
+type FMIndex struct {
+	// n is the text length, there are n+1 rows including the sentinel one.
+	n int
+	// primary is the row whose last character is the sentinel.
+	primary int
+	// c[ch] is the number of characters (including the sentinel) smaller than ch.
+	c  [256]int
+	wt *waveletTree
+	// sampleRate is the distance between the sampled text offsets.
+	sampleRate int
+	// sampled marks the rows whose suffix array values are kept in samples in the row order.
+	sampled *bitvector
+	samples []int
+}
+type fmOption func(*FMIndex)
+
+// WithSampleRate defines how often the suffix array is sampled: every rate-th text offset is kept.
+// The larger the rate, the less memory the index takes, and the slower Locate is.
+func WithSampleRate(rate int) fmOption {
+	return func(fm *FMIndex) {
+		fm.sampleRate = max(rate, 1)
+	}
+}
+
+// New builds the FM-index of the text.
+// The construction takes linear time, though the temporary suffix array takes n integers.
+func New(text string, options ...fmOption) *FMIndex {
+	fm := FMIndex{
+		n:          len(text),
+		sampleRate: DefaultSampleRate,
+	}
+	for _, opt := range options {
+		opt(&fm)
+	}
+
+	sa := suffixarray.SAIS(text)
+	bwt := make([]byte, 0, fm.n)
+	fm.sampled = newBitvector(fm.n + 1)
+	// The first row is the sentinel suffix.
+	if fm.n > 0 {
+		bwt = append(bwt, text[fm.n-1])
+	}
+	fm.sample(0, fm.n)
+	for i, suffix := range sa {
+		fm.sample(i+1, suffix)
+		if suffix == 0 {
+			fm.primary = i + 1
+			continue
+		}
+		bwt = append(bwt, text[suffix-1])
+	}
+	fm.sampled.build()
+	fm.wt = newWaveletTree(bwt)
+
+	var count [256]int
+	for i := 0; i < fm.n; i++ {
+		count[text[i]]++
+	}
+	sum := 1
+	for ch := range fm.c {
+		fm.c[ch] = sum
+		sum += count[ch]
+	}
+	return &fm
+}
+
+// sample keeps the suffix of the row if it's a multiple of the sample rate.
+func (fm *FMIndex) sample(row, suffix int) {
+	if suffix%fm.sampleRate == 0 {
+		fm.sampled.set(row)
+		fm.samples = append(fm.samples, suffix)
+	}
+}
+
+// rank returns the number of occurrences of the character ch in the last column of the rows before i.
+func (fm *FMIndex) rank(ch byte, i int) int {
+	return fm.wt.rank(ch, row(i, fm.primary))
+}
+
+// lf returns the row of the rotation that starts with the last character of the i-th row.
+func (fm *FMIndex) lf(i int) int {
+	ch := fm.wt.access(row(i, fm.primary))
+	return fm.c[ch] + fm.rank(ch, i)
+}
+
+// interval returns the range [sp, ep) of the rows that start with the pattern using backward search.
+func (fm *FMIndex) interval(pattern string) (sp, ep int) {
+	if pattern == "" {
+		// All the rows except for the sentinel suffix.
+		return 1, fm.n + 1
+	}
+
+	sp, ep = 0, fm.n+1
+	for i := len(pattern) - 1; i >= 0 && sp < ep; i-- {
+		ch := pattern[i]
+		sp = fm.c[ch] + fm.rank(ch, sp)
+		ep = fm.c[ch] + fm.rank(ch, ep)
+	}
+	return sp, max(sp, ep)
+}
+
+// Count returns the number of occurrences of the pattern in the text.
+func (fm *FMIndex) Count(pattern string) int {
+	sp, ep := fm.interval(pattern)
+	return ep - sp
+}
+
+// Locate returns the offsets of all occurrences of the pattern in the text in increasing order.
+func (fm *FMIndex) Locate(pattern string) []int {
+	sp, ep := fm.interval(pattern)
+	if sp == ep {
+		return nil
+	}
+
+	offsets := make([]int, 0, ep-sp)
+	for i := sp; i < ep; i++ {
+		// The row of the suffix 0 is always sampled, so the walk never reaches the sentinel.
+		r, steps := i, 0
+		for !fm.sampled.get(r) {
+			r = fm.lf(r)
+			steps++
+		}
+		offsets = append(offsets, fm.samples[fm.sampled.rank1(r)]+steps)
+	}
+	sort.Ints(offsets)
+	return offsets
+}
+
+// Len returns the length of the indexed text.
+func (fm *FMIndex) Len() int {
+	return fm.n
+}
@@ -0,0 +1,153 @@
+package bwt
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/marselester/alg/suffixarray"
+)
+
+func TestFMIndex(t *testing.T) {
+	fm := New("it was the best of times it was the worst of times")
+	tests := []struct {
+		pattern string
+		want    []int
+	}{
+		{"it was", []int{0, 25}},
+		{"times", []int{19, 45}},
+		{"the ", []int{7, 32}},
+		{"s", []int{5, 13, 23, 30, 39, 49}},
+		{"best of times it", []int{11}},
+		{"age of wisdom", nil},
+		{"z", nil},
+	}
+
+	for _, tc := range tests {
+		if got := fm.Count(tc.pattern); got != len(tc.want) {
+			t.Errorf("Count(%q) = %d, want %d", tc.pattern, got, len(tc.want))
+		}
+		if got := fm.Locate(tc.pattern); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Locate(%q) = %v, want %v", tc.pattern, got, tc.want)
+		}
+	}
+}
+
+func TestFMIndexRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		text := randomText(r, "ACGT", r.Intn(200))
+		rate := 1 + r.Intn(10)
+		fm := New(text, WithSampleRate(rate))
+		idx := suffixarray.New(text)
+
+		for j := 0; j < 20; j++ {
+			pattern := randomText(r, "ACGT", r.Intn(5))
+			if got, want := fm.Count(pattern), idx.Count(pattern); got != want {
+				t.Fatalf("Count(%q) = %d, want %d in %q", pattern, got, want, text)
+			}
+			if got, want := fm.Locate(pattern), idx.Locate(pattern); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Locate(%q) = %v, want %v in %q (rate %d)", pattern, got, want, text, rate)
+			}
+		}
+	}
+}
+
+// genome returns a random DNA sequence with repeats, so the BWT has long runs.
+func genome(n int) string {
+	r := rand.New(rand.NewSource(1))
+	genes := make([]string, 50)
+	for i := range genes {
+		genes[i] = randomText(r, "ACGT", 20+r.Intn(200))
+	}
+
+	var b strings.Builder
+	for b.Len() < n {
+		if r.Intn(2) == 0 {
+			b.WriteString(genes[r.Intn(len(genes))])
+		} else {
+			b.WriteString(randomText(r, "ACGT", 1+r.Intn(50)))
+		}
+	}
+	return b.String()[:n]
+}
+
+// heapInUse returns the number of bytes in use after garbage collection.
+func heapInUse() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// BenchmarkMemory reports how many bytes per text character an index takes (the text itself isn't counted).
+func BenchmarkMemory(b *testing.B) {
+	text := genome(1 << 20)
+	indexes := []struct {
+		name  string
+		build func() any
+	}{
+		{"KeywordIndex", func() any { return suffixarray.New(text) }},
+		{"FMIndex/rate=4", func() any { return New(text, WithSampleRate(4)) }},
+		{"FMIndex/rate=32", func() any { return New(text, WithSampleRate(32)) }},
+		{"FMIndex/rate=256", func() any { return New(text, WithSampleRate(256)) }},
+	}
+
+	for _, idx := range indexes {
+		b.Run(idx.name, func(b *testing.B) {
+			var bytes int64
+			for i := 0; i < b.N; i++ {
+				before := heapInUse()
+				x := idx.build()
+				// GC might free more memory than the index keeps.
+				bytes += max(int64(heapInUse())-int64(before), 0)
+				runtime.KeepAlive(x)
+			}
+			b.ReportMetric(float64(bytes)/float64(b.N)/float64(len(text)), "B/char")
+		})
+	}
+}
+
+func BenchmarkSearch(b *testing.B) {
+	text := genome(1 << 20)
+	r := rand.New(rand.NewSource(1))
+	patterns := make([]string, 100)
+	for i := range patterns {
+		start := r.Intn(len(text) - 20)
+		patterns[i] = text[start : start+8+r.Intn(12)]
+	}
+
+	ki := suffixarray.New(text)
+	fm := New(text)
+	for _, op := range []string{"Count", "Locate"} {
+		b.Run(fmt.Sprintf("KeywordIndex/%s", op), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if op == "Count" {
+					ki.Count(patterns[i%len(patterns)])
+				} else {
+					ki.Locate(patterns[i%len(patterns)])
+				}
+			}
+		})
+		b.Run(fmt.Sprintf("FMIndex/%s", op), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if op == "Count" {
+					fm.Count(patterns[i%len(patterns)])
+				} else {
+					fm.Locate(patterns[i%len(patterns)])
+				}
+			}
+		})
+	}
+}
+
+func randomText(r *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,125 @@
+package bwt
+
+/*
+waveletTree answers rank queries: how many times a character occurs in a prefix of a sequence.
+Each node splits its alphabet range in half and keeps a bitvector marking which characters
+of its subsequence belong to the upper half (1) or the lower half (0).
+The rank of character c is found by descending from the root towards c's leaf,
+translating the position with rank0 or rank1 of the bitvector at each level.
+
+It takes n*log σ bits (plus the rank overhead) for a sequence of length n over an alphabet of size σ,
+and the rank query takes log σ time.
+*/
+type waveletTree struct {
+	// code maps a character to its index in the alphabet of the sequence, or -1 if it doesn't occur.
+	code [256]int
+	// chars maps a code back to the character.
+	chars []byte
+	root  *waveletNode
+}
+type waveletNode struct {
+	// lo and hi are the alphabet range (codes) of the node.
+	lo, hi int
+	bv     *bitvector
+	left   *waveletNode
+	right  *waveletNode
+}
+
+func newWaveletTree(s []byte) *waveletTree {
+	wt := waveletTree{}
+	var seen [256]bool
+	for _, c := range s {
+		seen[c] = true
+	}
+	sigma := 0
+	for c := range wt.code {
+		wt.code[c] = -1
+		if seen[c] {
+			wt.code[c] = sigma
+			wt.chars = append(wt.chars, byte(c))
+			sigma++
+		}
+	}
+	if sigma == 0 {
+		return &wt
+	}
+
+	codes := make([]byte, len(s))
+	for i, c := range s {
+		codes[i] = byte(wt.code[c])
+	}
+	wt.root = newWaveletNode(codes, 0, sigma-1, make([]byte, len(s)))
+	return &wt
+}
+
+// newWaveletNode builds a subtree for the sequence of codes in [lo, hi] range.
+// The aux slice is used to partition the codes into the lower and upper halves.
+func newWaveletNode(codes []byte, lo, hi int, aux []byte) *waveletNode {
+	n := waveletNode{lo: lo, hi: hi}
+	if lo == hi {
+		return &n
+	}
+
+	mid := lo + (hi-lo)/2
+	n.bv = newBitvector(len(codes))
+	left, right := 0, 0
+	for _, c := range codes {
+		if int(c) > mid {
+			right++
+		}
+	}
+	// Stable partition: lower half goes first, upper half after it.
+	right = len(codes) - right
+	for i, c := range codes {
+		if int(c) > mid {
+			n.bv.set(i)
+			aux[right] = c
+			right++
+		} else {
+			aux[left] = c
+			left++
+		}
+	}
+	n.bv.build()
+	copy(codes, aux[:len(codes)])
+
+	n.left = newWaveletNode(codes[:left], lo, mid, aux)
+	n.right = newWaveletNode(codes[left:], mid+1, hi, aux)
+	return &n
+}
+
+// rank returns the number of occurrences of the character c in the first i characters of the sequence.
+func (wt *waveletTree) rank(c byte, i int) int {
+	code := wt.code[c]
+	if code == -1 {
+		return 0
+	}
+
+	n := wt.root
+	for n.lo != n.hi {
+		mid := n.lo + (n.hi-n.lo)/2
+		if code <= mid {
+			i = n.bv.rank0(i)
+			n = n.left
+		} else {
+			i = n.bv.rank1(i)
+			n = n.right
+		}
+	}
+	return i
+}
+
+// access returns the i-th character of the sequence.
+func (wt *waveletTree) access(i int) byte {
+	n := wt.root
+	for n.lo != n.hi {
+		if n.bv.get(i) {
+			i = n.bv.rank1(i)
+			n = n.right
+		} else {
+			i = n.bv.rank0(i)
+			n = n.left
+		}
+	}
+	return wt.chars[n.lo]
+}
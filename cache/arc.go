@@ -0,0 +1,132 @@
+package cache
+
+// ARC represents Adaptive Replacement Cache which balances between recency and frequency
+// https://www.usenix.org/legacy/events/fast03/tech/full_papers/megiddo/megiddo.pdf.
+//
+// The cache maintains two LRU lists of cached keys:
+// t1 holds keys that were seen only once recently (recency),
+// t2 holds keys that were seen at least twice recently (frequency).
+// Each of them is accompanied by a ghost list (b1 and b2) which remembers
+// keys (not values) recently evicted from t1 and t2 respectively.
+// A hit in a ghost list means the cache would have had a hit if the corresponding list was bigger,
+// so the target size p of t1 is adapted: hits in b1 grow t1, hits in b2 shrink it.
+// One-time scans only pass through t1, so the frequently used keys in t2 survive.
+//
+// Zero value is unusable (it has zero capacity), please use NewARC.
+type ARC struct {
+	capacity int
+	// p is the target size of t1 list.
+	p int
+	// t1 and t2 contain cached keys, b1 and b2 contain ghost keys without values.
+	t1, t2, b1, b2 list
+	// st maps cache key to its entry in one of the four lists.
+	st map[string]*entry
+}
+
+// NewARC returns ARC cache that holds up to capacity keys.
+// It also remembers up to capacity of recently evicted keys.
+func NewARC(capacity int) *ARC {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &ARC{
+		capacity: capacity,
+		st:       make(map[string]*entry, 2*capacity),
+	}
+}
+
+// Get returns a value by key. A hit moves the key to the front of t2.
+func (c *ARC) Get(key string) ([]byte, bool) {
+	e, ok := c.st[key]
+	if !ok || e.list == &c.b1 || e.list == &c.b2 {
+		return nil, false
+	}
+	e.list.remove(e)
+	c.t2.pushFront(e)
+	return e.value, true
+}
+
+// Set puts a key into the cache.
+// A key found in a ghost list adapts the target size of t1 and goes to t2,
+// a new key goes to t1.
+func (c *ARC) Set(key string, value []byte) {
+	e, ok := c.st[key]
+	switch {
+	case ok && (e.list == &c.t1 || e.list == &c.t2):
+		e.value = value
+		e.list.remove(e)
+		c.t2.pushFront(e)
+	case ok && e.list == &c.b1:
+		c.p = min(c.capacity, c.p+max(c.b2.n/c.b1.n, 1))
+		c.replace(false)
+		c.b1.remove(e)
+		e.value = value
+		c.t2.pushFront(e)
+	case ok && e.list == &c.b2:
+		c.p = max(0, c.p-max(c.b1.n/c.b2.n, 1))
+		c.replace(true)
+		c.b2.remove(e)
+		e.value = value
+		c.t2.pushFront(e)
+	default:
+		c.evictFor()
+		e = &entry{key: key, value: value}
+		c.st[key] = e
+		c.t1.pushFront(e)
+	}
+}
+
+// Size returns the number of cached keys excluding the ghost ones.
+func (c *ARC) Size() int {
+	return c.t1.n + c.t2.n
+}
+
+// evictFor makes room for a key that wasn't found in any of the lists.
+func (c *ARC) evictFor() {
+	switch {
+	// t1 and b1 together have exactly capacity keys.
+	case c.t1.n+c.b1.n == c.capacity:
+		if c.t1.n < c.capacity {
+			c.drop(&c.b1)
+			c.replace(false)
+		} else {
+			c.drop(&c.t1)
+		}
+	// The cache is full, so ghost lists have to be trimmed.
+	case c.t1.n+c.t2.n+c.b1.n+c.b2.n >= c.capacity:
+		if c.t1.n+c.t2.n+c.b1.n+c.b2.n == 2*c.capacity {
+			c.drop(&c.b2)
+		}
+		c.replace(false)
+	}
+}
+
+// replace evicts a key from t1 or t2 (depending on target size p) when the cache is full
+// and remembers it in the corresponding ghost list.
+func (c *ARC) replace(inB2 bool) {
+	if c.t1.n+c.t2.n < c.capacity {
+		return
+	}
+
+	var e *entry
+	if c.t1.n > 0 && (c.t1.n > c.p || (inB2 && c.t1.n == c.p)) {
+		e = c.t1.back()
+		c.t1.remove(e)
+		c.b1.pushFront(e)
+	} else {
+		e = c.t2.back()
+		c.t2.remove(e)
+		c.b2.pushFront(e)
+	}
+	e.value = nil
+}
+
+// drop forgets the least recently used key of the list.
+func (c *ARC) drop(l *list) {
+	e := l.back()
+	if e == nil {
+		return
+	}
+	l.remove(e)
+	delete(c.st, e.key)
+}
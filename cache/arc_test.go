@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestARCScanResistance(t *testing.T) {
+	c := NewARC(4)
+	for _, key := range []string{"a", "b", "a", "b"} {
+		if _, ok := c.Get(key); !ok {
+			c.Set(key, []byte(key))
+		}
+	}
+	if c.t2.n != 2 {
+		t.Fatalf("t2 size = %d, want 2", c.t2.n)
+	}
+
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("scan%d", i)
+		c.Set(key, []byte(key))
+	}
+	for _, key := range []string{"a", "b"} {
+		if _, ok := c.Get(key); !ok {
+			t.Errorf("Get(%q) key was evicted by scan", key)
+		}
+	}
+	if c.Size() != 4 {
+		t.Errorf("Size() = %d, want 4", c.Size())
+	}
+	if n := c.t1.n + c.t2.n + c.b1.n + c.b2.n; n > 8 {
+		t.Errorf("directory size = %d, want <= 8", n)
+	}
+}
+
+func TestARCGhostHit(t *testing.T) {
+	c := NewARC(2)
+	c.Set("a", nil)
+	c.Get("a")
+	c.Set("b", nil)
+	// The key "b" is evicted to b1 ghost list.
+	c.Set("c", nil)
+	if e := c.st["b"]; e == nil || e.list != &c.b1 {
+		t.Fatalf("b isn't in b1")
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatalf("Get(b) found ghost key")
+	}
+
+	// A hit in b1 increases target size of t1 and brings the key to t2.
+	c.Set("b", []byte("B"))
+	if c.p != 1 {
+		t.Errorf("p = %d, want 1", c.p)
+	}
+	if e := c.st["b"]; e.list != &c.t2 {
+		t.Errorf("b isn't in t2")
+	}
+	if c.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", c.Size())
+	}
+}
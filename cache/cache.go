@@ -0,0 +1,23 @@
+/*
+Package cache implements caching strategies that decide which items to discard
+when a cache runs out of space: move-to-front, LRU, LFU, ARC, 2Q, CLOCK and W-TinyLFU.
+
+LRU is simple and works well when recently used items are likely to be reused,
+but a single scan over a large set of keys flushes the whole cache.
+LFU keeps popular items regardless of scans, though it adapts slowly
+when popularity changes. ARC, 2Q and W-TinyLFU combine recency and frequency
+to resist scans while still adapting to changing workloads.
+CLOCK approximates LRU with a cheaper hit path: it only sets a reference bit.
+*/
+package cache
+
+// Cache is a fixed size key-value store that evicts items according to
+// its replacement policy when there is no space left for a new key.
+type Cache interface {
+	// Get returns a value by key and reports whether the key was found.
+	Get(key string) (value []byte, ok bool)
+	// Set puts a key into the cache possibly evicting another key.
+	Set(key string, value []byte)
+	// Size returns the number of items in the cache.
+	Size() int
+}
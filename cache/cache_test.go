@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func TestCacheCapacity(t *testing.T) {
+	const capacity = 10
+	var tt = map[string]Cache{
+		"LRU":     NewLRU[string, []byte](capacity),
+		"LFU":     NewLFU(capacity),
+		"ARC":     NewARC(capacity),
+		"2Q":      NewTwoQueue(capacity),
+		"CLOCK":   NewClock(capacity),
+		"TinyLFU": NewTinyLFU(capacity),
+	}
+
+	for name, c := range tt {
+		t.Run(name, func(t *testing.T) {
+			r := rand.New(rand.NewSource(1))
+			for i := 0; i < 1000; i++ {
+				key := fmt.Sprintf("%d", r.Intn(30))
+				if _, ok := c.Get(key); !ok {
+					c.Set(key, []byte(key))
+				}
+				if c.Size() > capacity {
+					t.Fatalf("Size() = %d, want <= %d", c.Size(), capacity)
+				}
+
+				got, ok := c.Get(key)
+				if !ok || string(got) != key {
+					t.Fatalf("Get(%q) = %q, %v, want %q, true", key, got, ok, key)
+				}
+			}
+		})
+	}
+}
+
+func TestCacheSetUpdate(t *testing.T) {
+	var tt = map[string]Cache{
+		"LRU":     NewLRU[string, []byte](2),
+		"LFU":     NewLFU(2),
+		"ARC":     NewARC(2),
+		"2Q":      NewTwoQueue(2),
+		"CLOCK":   NewClock(2),
+		"TinyLFU": NewTinyLFU(2),
+	}
+
+	for name, c := range tt {
+		t.Run(name, func(t *testing.T) {
+			c.Set("a", []byte("A"))
+			c.Set("a", []byte("AA"))
+			if c.Size() != 1 {
+				t.Errorf("Size() = %d, want 1", c.Size())
+			}
+			got, ok := c.Get("a")
+			if !ok || string(got) != "AA" {
+				t.Errorf("Get(a) = %q, %v, want AA, true", got, ok)
+			}
+			if _, ok = c.Get("b"); ok {
+				t.Errorf("Get(b) = _, %v, want false", ok)
+			}
+		})
+	}
+}
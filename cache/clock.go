@@ -0,0 +1,84 @@
+package cache
+
+// Clock represents a cache that approximates LRU using the CLOCK algorithm
+// (second chance replacement). Keys are kept in a circular buffer with a reference bit
+// which is set on every access. When the cache is full, the clock hand sweeps
+// the buffer clearing reference bits until it finds a key that wasn't accessed since
+// the last sweep, and that key is replaced with the new one.
+// Unlike LRU, a cache hit doesn't reorder anything, it only sets a bit.
+//
+// Zero value is unusable (it has zero capacity), please use NewClock.
+type Clock struct {
+	slots []clockslot
+	// st maps cache key to its slot index.
+	st map[string]int
+	// hand is a slot index where the search for a victim starts.
+	hand int
+}
+type clockslot struct {
+	key   string
+	value []byte
+	// referenced indicates whether the key was accessed since the hand passed the slot.
+	referenced bool
+}
+
+// NewClock returns CLOCK cache that holds up to capacity keys.
+func NewClock(capacity int) *Clock {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &Clock{
+		slots: make([]clockslot, 0, capacity),
+		st:    make(map[string]int, capacity),
+	}
+}
+
+// Get returns a value by key and sets its reference bit.
+func (c *Clock) Get(key string) ([]byte, bool) {
+	i, ok := c.st[key]
+	if !ok {
+		return nil, false
+	}
+	c.slots[i].referenced = true
+	return c.slots[i].value, true
+}
+
+// Set puts a key into the cache.
+// A new key starts with a cleared reference bit, so it is evicted
+// during the next sweep of the hand unless it is accessed again.
+func (c *Clock) Set(key string, value []byte) {
+	if i, ok := c.st[key]; ok {
+		c.slots[i].value = value
+		c.slots[i].referenced = true
+		return
+	}
+
+	if len(c.slots) < cap(c.slots) {
+		c.st[key] = len(c.slots)
+		c.slots = append(c.slots, clockslot{key: key, value: value})
+		return
+	}
+
+	for c.slots[c.hand].referenced {
+		c.slots[c.hand].referenced = false
+		c.hand = next(c.hand, len(c.slots))
+	}
+	delete(c.st, c.slots[c.hand].key)
+	c.slots[c.hand] = clockslot{key: key, value: value}
+	c.st[key] = c.hand
+	c.hand = next(c.hand, len(c.slots))
+}
+
+// Size returns the number of keys in the cache.
+func (c *Clock) Size() int {
+	return len(c.slots)
+}
+
+// next moves the clock hand i to the following slot wrapping around at n.
+func next(i, n int) int {
+	i++
+	if i == n {
+		return 0
+	}
+	return i
+}
@@ -0,0 +1,48 @@
+package cache
+
+import "testing"
+
+func TestClockSet(t *testing.T) {
+	c := NewClock(3)
+	c.Set("a", []byte("A"))
+	c.Set("b", []byte("B"))
+	c.Set("c", []byte("C"))
+	// The key gets a second chance.
+	c.Get("a")
+	c.Set("d", []byte("D"))
+
+	var tt = []struct {
+		key  string
+		want bool
+	}{
+		{"a", true},
+		{"b", false},
+		{"c", true},
+		{"d", true},
+	}
+	for _, tc := range tt {
+		if _, got := c.Get(tc.key); got != tc.want {
+			t.Errorf("Get(%q) = _, %v, want %v", tc.key, got, tc.want)
+		}
+	}
+
+	if c.hand != 2 {
+		t.Errorf("hand = %d, want 2", c.hand)
+	}
+}
+
+func TestClockSweep(t *testing.T) {
+	c := NewClock(2)
+	c.Set("a", nil)
+	c.Set("b", nil)
+	c.Get("a")
+	c.Get("b")
+	// All keys are referenced, so the hand makes a full circle and evicts the first key.
+	c.Set("c", nil)
+	if _, ok := c.Get("a"); ok {
+		t.Errorf("Get(a) found evicted key")
+	}
+	if _, ok := c.Get("b"); !ok {
+		t.Errorf("Get(b) didn't find key")
+	}
+}
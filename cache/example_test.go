@@ -7,7 +7,7 @@ import (
 )
 
 func Example() {
-	c := cache.LRU{}
+	c := cache.LRU[string, []byte]{}
 	c.Set("a", []byte("A"))
 	c.Set("b", []byte("B"))
 	c.Set("c", []byte("C"))
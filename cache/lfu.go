@@ -0,0 +1,98 @@
+package cache
+
+// LFU represents a cache that discards the least frequently used items first.
+// Each key has an access counter, and keys with the same count are kept
+// in a linked list ordered by recency, so ties are broken by evicting
+// the least recently used key among the least frequently used ones.
+// Since the counter only grows by one, the cache keeps track of the minimal frequency
+// which makes Get, Set and eviction constant time operations
+// http://dhruvbird.com/lfu.pdf.
+//
+// Zero value is unusable (it has zero capacity), please use NewLFU.
+type LFU struct {
+	capacity int
+	// st maps cache key to its entry.
+	st map[string]*entry
+	// freq maps access count to a list of entries having that count.
+	freq map[int]*list
+	// minFreq is the smallest access count among the cached keys.
+	minFreq int
+}
+
+// NewLFU returns LFU cache that holds up to capacity keys.
+func NewLFU(capacity int) *LFU {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LFU{
+		capacity: capacity,
+		st:       make(map[string]*entry, capacity),
+		freq:     make(map[int]*list),
+	}
+}
+
+// Get returns a value by key and increments its access count.
+func (c *LFU) Get(key string) ([]byte, bool) {
+	e, ok := c.st[key]
+	if !ok {
+		return nil, false
+	}
+	c.touch(e)
+	return e.value, true
+}
+
+// Set puts a key into the cache.
+// The least frequently used key is evicted when the cache is full.
+func (c *LFU) Set(key string, value []byte) {
+	if e, ok := c.st[key]; ok {
+		e.value = value
+		c.touch(e)
+		return
+	}
+
+	if len(c.st) == c.capacity {
+		victim := c.freq[c.minFreq].back()
+		c.unlink(victim)
+		delete(c.st, victim.key)
+	}
+
+	e := &entry{key: key, value: value, freq: 1}
+	c.st[key] = e
+	c.link(e)
+	c.minFreq = 1
+}
+
+// Size returns the number of keys in the cache.
+func (c *LFU) Size() int {
+	return len(c.st)
+}
+
+// touch moves the entry to the list of the next frequency.
+func (c *LFU) touch(e *entry) {
+	c.unlink(e)
+	if e.freq == c.minFreq && c.freq[e.freq] == nil {
+		c.minFreq++
+	}
+	e.freq++
+	c.link(e)
+}
+
+// link adds the entry to the front of the list that corresponds to its frequency.
+func (c *LFU) link(e *entry) {
+	l, ok := c.freq[e.freq]
+	if !ok {
+		l = &list{}
+		c.freq[e.freq] = l
+	}
+	l.pushFront(e)
+}
+
+// unlink removes the entry from its frequency list.
+// The list is discarded once it becomes empty.
+func (c *LFU) unlink(e *entry) {
+	l := e.list
+	l.remove(e)
+	if l.n == 0 {
+		delete(c.freq, e.freq)
+	}
+}
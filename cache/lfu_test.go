@@ -0,0 +1,49 @@
+package cache
+
+import "testing"
+
+func TestLFUSet(t *testing.T) {
+	var tt = []struct {
+		name    string
+		access  []string
+		evicted string
+	}{
+		{"least frequent", []string{"a", "b", "a", "c"}, "b"},
+		{"least recent among least frequent", []string{"a", "b", "c"}, "a"},
+		{"frequent key survives", []string{"a", "a", "a", "b", "c", "d"}, "b"},
+		{"tie after promotion", []string{"a", "b", "a", "b", "c"}, "a"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			c := NewLFU(2)
+			for _, key := range tc.access {
+				if _, ok := c.Get(key); !ok {
+					c.Set(key, []byte(key))
+				}
+			}
+			if _, ok := c.Get(tc.evicted); ok {
+				t.Errorf("Get(%q) found evicted key", tc.evicted)
+			}
+		})
+	}
+}
+
+func TestLFUMinFreq(t *testing.T) {
+	c := NewLFU(3)
+	c.Set("a", nil)
+	c.Set("b", nil)
+	c.Get("a")
+	c.Get("b")
+	if c.minFreq != 2 {
+		t.Errorf("minFreq = %d, want 2", c.minFreq)
+	}
+	if _, ok := c.freq[1]; ok {
+		t.Errorf("empty frequency list 1 wasn't discarded")
+	}
+
+	c.Set("c", nil)
+	if c.minFreq != 1 {
+		t.Errorf("minFreq = %d, want 1", c.minFreq)
+	}
+}
@@ -0,0 +1,65 @@
+package cache
+
+// entry is a cached key-value pair which is stored in a doubly linked list.
+type entry struct {
+	key   string
+	value []byte
+	// freq is how many times the key was accessed, it's used by LFU.
+	freq int
+	// list is the list the entry belongs to, so policies which maintain several lists
+	// (for example, ARC) can tell where the key resides.
+	list       *list
+	prev, next *entry
+}
+
+// list is a circular doubly linked list with a sentinel node root.
+// The front of the list is root.next, the back of the list is root.prev.
+// Zero value is ready to use.
+type list struct {
+	root entry
+	// n is the number of entries in the list.
+	n int
+}
+
+// lazyInit makes the sentinel point to itself when the list is used for the first time.
+func (l *list) lazyInit() {
+	if l.root.next == nil {
+		l.root.next = &l.root
+		l.root.prev = &l.root
+	}
+}
+
+// pushFront inserts the entry at the front of the list.
+func (l *list) pushFront(e *entry) {
+	l.lazyInit()
+	e.prev = &l.root
+	e.next = l.root.next
+	l.root.next.prev = e
+	l.root.next = e
+	e.list = l
+	l.n++
+}
+
+// remove deletes the entry from the list.
+func (l *list) remove(e *entry) {
+	e.prev.next = e.next
+	e.next.prev = e.prev
+	e.prev = nil
+	e.next = nil
+	e.list = nil
+	l.n--
+}
+
+// moveToFront moves the entry to the front of the list.
+func (l *list) moveToFront(e *entry) {
+	l.remove(e)
+	l.pushFront(e)
+}
+
+// back returns the last entry of the list or nil if the list is empty.
+func (l *list) back() *entry {
+	if l.n == 0 {
+		return nil
+	}
+	return l.root.prev
+}
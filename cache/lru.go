@@ -1,26 +1,81 @@
 package cache
 
+import "iter"
+
 // LRU represents a cache that discards the least recently used items first.
 // Items are stored in order of access in a doubly linked list.
 // A previously unseen key is inserted at the front of the list.
 // A duplicate key is deleted from the list and reinsert at the beginning.
 // Remove operation deletes an element from the end and from the symbol table.
-type LRU struct {
-	first *lrunode
-	last  *lrunode
+//
+// Zero value has unlimited capacity, use NewLRU to create a cache of a fixed size
+// which evicts the least recently used key when it's full.
+type LRU[K comparable, V any] struct {
+	first *lrunode[K, V]
+	last  *lrunode[K, V]
 	// st maps cache key to its location in linked list.
-	st map[string]*lrunode
+	st map[K]*lrunode[K, V]
+	// capacity is the max number of keys in the cache, zero means no limit.
+	capacity int
+}
+type lrunode[K comparable, V any] struct {
+	key   K
+	value V
+	prev  *lrunode[K, V]
+	next  *lrunode[K, V]
+}
+
+// NewLRU returns LRU cache that holds up to capacity keys.
+func NewLRU[K comparable, V any](capacity int) *LRU[K, V] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &LRU[K, V]{capacity: capacity}
+}
+
+// Get returns a value by key and marks the key as the most recently used.
+func (c *LRU[K, V]) Get(key K) (value V, ok bool) {
+	n, ok := c.st[key]
+	if !ok {
+		return value, false
+	}
+	if c.first != n {
+		c.delete(n)
+		c.frontInsert(n)
+	}
+	return n.value, true
+}
+
+// Peek returns a value by key without updating the key's recency.
+func (c *LRU[K, V]) Peek(key K) (value V, ok bool) {
+	n, ok := c.st[key]
+	if !ok {
+		return value, false
+	}
+	return n.value, true
 }
-type lrunode struct {
-	key   string
-	value []byte
-	prev  *lrunode
-	next  *lrunode
+
+// Size returns the number of keys in the cache.
+func (c *LRU[K, V]) Size() int {
+	return len(c.st)
+}
+
+// All returns an iterator over key-value pairs from the most to the least recently used.
+// The iteration doesn't affect recency of the keys.
+func (c *LRU[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := c.first; n != nil; n = n.next {
+			if !yield(n.key, n.value) {
+				return
+			}
+		}
+	}
 }
 
 // Set puts a key into the cache.
-func (c *LRU) Set(key string, value []byte) {
-	newnode := lrunode{
+// The least recently used key is evicted when the cache is full.
+func (c *LRU[K, V]) Set(key K, value V) {
+	newnode := lrunode[K, V]{
 		key:   key,
 		value: value,
 	}
@@ -30,10 +85,14 @@ func (c *LRU) Set(key string, value []byte) {
 		c.delete(n)
 	}
 	c.frontInsert(&newnode)
+
+	if c.capacity > 0 && len(c.st) > c.capacity {
+		c.Remove()
+	}
 }
 
 // delete removes a node from the linked list.
-func (c *LRU) delete(n *lrunode) {
+func (c *LRU[K, V]) delete(n *lrunode[K, V]) {
 	delete(c.st, n.key)
 	// Delete middle node.
 	if c.first != n && c.last != n {
@@ -60,21 +119,25 @@ func (c *LRU) delete(n *lrunode) {
 }
 
 // frontInsert adds a new node at the beginning of the linked list.
-func (c *LRU) frontInsert(newnode *lrunode) {
+func (c *LRU[K, V]) frontInsert(newnode *lrunode[K, V]) {
+	newnode.prev = nil
+	newnode.next = nil
 	if c.first != nil {
 		newnode.next = c.first
 		c.first.prev = newnode
 	}
 	c.first = newnode
 	if c.st == nil {
-		c.st = make(map[string]*lrunode)
+		c.st = make(map[K]*lrunode[K, V])
+	}
+	if c.last == nil {
 		c.last = newnode
 	}
 	c.st[newnode.key] = newnode
 }
 
 // Remove deletes and returns the least recently accessed key-value pair.
-func (c *LRU) Remove() (key string, value []byte) {
+func (c *LRU[K, V]) Remove() (key K, value V) {
 	if c.last == nil {
 		return
 	}
@@ -6,7 +6,7 @@ import (
 )
 
 // cachedLRUKeyVal returns a list of key-value pairs from LRU linked list for testing.
-func cachedLRUKeyVal(c *LRU) []string {
+func cachedLRUKeyVal(c *LRU[string, []byte]) []string {
 	var kv []string
 	for n := c.first; n != nil; n = n.next {
 		kv = append(kv, fmt.Sprintf("%s:%s", n.key, n.value))
@@ -16,7 +16,7 @@ func cachedLRUKeyVal(c *LRU) []string {
 
 func TestLRUSet(t *testing.T) {
 	var tt = []struct {
-		cache LRU
+		cache LRU[string, []byte]
 		items []struct {
 			key   string
 			value []byte
@@ -24,7 +24,7 @@ func TestLRUSet(t *testing.T) {
 		want []string
 	}{
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -34,7 +34,7 @@ func TestLRUSet(t *testing.T) {
 			want: []string{"a:A"},
 		},
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -45,7 +45,7 @@ func TestLRUSet(t *testing.T) {
 			want: []string{"a:A", "b:B"},
 		},
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -56,7 +56,7 @@ func TestLRUSet(t *testing.T) {
 			want: []string{"a:AAA"},
 		},
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -68,7 +68,7 @@ func TestLRUSet(t *testing.T) {
 			want: []string{"a:AAA", "b:B"},
 		},
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -94,7 +94,7 @@ func TestLRUSet(t *testing.T) {
 
 func TestLRURemove(t *testing.T) {
 	var tt = []struct {
-		cache LRU
+		cache LRU[string, []byte]
 		items []struct {
 			key   string
 			value []byte
@@ -102,12 +102,12 @@ func TestLRURemove(t *testing.T) {
 		want string
 	}{
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: nil,
 			want:  ":",
 		},
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -117,7 +117,7 @@ func TestLRURemove(t *testing.T) {
 			want: "a:A",
 		},
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -128,7 +128,7 @@ func TestLRURemove(t *testing.T) {
 			want: "b:B",
 		},
 		{
-			cache: LRU{},
+			cache: LRU[string, []byte]{},
 			items: []struct {
 				key   string
 				value []byte
@@ -152,3 +152,47 @@ func TestLRURemove(t *testing.T) {
 		}
 	}
 }
+
+func TestLRUGet(t *testing.T) {
+	c := NewLRU[string, []byte](2)
+	c.Set("a", []byte("A"))
+	c.Set("b", []byte("B"))
+	// Key "a" becomes the most recently used, so "b" is evicted.
+	if got, ok := c.Get("a"); !ok || string(got) != "A" {
+		t.Errorf("Get(a) = %q, %v, want A, true", got, ok)
+	}
+	c.Set("c", []byte("C"))
+
+	want := []string{"c:C", "a:A"}
+	if got := cachedLRUKeyVal(c); !equal(got, want) {
+		t.Errorf("Set() = %v, want %v", got, want)
+	}
+	if c.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", c.Size())
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Errorf("Get(b) found evicted key")
+	}
+}
+
+func TestLRUPeek(t *testing.T) {
+	c := NewLRU[int, string](2)
+	c.Set(1, "one")
+	c.Set(2, "two")
+	// Peek doesn't make key 1 recently used, so it is evicted.
+	if got, ok := c.Peek(1); !ok || got != "one" {
+		t.Errorf("Peek(1) = %q, %v, want one, true", got, ok)
+	}
+	c.Set(3, "three")
+	if _, ok := c.Peek(1); ok {
+		t.Errorf("Peek(1) found evicted key")
+	}
+
+	var got []string
+	for k, v := range c.All() {
+		got = append(got, fmt.Sprintf("%d:%s", k, v))
+	}
+	if want := []string{"3:three", "2:two"}; !equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
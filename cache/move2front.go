@@ -1,32 +1,34 @@
-// Package cache implements caching using move-to-front strategy.
 package cache
 
+import "iter"
+
 // MoveToFront represents a cache that stores keys using move-to-front strategy,
 // where items that have been recently accessed are more likely to be reaccessed.
 // A previously unseen key is inserted at the front of the list.
 // A duplicate key is deleted from the list and reinsert at the beginning.
-type MoveToFront struct {
-	first *node
+// A found key is moved to the front of the list as well.
+type MoveToFront[K comparable, V any] struct {
+	first *node[K, V]
 }
-type node struct {
-	key   string
-	value []byte
-	next  *node
+type node[K comparable, V any] struct {
+	key   K
+	value V
+	next  *node[K, V]
 }
 
 // Set puts a key into the cache.
-func (c *MoveToFront) Set(key string, value []byte) {
-	newnode := node{
+func (c *MoveToFront[K, V]) Set(key K, value V) {
+	newnode := node[K, V]{
 		key:   key,
 		value: value,
 	}
-	c.deleteDuplicate(key)
+	c.delete(key)
 	c.frontInsert(&newnode)
 }
 
-// deleteDuplicate deletes a node by given key found in the linked list.
-func (c *MoveToFront) deleteDuplicate(key string) {
-	var prev *node
+// delete deletes a node by given key found in the linked list and returns it.
+func (c *MoveToFront[K, V]) delete(key K) *node[K, V] {
+	var prev *node[K, V]
 	for n := c.first; n != nil; {
 		if n.key == key {
 			if prev == nil {
@@ -34,25 +36,48 @@ func (c *MoveToFront) deleteDuplicate(key string) {
 			} else {
 				prev.next = n.next // Delete middle node.
 			}
-			break
+			return n
 		}
 		prev = n
 		n = n.next
 	}
+	return nil
 }
 
 // frontInsert adds a new node at the beginning of the linked list.
-func (c *MoveToFront) frontInsert(newnode *node) {
+func (c *MoveToFront[K, V]) frontInsert(newnode *node[K, V]) {
 	newnode.next = c.first
 	c.first = newnode
 }
 
-// Get retrieves a key from the cache.
-func (c *MoveToFront) Get(key string) []byte {
+// Get retrieves a key from the cache and moves it to the front of the list.
+func (c *MoveToFront[K, V]) Get(key K) (value V, ok bool) {
+	n := c.delete(key)
+	if n == nil {
+		return value, false
+	}
+	c.frontInsert(n)
+	return n.value, true
+}
+
+// Peek retrieves a key from the cache without moving it to the front of the list.
+func (c *MoveToFront[K, V]) Peek(key K) (value V, ok bool) {
 	for n := c.first; n != nil; n = n.next {
 		if n.key == key {
-			return n.value
+			return n.value, true
+		}
+	}
+	return value, false
+}
+
+// All returns an iterator over key-value pairs from the most to the least recently used.
+// The iteration doesn't affect recency of the keys.
+func (c *MoveToFront[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for n := c.first; n != nil; n = n.next {
+			if !yield(n.key, n.value) {
+				return
+			}
 		}
 	}
-	return nil
 }
@@ -18,7 +18,7 @@ func equal(s1, s2 []string) bool {
 }
 
 // cachedKeyVal returns a list of key-value pairs from linked list for testing.
-func cachedKeyVal(first *node) []string {
+func cachedKeyVal(first *node[string, []byte]) []string {
 	var kv []string
 	for n := first; n != nil; n = n.next {
 		kv = append(kv, fmt.Sprintf("%s:%s", n.key, n.value))
@@ -28,20 +28,20 @@ func cachedKeyVal(first *node) []string {
 
 func TestMoveToFrontSet(t *testing.T) {
 	var tt = []struct {
-		cache MoveToFront
+		cache MoveToFront[string, []byte]
 		key   string
 		value []byte
 		want  []string
 	}{
 		{
-			cache: MoveToFront{},
+			cache: MoveToFront[string, []byte]{},
 			key:   "a",
 			value: []byte("A"),
 			want:  []string{"a:A"},
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "b",
 					value: []byte("B"),
 				},
@@ -51,8 +51,8 @@ func TestMoveToFrontSet(t *testing.T) {
 			want:  []string{"a:A", "b:B"},
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "a",
 					value: []byte("A"),
 				},
@@ -62,11 +62,11 @@ func TestMoveToFrontSet(t *testing.T) {
 			want:  []string{"a:AAA"},
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "b",
 					value: []byte("B"),
-					next: &node{
+					next: &node[string, []byte]{
 						key:   "a",
 						value: []byte("A"),
 					},
@@ -77,11 +77,11 @@ func TestMoveToFrontSet(t *testing.T) {
 			want:  []string{"a:AAA", "b:B"},
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "a",
 					value: []byte("A"),
-					next: &node{
+					next: &node[string, []byte]{
 						key:   "b",
 						value: []byte("B"),
 					},
@@ -104,18 +104,18 @@ func TestMoveToFrontSet(t *testing.T) {
 
 func TestMoveToFrontGet(t *testing.T) {
 	var tt = []struct {
-		cache MoveToFront
+		cache MoveToFront[string, []byte]
 		key   string
 		want  string
 	}{
 		{
-			cache: MoveToFront{},
+			cache: MoveToFront[string, []byte]{},
 			key:   "a",
 			want:  "",
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "b",
 					value: []byte("B"),
 				},
@@ -124,8 +124,8 @@ func TestMoveToFrontGet(t *testing.T) {
 			want: "",
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "b",
 					value: []byte("B"),
 				},
@@ -134,11 +134,11 @@ func TestMoveToFrontGet(t *testing.T) {
 			want: "B",
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "b",
 					value: []byte("B"),
-					next: &node{
+					next: &node[string, []byte]{
 						key:   "a",
 						value: []byte("A"),
 					},
@@ -148,11 +148,11 @@ func TestMoveToFrontGet(t *testing.T) {
 			want: "A",
 		},
 		{
-			cache: MoveToFront{
-				first: &node{
+			cache: MoveToFront[string, []byte]{
+				first: &node[string, []byte]{
 					key:   "a",
 					value: []byte("A"),
-					next: &node{
+					next: &node[string, []byte]{
 						key:   "b",
 						value: []byte("B"),
 					},
@@ -164,9 +164,44 @@ func TestMoveToFrontGet(t *testing.T) {
 	}
 
 	for _, tc := range tt {
-		got := string(tc.cache.Get(tc.key))
-		if got != tc.want {
-			t.Errorf("Get(%q) = %v, want %v", tc.key, got, tc.want)
+		v, ok := tc.cache.Get(tc.key)
+		got := string(v)
+		if got != tc.want || ok != (tc.want != "") {
+			t.Errorf("Get(%q) = %v, %v, want %v", tc.key, got, ok, tc.want)
 		}
+		if ok && tc.cache.first.key != tc.key {
+			t.Errorf("Get(%q) didn't move key to front", tc.key)
+		}
+	}
+}
+
+func TestMoveToFrontPeek(t *testing.T) {
+	c := MoveToFront[string, int]{}
+	c.Set("a", 1)
+	c.Set("b", 2)
+	c.Set("c", 3)
+
+	if got, ok := c.Peek("a"); !ok || got != 1 {
+		t.Errorf("Peek(a) = %d, %v, want 1, true", got, ok)
+	}
+	if _, ok := c.Peek("d"); ok {
+		t.Errorf("Peek(d) found missing key")
+	}
+
+	var keys []string
+	for k := range c.All() {
+		keys = append(keys, k)
+	}
+	if want := []string{"c", "b", "a"}; !equal(keys, want) {
+		t.Errorf("All() = %v, want %v", keys, want)
+	}
+
+	c.Get("a")
+	keys = keys[:0]
+	for k := range c.All() {
+		keys = append(keys, k)
+	}
+	if want := []string{"a", "c", "b"}; !equal(keys, want) {
+		t.Errorf("All() after Get = %v, want %v", keys, want)
 	}
 }
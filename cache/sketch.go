@@ -0,0 +1,89 @@
+package cache
+
+import "hash/fnv"
+
+// sketchDepth is the number of rows (hash functions) in count-min sketch.
+const sketchDepth = 4
+
+// cmSketch is a count-min sketch that estimates how often keys were accessed
+// using a fixed amount of memory https://en.wikipedia.org/wiki/Count%E2%80%93min_sketch.
+// A key is hashed into one counter per row, and its frequency is the smallest of those counters,
+// so the estimate can only be higher than the true count due to hash collisions.
+//
+// Counters saturate at 15 (4 bits are enough to tell popular keys apart).
+// Once the number of recorded accesses reaches sample size, all counters are halved,
+// so the sketch ages out keys that used to be popular.
+type cmSketch struct {
+	rows [sketchDepth][]uint8
+	// mask is used instead of remainder to turn a hash into a column, since the width is a power of 2.
+	mask uint32
+	// additions is the number of recorded accesses since the last reset.
+	additions int
+	// sampleSize is the number of accesses after which the counters are halved.
+	sampleSize int
+}
+
+// newCMSketch returns count-min sketch sized for a cache of capacity keys.
+// Each row has at least 8 counters per key to keep collisions rare.
+func newCMSketch(capacity int) *cmSketch {
+	width := 1
+	for width < 8*capacity {
+		width <<= 1
+	}
+	s := cmSketch{
+		mask:       uint32(width - 1),
+		sampleSize: 10 * capacity,
+	}
+	for i := range s.rows {
+		s.rows[i] = make([]uint8, width)
+	}
+	return &s
+}
+
+// indexes returns a column for each row using double hashing:
+// two halves of 64-bit hash value are combined to simulate independent hash functions.
+func (s *cmSketch) indexes(key string) [sketchDepth]uint32 {
+	h := fnv.New64a()
+	h.Write([]byte(key))
+	sum := h.Sum64()
+	h1, h2 := uint32(sum), uint32(sum>>32)
+
+	var idx [sketchDepth]uint32
+	for i := range idx {
+		idx[i] = (h1 + uint32(i)*h2) & s.mask
+	}
+	return idx
+}
+
+// increment records an access of the key.
+func (s *cmSketch) increment(key string) {
+	for i, col := range s.indexes(key) {
+		if s.rows[i][col] < 15 {
+			s.rows[i][col]++
+		}
+	}
+
+	s.additions++
+	if s.additions == s.sampleSize {
+		s.reset()
+	}
+}
+
+// estimate returns the estimated access count of the key.
+func (s *cmSketch) estimate(key string) uint8 {
+	var f uint8 = 15
+	for i, col := range s.indexes(key) {
+		f = min(f, s.rows[i][col])
+	}
+	return f
+}
+
+// reset halves all the counters.
+func (s *cmSketch) reset() {
+	for i := range s.rows {
+		for j := range s.rows[i] {
+			s.rows[i][j] >>= 1
+		}
+	}
+	s.additions /= 2
+}
@@ -0,0 +1,117 @@
+package cache
+
+// TinyLFU represents W-TinyLFU cache which admits a new key into the main cache
+// only if it's estimated to be accessed more often than the key it would replace
+// https://arxiv.org/abs/1512.00727.
+//
+// The access frequencies are approximated with a count-min sketch,
+// so there is no need to keep the history of evicted keys.
+// A new key first lands in a small window LRU (1% of the capacity) which lets bursts of
+// recently used keys build up their frequency. A key evicted from the window is a candidate
+// to enter the main cache which is a segmented LRU: the probation segment
+// holds keys that were accessed once in the main cache, the protected segment (80% of the main cache)
+// holds keys accessed at least twice. When the main cache is full,
+// the candidate competes with the least recently used key of the probation segment,
+// and the one with lower frequency is evicted.
+//
+// Zero value is unusable (it has zero capacity), please use NewTinyLFU.
+type TinyLFU struct {
+	// windowSize, protectedSize and mainSize are max number of keys
+	// in the window, in the protected segment and in the main cache.
+	windowSize    int
+	protectedSize int
+	mainSize      int
+	// window is LRU list of new keys, probation and protected are LRU segments of the main cache.
+	window, probation, protected list
+	// st maps cache key to its entry in one of the three lists.
+	st     map[string]*entry
+	sketch *cmSketch
+}
+
+// NewTinyLFU returns W-TinyLFU cache that holds up to capacity keys.
+func NewTinyLFU(capacity int) *TinyLFU {
+	if capacity < 1 {
+		capacity = 1
+	}
+	c := TinyLFU{
+		windowSize: max(1, capacity/100),
+		st:         make(map[string]*entry, capacity),
+		sketch:     newCMSketch(capacity),
+	}
+	c.mainSize = capacity - c.windowSize
+	c.protectedSize = c.mainSize * 8 / 10
+	return &c
+}
+
+// Get returns a value by key. Every call is recorded in the frequency sketch,
+// including misses, since a missed key is likely to be put into the cache.
+func (c *TinyLFU) Get(key string) ([]byte, bool) {
+	c.sketch.increment(key)
+	e, ok := c.st[key]
+	if !ok {
+		return nil, false
+	}
+	c.hit(e)
+	return e.value, true
+}
+
+// Set puts a key into the cache.
+// Note, Set doesn't count as an access in the frequency sketch because
+// it usually follows a missed Get.
+func (c *TinyLFU) Set(key string, value []byte) {
+	if e, ok := c.st[key]; ok {
+		e.value = value
+		c.hit(e)
+		return
+	}
+
+	e := &entry{key: key, value: value}
+	c.st[key] = e
+	c.window.pushFront(e)
+	if c.window.n <= c.windowSize {
+		return
+	}
+
+	candidate := c.window.back()
+	c.window.remove(candidate)
+	if c.probation.n+c.protected.n < c.mainSize {
+		c.probation.pushFront(candidate)
+		return
+	}
+
+	victim := c.probation.back()
+	if victim == nil {
+		victim = c.protected.back()
+	}
+	if victim == nil || c.sketch.estimate(candidate.key) <= c.sketch.estimate(victim.key) {
+		delete(c.st, candidate.key)
+		return
+	}
+	victim.list.remove(victim)
+	delete(c.st, victim.key)
+	c.probation.pushFront(candidate)
+}
+
+// Size returns the number of keys in the cache.
+func (c *TinyLFU) Size() int {
+	return len(c.st)
+}
+
+// hit moves the entry to the front of its list.
+// A key from the probation segment is promoted to the protected one,
+// and if the protected segment overflows, its least recently used key
+// is demoted back to the probation segment.
+func (c *TinyLFU) hit(e *entry) {
+	if e.list != &c.probation {
+		e.list.moveToFront(e)
+		return
+	}
+
+	c.probation.remove(e)
+	c.protected.pushFront(e)
+	if c.protected.n > c.protectedSize {
+		demoted := c.protected.back()
+		c.protected.remove(demoted)
+		c.probation.pushFront(demoted)
+	}
+}
@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestTinyLFUAdmission(t *testing.T) {
+	c := NewTinyLFU(100)
+	access := func(key string) {
+		if _, ok := c.Get(key); !ok {
+			c.Set(key, []byte(key))
+		}
+	}
+
+	// One-time keys are not admitted since they are accessed less often than the hot keys.
+	for round := 0; round < 20; round++ {
+		for i := 0; i < 50; i++ {
+			access(fmt.Sprintf("hot%d", i))
+		}
+		for i := 0; i < 100; i++ {
+			access(fmt.Sprintf("scan%d-%d", round, i))
+		}
+	}
+
+	var hits int
+	for i := 0; i < 50; i++ {
+		if _, ok := c.Get(fmt.Sprintf("hot%d", i)); ok {
+			hits++
+		}
+	}
+	if hits != 50 {
+		t.Errorf("hot keys hits = %d, want 50", hits)
+	}
+	if c.Size() != 100 {
+		t.Errorf("Size() = %d, want 100", c.Size())
+	}
+}
+
+func TestCMSketch(t *testing.T) {
+	s := newCMSketch(16)
+	for i := 0; i < 5; i++ {
+		s.increment("a")
+	}
+	s.increment("b")
+
+	if got := s.estimate("a"); got < 5 {
+		t.Errorf("estimate(a) = %d, want >= 5", got)
+	}
+	if got := s.estimate("b"); got < 1 {
+		t.Errorf("estimate(b) = %d, want >= 1", got)
+	}
+
+	for i := 0; i < 20; i++ {
+		s.increment("c")
+	}
+	if got := s.estimate("c"); got > 15 {
+		t.Errorf("estimate(c) = %d, want <= 15", got)
+	}
+
+	s.reset()
+	if got := s.estimate("c"); got > 7 {
+		t.Errorf("estimate(c) after reset = %d, want <= 7", got)
+	}
+}
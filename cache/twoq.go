@@ -0,0 +1,109 @@
+package cache
+
+// TwoQueue represents 2Q cache which filters out keys accessed only once
+// before they can pollute the main LRU list
+// http://www.vldb.org/conf/1994/P439.PDF.
+//
+// A new key goes to in FIFO queue. If the key gets evicted from there,
+// it's remembered in out FIFO queue of ghost keys (without values).
+// Only a key that is requested again while it's remembered in out
+// is considered hot and promoted to the main LRU list.
+// Hence a scan over many keys only churns in and out queues.
+//
+// Zero value is unusable (it has zero capacity), please use NewTwoQueue.
+type TwoQueue struct {
+	capacity int
+	// inSize is the max number of keys in the in queue (Kin in the paper).
+	inSize int
+	// outSize is the max number of ghost keys in the out queue (Kout in the paper).
+	outSize int
+	// in and out are FIFO queues, main is LRU list (A1in, A1out, Am in the paper).
+	in, out, main list
+	// st maps cache key to its entry in one of the three lists.
+	st map[string]*entry
+}
+
+// NewTwoQueue returns 2Q cache that holds up to capacity keys.
+// The in queue takes a quarter of the capacity,
+// and the out queue remembers half as many evicted keys as the capacity,
+// as recommended by the paper.
+func NewTwoQueue(capacity int) *TwoQueue {
+	if capacity < 1 {
+		capacity = 1
+	}
+	return &TwoQueue{
+		capacity: capacity,
+		inSize:   max(1, capacity/4),
+		outSize:  max(1, capacity/2),
+		st:       make(map[string]*entry, capacity+capacity/2),
+	}
+}
+
+// Get returns a value by key. A hit in the main list moves the key to its front,
+// a hit in the in queue doesn't change the order since the key is likely
+// to be requested again shortly after the first access (correlated reference).
+func (c *TwoQueue) Get(key string) ([]byte, bool) {
+	e, ok := c.st[key]
+	if !ok || e.list == &c.out {
+		return nil, false
+	}
+	if e.list == &c.main {
+		c.main.moveToFront(e)
+	}
+	return e.value, true
+}
+
+// Set puts a key into the cache.
+// A key remembered in the out queue is promoted to the main list,
+// an unseen key goes to the in queue.
+func (c *TwoQueue) Set(key string, value []byte) {
+	e, ok := c.st[key]
+	switch {
+	case ok && e.list == &c.main:
+		e.value = value
+		c.main.moveToFront(e)
+	case ok && e.list == &c.in:
+		e.value = value
+	case ok && e.list == &c.out:
+		c.out.remove(e)
+		c.reclaim()
+		e.value = value
+		c.main.pushFront(e)
+	default:
+		c.reclaim()
+		e = &entry{key: key, value: value}
+		c.st[key] = e
+		c.in.pushFront(e)
+	}
+}
+
+// Size returns the number of cached keys excluding the ghost ones.
+func (c *TwoQueue) Size() int {
+	return c.in.n + c.main.n
+}
+
+// reclaim frees a slot for a new key when the cache is full.
+// It evicts the oldest key from the in queue if the queue exceeds its size
+// and moves that key to the out queue, otherwise it evicts from the main list.
+func (c *TwoQueue) reclaim() {
+	if c.in.n+c.main.n < c.capacity {
+		return
+	}
+
+	if c.in.n > c.inSize || c.main.n == 0 {
+		e := c.in.back()
+		c.in.remove(e)
+		e.value = nil
+		c.out.pushFront(e)
+		if c.out.n > c.outSize {
+			ghost := c.out.back()
+			c.out.remove(ghost)
+			delete(c.st, ghost.key)
+		}
+		return
+	}
+
+	e := c.main.back()
+	c.main.remove(e)
+	delete(c.st, e.key)
+}
@@ -0,0 +1,47 @@
+package cache
+
+import "testing"
+
+func TestTwoQueueSet(t *testing.T) {
+	c := NewTwoQueue(4)
+	for _, key := range []string{"a", "b", "c", "d", "e"} {
+		c.Set(key, []byte(key))
+	}
+	// The oldest key left the in queue and is remembered as a ghost.
+	if e := c.st["a"]; e == nil || e.list != &c.out {
+		t.Fatalf("a isn't in out queue")
+	}
+	if _, ok := c.Get("a"); ok {
+		t.Fatalf("Get(a) found ghost key")
+	}
+
+	// The key requested again is considered hot.
+	c.Set("a", []byte("A"))
+	if e := c.st["a"]; e.list != &c.main {
+		t.Errorf("a isn't in main list")
+	}
+	if c.Size() != 4 {
+		t.Errorf("Size() = %d, want 4", c.Size())
+	}
+	if c.out.n > c.outSize {
+		t.Errorf("out queue size = %d, want <= %d", c.out.n, c.outSize)
+	}
+}
+
+func TestTwoQueueScanResistance(t *testing.T) {
+	c := NewTwoQueue(4)
+	c.Set("a", []byte("A"))
+	c.Set("b", nil)
+	c.Set("c", nil)
+	c.Set("d", nil)
+	c.Set("e", nil)
+	c.Set("a", []byte("A"))
+
+	for _, key := range []string{"s1", "s2", "s3", "s4", "s5", "s6"} {
+		c.Set(key, nil)
+	}
+	got, ok := c.Get("a")
+	if !ok || string(got) != "A" {
+		t.Errorf("Get(a) = %q, %v, want A, true", got, ok)
+	}
+}
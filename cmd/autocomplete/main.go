@@ -0,0 +1,100 @@
+/*
+Program autocomplete loads weighted terms from a file and suggests the heaviest terms
+that start with a prefix read from standard input, one prefix per line.
+The file has a term per line prefixed with its weight and a tab,
+e.g., the book's cities.txt or wiktionary.txt (the first line with the number of terms is skipped).
+
+	14608512	Shanghai, China
+	13076300	Buenos Aires, Argentina
+	12691836	Mumbai, India
+
+With -fuzzy flag the terms are matched within the given edit distance of the prefix,
+so typos are tolerated.
+
+	$ echo Mumbia | autocomplete -file=cities.txt -k=3 -fuzzy=1
+	12691836	Mumbai, India
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/marselester/alg/strsearch"
+)
+
+func main() {
+	file := flag.String("file", "", "File of weighted terms: weight<TAB>term per line.")
+	k := flag.Int("k", 10, "Number of suggestions to show.")
+	fuzzy := flag.Int("fuzzy", 0, "Max edit distance between the prefix and the terms, 1 or 2 tolerate typos.")
+	flag.Parse()
+
+	f, err := os.Open(*file)
+	if err != nil {
+		log.Fatalf("autocomplete: %v", err)
+	}
+	a, err := load(f)
+	f.Close()
+	if err != nil {
+		log.Fatalf("autocomplete: %v", err)
+	}
+
+	if err = run(a, os.Stdin, os.Stdout, *k, *fuzzy); err != nil {
+		log.Fatalf("autocomplete: %v", err)
+	}
+}
+
+// load reads weighted terms in "weight<TAB>term" format.
+// The leading whitespace is ignored, and so is the first line if it has no tab (number of terms).
+func load(r io.Reader) (*strsearch.Autocomplete, error) {
+	a := strsearch.Autocomplete{}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		weight, term, ok := strings.Cut(text, "\t")
+		if !ok {
+			if line == 1 {
+				continue
+			}
+			return nil, fmt.Errorf("line %d: expected weight<TAB>term", line)
+		}
+		w, err := strconv.Atoi(strings.TrimSpace(weight))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		a.Put(term, w)
+	}
+	return &a, scanner.Err()
+}
+
+// run reads prefixes from r and writes up to k suggestions for each of them to w
+// followed by an empty line.
+func run(a *strsearch.Autocomplete, r io.Reader, w io.Writer, k, maxEdits int) error {
+	scanner := bufio.NewScanner(r)
+	bw := bufio.NewWriter(w)
+	for scanner.Scan() {
+		var cc []strsearch.Completion
+		if maxEdits > 0 {
+			cc = a.Fuzzy(scanner.Text(), k, maxEdits)
+		} else {
+			cc = a.TopK(scanner.Text(), k)
+		}
+		for _, c := range cc {
+			fmt.Fprintf(bw, "%d\t%s\n", c.Weight, c.Term)
+		}
+		fmt.Fprintln(bw)
+		if err := bw.Flush(); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
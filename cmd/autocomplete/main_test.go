@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const cities = `93827
+  14608512	Shanghai, China
+  13076300	Buenos Aires, Argentina
+  12691836	Mumbai, India
+  12294193	Mexico City, Distrito Federal, Mexico
+  10381222	Moscow, Russia
+`
+
+func TestLoad(t *testing.T) {
+	a, err := load(strings.NewReader(cities))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Size() != 5 {
+		t.Errorf("load() got %d terms, want 5", a.Size())
+	}
+	if w, _ := a.Weight("Moscow, Russia"); w != 10381222 {
+		t.Errorf("load() Moscow weight %d, want 10381222", w)
+	}
+
+	if _, err = load(strings.NewReader("1\tfizz\nbazz\n")); err == nil {
+		t.Errorf("load() expected error for a line without a tab")
+	}
+	if _, err = load(strings.NewReader("1\tfizz\nx\tbazz\n")); err == nil {
+		t.Errorf("load() expected error for invalid weight")
+	}
+}
+
+func TestRun(t *testing.T) {
+	a, err := load(strings.NewReader(cities))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		input    string
+		k        int
+		maxEdits int
+		want     string
+	}{
+		{"M\n", 2, 0, "12691836\tMumbai, India\n12294193\tMexico City, Distrito Federal, Mexico\n\n"},
+		{"Mo\nX\n", 2, 0, "10381222\tMoscow, Russia\n\n\n"},
+		{"Mumbia\n", 2, 1, "12691836\tMumbai, India\n\n"},
+	}
+	for _, tc := range tests {
+		var out bytes.Buffer
+		if err = run(a, strings.NewReader(tc.input), &out, tc.k, tc.maxEdits); err != nil {
+			t.Fatal(err)
+		}
+		if got := out.String(); got != tc.want {
+			t.Errorf("run(%q) = %q, want %q", tc.input, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+// Program cachesim replays a trace of keys from standard input
+// and reports hit ratio of every cache replacement policy.
+// Keys are separated by whitespace, a key that is not found in a cache is put there.
+// For example, a trace of skewed popular keys mixed with a scan:
+//
+//	$ awk 'BEGIN { for (i = 0; i < 100000; i++) { print int(rand()*rand()*50); if (i%3==0) print "scan" i } }' | cachesim -size=20
+//	policy     hits       ratio
+//	LRU        47101      35.33%
+//	LFU        71482      53.61%
+//	ARC        65671      49.25%
+//	2Q         61390      46.04%
+//	CLOCK      50199      37.65%
+//	W-TinyLFU  71457      53.59%
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/marselester/alg/cache"
+)
+
+func main() {
+	size := flag.Int("size", 1000, "cache capacity")
+	flag.Parse()
+
+	policies := []struct {
+		name  string
+		cache cache.Cache
+		hits  int
+	}{
+		{name: "LRU", cache: cache.NewLRU[string, []byte](*size)},
+		{name: "LFU", cache: cache.NewLFU(*size)},
+		{name: "ARC", cache: cache.NewARC(*size)},
+		{name: "2Q", cache: cache.NewTwoQueue(*size)},
+		{name: "CLOCK", cache: cache.NewClock(*size)},
+		{name: "W-TinyLFU", cache: cache.NewTinyLFU(*size)},
+	}
+
+	var requests int
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Split(bufio.ScanWords)
+	for scanner.Scan() {
+		key := scanner.Text()
+		requests++
+		for i := range policies {
+			p := &policies[i]
+			if _, ok := p.cache.Get(key); ok {
+				p.hits++
+				continue
+			}
+			p.cache.Set(key, nil)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatalf("cachesim: %v", err)
+	}
+
+	fmt.Printf("%-10s %-10s %s\n", "policy", "hits", "ratio")
+	for _, p := range policies {
+		var ratio float64
+		if requests > 0 {
+			ratio = float64(p.hits) / float64(requests) * 100
+		}
+		fmt.Printf("%-10s %-10d %.2f%%\n", p.name, p.hits, ratio)
+	}
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/marselester/alg/stack"
+)
+
+// SyntaxError describes a malformed expression and a position (byte offset) where it was detected.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// Token kinds.
+const (
+	tokenNumber = iota
+	tokenVariable
+	tokenFunction
+	tokenOperator
+	tokenLeftParen
+	tokenRightParen
+	tokenComma
+)
+
+// token is a lexeme of an expression.
+type token struct {
+	kind int
+	text string
+	// pos is a byte offset of the token in the expression.
+	pos int
+	// num is a value of the number token.
+	num float64
+	// argc is the number of arguments of the function token.
+	// For a left parenthesis that opens a function call, it counts the arguments parsed so far.
+	argc int
+}
+
+// neg is a text of unary minus operator in postfix notation,
+// since "-" is reserved for subtraction.
+const neg = "neg"
+
+// operator describes precedence and associativity of an operator.
+type operator struct {
+	prec       int
+	rightAssoc bool
+}
+
+// operators maps an operator to its precedence and associativity.
+// Unary minus binds tighter than multiplication but looser than exponentiation,
+// so -2^2 is -4.
+var operators = map[string]operator{
+	"+": {prec: 1},
+	"-": {prec: 1},
+	"*": {prec: 2},
+	"/": {prec: 2},
+	neg: {prec: 3, rightAssoc: true},
+	"^": {prec: 4, rightAssoc: true},
+}
+
+// functions maps a function name to its implementation and the min and max number of arguments
+// (-1 means there is no upper limit).
+var functions = map[string]struct {
+	minArgs, maxArgs int
+	call             func(args []float64) (float64, error)
+}{
+	"sqrt": {1, 1, func(args []float64) (float64, error) {
+		if args[0] < 0 {
+			return 0, fmt.Errorf("square root of negative number %s", formatNumber(args[0]))
+		}
+		return math.Sqrt(args[0]), nil
+	}},
+	"min": {1, -1, func(args []float64) (float64, error) {
+		m := args[0]
+		for _, v := range args[1:] {
+			m = math.Min(m, v)
+		}
+		return m, nil
+	}},
+	"max": {1, -1, func(args []float64) (float64, error) {
+		m := args[0]
+		for _, v := range args[1:] {
+			m = math.Max(m, v)
+		}
+		return m, nil
+	}},
+}
+
+// lex splits the expression into tokens.
+// A name followed by a left parenthesis is a function, otherwise it's a variable.
+func lex(expr string) ([]token, error) {
+	var tokens []token
+	for i := 0; i < len(expr); {
+		c := expr[i]
+		switch {
+		case c == ' ' || c == '\t':
+			i++
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLeftParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRightParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		case strings.IndexByte("+-*/^", c) != -1:
+			tokens = append(tokens, token{kind: tokenOperator, text: string(c), pos: i})
+			i++
+		case c == '.' || isDigit(c):
+			j := i
+			for j < len(expr) && (expr[j] == '.' || isDigit(expr[j])) {
+				j++
+			}
+			num, err := strconv.ParseFloat(expr[i:j], 64)
+			if err != nil {
+				return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("invalid number %q", expr[i:j])}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: expr[i:j], pos: i, num: num})
+			i = j
+		case c == '_' || unicode.IsLetter(rune(c)):
+			j := i
+			for j < len(expr) && (expr[j] == '_' || isDigit(expr[j]) || unicode.IsLetter(rune(expr[j]))) {
+				j++
+			}
+			t := token{kind: tokenVariable, text: expr[i:j], pos: i}
+			// Look ahead for a left parenthesis skipping whitespace.
+			k := j
+			for k < len(expr) && (expr[k] == ' ' || expr[k] == '\t') {
+				k++
+			}
+			if k < len(expr) && expr[k] == '(' {
+				t.kind = tokenFunction
+			}
+			tokens = append(tokens, t)
+			i = j
+		default:
+			return nil, &SyntaxError{Pos: i, Msg: fmt.Sprintf("unexpected character %q", c)}
+		}
+	}
+	return tokens, nil
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// toPostfix converts infix expression into postfix (reverse Polish) notation
+// using Dijkstra's Shunting-yard algorithm:
+//
+//   - send operands (numbers and variables) straight to the output
+//   - push functions and left parentheses onto the operator stack
+//   - before pushing an operator, pop onto the output the operators from the stack
+//     that have higher precedence, or the same precedence if the operator is left-associative
+//   - on a comma, pop operators until a left parenthesis, so the function argument is complete
+//   - on a right parenthesis, pop operators until a left parenthesis, discard the parenthesis,
+//     and pop the function if there is one
+//   - at the end of input, pop the remaining operators onto the output
+//
+// The parser tracks whether an operand or an operator is expected next
+// to tell unary minus from subtraction and to report malformed expressions.
+func toPostfix(expr string) ([]token, error) {
+	tokens, err := lex(expr)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		output []token
+		ops    stack.ArrayOf[token]
+		// expectOperand is true at the start of the expression, after an operator,
+		// a left parenthesis and a comma.
+		expectOperand = true
+	)
+	for i, t := range tokens {
+		switch t.kind {
+		case tokenNumber, tokenVariable:
+			if !expectOperand {
+				return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q, expected operator", t.text)}
+			}
+			output = append(output, t)
+			expectOperand = false
+
+		case tokenFunction:
+			if !expectOperand {
+				return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q, expected operator", t.text)}
+			}
+			if _, ok := functions[t.text]; !ok {
+				return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unknown function %q", t.text)}
+			}
+			ops.Push(t)
+
+		case tokenOperator:
+			if expectOperand {
+				if t.text != "-" {
+					return nil, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("unexpected %q, expected operand", t.text)}
+				}
+				// Unary minus is a prefix operator, it doesn't pop anything
+				// since its operand hasn't been seen yet.
+				t.text = neg
+				ops.Push(t)
+				continue
+			}
+			o1 := operators[t.text]
+			for {
+				top, ok := ops.Peek()
+				if !ok || top.kind != tokenOperator {
+					break
+				}
+				o2 := operators[top.text]
+				if o2.prec < o1.prec || (o2.prec == o1.prec && o1.rightAssoc) {
+					break
+				}
+				output = append(output, ops.Pop())
+			}
+			ops.Push(t)
+			expectOperand = true
+
+		case tokenLeftParen:
+			if !expectOperand {
+				return nil, &SyntaxError{Pos: t.pos, Msg: `unexpected "(", expected operator`}
+			}
+			if i > 0 && tokens[i-1].kind == tokenFunction {
+				t.argc = 1
+			}
+			ops.Push(t)
+
+		case tokenComma:
+			if expectOperand {
+				return nil, &SyntaxError{Pos: t.pos, Msg: `unexpected ",", expected operand`}
+			}
+			for {
+				top, ok := ops.Peek()
+				if !ok {
+					return nil, &SyntaxError{Pos: t.pos, Msg: `unexpected "," outside of function call`}
+				}
+				if top.kind == tokenLeftParen {
+					break
+				}
+				output = append(output, ops.Pop())
+			}
+			// The left parenthesis must open a function call.
+			paren := ops.Pop()
+			if paren.argc == 0 {
+				return nil, &SyntaxError{Pos: t.pos, Msg: `unexpected "," outside of function call`}
+			}
+			paren.argc++
+			ops.Push(paren)
+			expectOperand = true
+
+		case tokenRightParen:
+			if expectOperand {
+				return nil, &SyntaxError{Pos: t.pos, Msg: `unexpected ")", expected operand`}
+			}
+			for {
+				top, ok := ops.Peek()
+				if !ok {
+					return nil, &SyntaxError{Pos: t.pos, Msg: `unmatched ")"`}
+				}
+				if top.kind == tokenLeftParen {
+					break
+				}
+				output = append(output, ops.Pop())
+			}
+			paren := ops.Pop()
+			if paren.argc > 0 {
+				fn := ops.Pop()
+				fn.argc = paren.argc
+				output = append(output, fn)
+			}
+		}
+	}
+
+	if expectOperand {
+		return nil, &SyntaxError{Pos: len(expr), Msg: "unexpected end of expression, expected operand"}
+	}
+	for ops.Size() > 0 {
+		t := ops.Pop()
+		if t.kind == tokenLeftParen {
+			return nil, &SyntaxError{Pos: t.pos, Msg: `unmatched "("`}
+		}
+		output = append(output, t)
+	}
+	return output, nil
+}
+
+// evalPostfix computes the value of the expression in postfix notation.
+// Variables are looked up in vars.
+func evalPostfix(postfix []token, vars map[string]float64) (float64, error) {
+	var operands stack.ArrayOf[float64]
+	for _, t := range postfix {
+		switch t.kind {
+		case tokenNumber:
+			operands.Push(t.num)
+
+		case tokenVariable:
+			v, ok := vars[t.text]
+			if !ok {
+				return 0, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("undefined variable %q", t.text)}
+			}
+			operands.Push(v)
+
+		case tokenOperator:
+			if t.text == neg {
+				operands.Push(-operands.Pop())
+				continue
+			}
+			b, a := operands.Pop(), operands.Pop()
+			res, err := apply(t.text, a, b)
+			if err != nil {
+				return 0, &SyntaxError{Pos: t.pos, Msg: err.Error()}
+			}
+			operands.Push(res)
+
+		case tokenFunction:
+			fn := functions[t.text]
+			if t.argc < fn.minArgs || (fn.maxArgs != -1 && t.argc > fn.maxArgs) {
+				return 0, &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf("%s takes %s, got %d", t.text, arity(fn.minArgs, fn.maxArgs), t.argc)}
+			}
+			args := make([]float64, t.argc)
+			for i := t.argc - 1; i >= 0; i-- {
+				args[i] = operands.Pop()
+			}
+			res, err := fn.call(args)
+			if err != nil {
+				return 0, &SyntaxError{Pos: t.pos, Msg: err.Error()}
+			}
+			operands.Push(res)
+		}
+	}
+	return operands.Pop(), nil
+}
+
+// apply computes a binary operation a op b.
+func apply(op string, a, b float64) (float64, error) {
+	switch op {
+	case "+":
+		return a + b, nil
+	case "-":
+		return a - b, nil
+	case "*":
+		return a * b, nil
+	case "/":
+		if b == 0 {
+			return 0, fmt.Errorf("division by zero")
+		}
+		return a / b, nil
+	case "^":
+		return math.Pow(a, b), nil
+	}
+	return 0, fmt.Errorf("unknown operation %q", op)
+}
+
+// arity describes the number of function arguments, e.g., "1 argument" or "at least 1 argument".
+func arity(minArgs, maxArgs int) string {
+	noun := "arguments"
+	if minArgs == 1 {
+		noun = "argument"
+	}
+	if minArgs == maxArgs {
+		return fmt.Sprintf("%d %s", minArgs, noun)
+	}
+	return fmt.Sprintf("at least %d %s", minArgs, noun)
+}
+
+// formatPostfix returns the postfix notation as a string, e.g., "1 2 3 * +".
+// Functions are followed by the number of arguments, e.g., "1 2 max/2".
+func formatPostfix(postfix []token) string {
+	ss := make([]string, len(postfix))
+	for i, t := range postfix {
+		if t.kind == tokenFunction {
+			ss[i] = fmt.Sprintf("%s/%d", t.text, t.argc)
+		} else {
+			ss[i] = t.text
+		}
+	}
+	return strings.Join(ss, " ")
+}
+
+// formatNumber returns the shortest representation of the number.
+func formatNumber(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
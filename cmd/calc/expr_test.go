@@ -0,0 +1,134 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestToPostfix(t *testing.T) {
+	tt := []struct {
+		expr string
+		want string
+	}{
+		{"1", "1"},
+		{"1 + 2 * 3", "1 2 3 * +"},
+		{"(1 + 2) * 3", "1 2 + 3 *"},
+		{"1 - 2 - 3", "1 2 - 3 -"},
+		{"2 ^ 3 ^ 2", "2 3 2 ^ ^"},
+		{"-2^2", "2 2 ^ neg"},
+		{"2 ^ -1", "2 1 neg ^"},
+		{"2 * -3", "2 3 neg *"},
+		{"--x", "x neg neg"},
+		{"sqrt(16)", "16 sqrt/1"},
+		{"max(1, 2 + 3, min(x, y))", "1 2 3 + x y min/2 max/3"},
+		{"(1 + ((2 + 3) * (4 * 5)))", "1 2 3 + 4 5 * * +"},
+	}
+	for _, tc := range tt {
+		pf, err := toPostfix(tc.expr)
+		if err != nil {
+			t.Errorf("toPostfix(%q) error: %v", tc.expr, err)
+			continue
+		}
+		if got := formatPostfix(pf); got != tc.want {
+			t.Errorf("toPostfix(%q) = %q, want %q", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestToPostfixError(t *testing.T) {
+	tt := []struct {
+		expr string
+		pos  int
+	}{
+		{"", 0},
+		{"1 +", 3},
+		{"1 + * 2", 4},
+		{"1 2", 2},
+		{"(1 + 2", 0},
+		{"1 + 2)", 5},
+		{"()", 1},
+		{"1, 2", 1},
+		{"(1, 2)", 2},
+		{"max(1,)", 6},
+		{"foo(1)", 0},
+		{"1 $ 2", 2},
+		{"1.2.3", 0},
+		{"2 (3)", 2},
+	}
+	for _, tc := range tt {
+		_, err := toPostfix(tc.expr)
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("toPostfix(%q) error = %v, want SyntaxError", tc.expr, err)
+			continue
+		}
+		if serr.Pos != tc.pos {
+			t.Errorf("toPostfix(%q) error position = %d, want %d (%v)", tc.expr, serr.Pos, tc.pos, err)
+		}
+	}
+}
+
+func TestEvalPostfix(t *testing.T) {
+	vars := map[string]float64{"x": 3, "y": -1}
+	tt := []struct {
+		expr string
+		want float64
+	}{
+		{"1 + 2 * 3", 7},
+		{"(1 + 2) * 3", 9},
+		{"10 - 4 - 3", 3},
+		{"2 ^ 3 ^ 2", 512},
+		{"-2^2", -4},
+		{"(-2)^2", 4},
+		{"2 ^ -1", 0.5},
+		{"7 / 2", 3.5},
+		{"sqrt(16) + 1", 5},
+		{"min(x, y, 0)", -1},
+		{"max(x, y) * -x", -9},
+		{"x * (y + 4)", 9},
+		{"(1 + ((2 + 3) * (4 * 5)))", 101},
+	}
+	for _, tc := range tt {
+		pf, err := toPostfix(tc.expr)
+		if err != nil {
+			t.Errorf("toPostfix(%q) error: %v", tc.expr, err)
+			continue
+		}
+		got, err := evalPostfix(pf, vars)
+		if err != nil {
+			t.Errorf("evalPostfix(%q) error: %v", tc.expr, err)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("evalPostfix(%q) = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestEvalPostfixError(t *testing.T) {
+	tt := []struct {
+		expr string
+		pos  int
+	}{
+		{"1 / (2 - 2)", 2},
+		{"1 + z", 4},
+		{"sqrt(1, 2)", 0},
+		{"sqrt(-1)", 0},
+	}
+	for _, tc := range tt {
+		pf, err := toPostfix(tc.expr)
+		if err != nil {
+			t.Errorf("toPostfix(%q) error: %v", tc.expr, err)
+			continue
+		}
+		_, err = evalPostfix(pf, nil)
+		var serr *SyntaxError
+		if !errors.As(err, &serr) {
+			t.Errorf("evalPostfix(%q) error = %v, want SyntaxError", tc.expr, err)
+			continue
+		}
+		if serr.Pos != tc.pos {
+			t.Errorf("evalPostfix(%q) error position = %d, want %d (%v)", tc.expr, serr.Pos, tc.pos, err)
+		}
+	}
+}
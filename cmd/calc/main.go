@@ -14,7 +14,18 @@ The Dijkstra's two-stack arithmetic expression-evaluation algorithm:
 	and push onto the operand stack the result of applying that operator to those operands.
 
 After the final right parenthesis has been processed, there is one value on the stack,
-which is the value of the expression.
+which is the value of the expression. The two-stack algorithm is used when -dijkstra flag is set.
+
+By default the program parses a full expression language with Dijkstra's Shunting-yard algorithm,
+so parentheses are only needed to override the operators precedence:
+
+	calc -expr '-2^2 + max(x, sqrt(16)) * 3' -var x=5 -postfix
+	2 2 ^ neg x 16 sqrt/1 max/2 3 * +
+	11
+
+Operators in order of increasing precedence are + and -, * and /, unary minus, ^ (right-associative).
+Functions sqrt(x), min(x, ...), max(x, ...) and variables defined with -var flags are supported.
+Malformed expressions are reported with the position where the error was detected.
 */
 package main
 
@@ -23,6 +34,8 @@ import (
 	"flag"
 	"fmt"
 	"log"
+	"os"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -31,11 +44,65 @@ import (
 
 func main() {
 	expr := flag.String("expr", "", "Arithmetic expression to evaluate, e.g., (1 + ((2 + 3) * (4 * 5))).")
+	dijkstra := flag.Bool("dijkstra", false, "Evaluate fully parenthesized expression with Dijkstra's two-stack algorithm.")
+	postfix := flag.Bool("postfix", false, "Print the expression in postfix notation.")
+	vars := variables{}
+	flag.Var(vars, "var", "Variable assignment name=value, can be repeated.")
 	flag.Parse()
 
+	if *dijkstra {
+		twoStack(*expr)
+		return
+	}
+
+	pf, err := toPostfix(*expr)
+	if err == nil && *postfix {
+		fmt.Println(formatPostfix(pf))
+	}
+	var res float64
+	if err == nil {
+		res, err = evalPostfix(pf, vars)
+	}
+	if err != nil {
+		if serr, ok := err.(*SyntaxError); ok {
+			fmt.Fprintf(os.Stderr, "%s\n%s^\n", *expr, strings.Repeat(" ", serr.Pos))
+		}
+		log.Fatalf("calc: %v", err)
+	}
+	fmt.Println(formatNumber(res))
+}
+
+// variables are set by -var flags, e.g., -var x=1 -var y=2.
+type variables map[string]float64
+
+func (vv variables) String() string {
+	ss := make([]string, 0, len(vv))
+	for name, v := range vv {
+		ss = append(ss, name+"="+formatNumber(v))
+	}
+	sort.Strings(ss)
+	return strings.Join(ss, ",")
+}
+
+func (vv variables) Set(s string) error {
+	name, value, ok := strings.Cut(s, "=")
+	if !ok || name == "" {
+		return fmt.Errorf("expected name=value, got %q", s)
+	}
+	v, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return err
+	}
+	vv[name] = v
+	return nil
+}
+
+// twoStack evaluates fully parenthesized expression using Dijkstra's two-stack algorithm
+// and prints each computed operation.
+func twoStack(expr string) {
 	operand := stack.Array{}
 	operator := stack.Array{}
-	for _, t := range tokenize(*expr) {
+	for _, t := range tokenize(expr) {
 		switch t {
 		// Ignore left parentheses.
 		case "(":
@@ -0,0 +1,105 @@
+/*
+Program compress compresses standard input to standard output (or expands it back with -d flag)
+using one of the algorithms of compress package, see https://godoc.org/github.com/marselester/alg/compress.
+
+	$ compress -alg=huffman < tale.txt > tale.huff
+	$ compress -alg=huffman -d < tale.huff > tale.txt
+
+The algorithms are:
+
+	rle is run-length encoding of bit runs, good for bitmaps
+	huffman is Huffman coding of bytes
+	lzw is Lempel-Ziv-Welch coding with 12-bit codewords
+	bwt is bzip2-like Burrows-Wheeler transform, move-to-front and Huffman coding pipeline
+
+With -v flag the compression ratio is printed to standard error.
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/marselester/alg/compress"
+)
+
+func main() {
+	alg := flag.String("alg", "bwt", "Compression algorithm: rle, huffman, lzw, bwt.")
+	expand := flag.Bool("d", false, "Expand compressed input.")
+	verbose := flag.Bool("v", false, "Print compression ratio to stderr.")
+	flag.Parse()
+
+	c, err := codec(*alg)
+	if err != nil {
+		log.Fatalf("compress: %v", err)
+	}
+
+	in := &countingReader{r: bufio.NewReader(os.Stdin)}
+	w := bufio.NewWriter(os.Stdout)
+	out := &countingWriter{w: w}
+	if err = run(c, out, in, *expand); err != nil {
+		log.Fatalf("compress: %v", err)
+	}
+	if err = w.Flush(); err != nil {
+		log.Fatalf("compress: %v", err)
+	}
+
+	if *verbose && in.n > 0 {
+		compressed, original := out.n, in.n
+		if *expand {
+			compressed, original = in.n, out.n
+		}
+		fmt.Fprintf(os.Stderr, "%d -> %d bytes (%.1f%%)\n", original, compressed, 100*float64(compressed)/float64(original))
+	}
+}
+
+// codec returns the codec by the algorithm name.
+func codec(alg string) (compress.Codec, error) {
+	switch alg {
+	case "rle":
+		return compress.RunLength{}, nil
+	case "huffman":
+		return compress.Huffman{}, nil
+	case "lzw":
+		return compress.LZW{}, nil
+	case "bwt":
+		return compress.BurrowsWheeler{}, nil
+	}
+	return nil, fmt.Errorf("unknown algorithm %q", alg)
+}
+
+// run compresses or expands src into dst.
+func run(c compress.Codec, dst io.Writer, src io.Reader, expand bool) error {
+	if expand {
+		return c.Expand(dst, src)
+	}
+	return c.Compress(dst, src)
+}
+
+// countingReader counts the bytes read.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (cr *countingReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.n += int64(n)
+	return n, err
+}
+
+// countingWriter counts the bytes written.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun(t *testing.T) {
+	text := strings.Repeat("it was the best of times it was the worst of times\n", 10)
+	for _, alg := range []string{"rle", "huffman", "lzw", "bwt"} {
+		c, err := codec(alg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var compressed, expanded bytes.Buffer
+		if err = run(c, &compressed, strings.NewReader(text), false); err != nil {
+			t.Fatalf("%s: compress error: %v", alg, err)
+		}
+		if err = run(c, &expanded, &compressed, true); err != nil {
+			t.Fatalf("%s: expand error: %v", alg, err)
+		}
+		if got := expanded.String(); got != text {
+			t.Errorf("%s: expanded %q, want %q", alg, got, text)
+		}
+	}
+}
+
+func TestCodecUnknown(t *testing.T) {
+	if _, err := codec("zip"); err == nil {
+		t.Errorf("codec(zip) expected error")
+	}
+}
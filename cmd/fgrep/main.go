@@ -0,0 +1,193 @@
+/*
+Program fgrep searches files (or standard input) for lines containing any of the fixed string patterns.
+All the patterns are looked up simultaneously with Aho-Corasick automaton,
+so thousands of keywords can be scanned in a single pass.
+The patterns are given with -e flags or read from a file, one per line:
+
+	$ fgrep -e timeout -e refused app.log
+	2024/01/02 12:00:01 dial tcp: connection refused
+	2024/01/02 12:00:07 read: i/o timeout
+
+With -o flag the input is streamed as a whole, and each match is printed as its byte offset and pattern.
+The -leftmost flag reports only non-overlapping leftmost-longest matches instead of all of them.
+
+	$ echo ushers | fgrep -o -e he -e she -e hers
+	1:she
+	2:he
+	2:hers
+
+Like grep, the exit status is 1 when nothing is found.
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/marselester/alg/substr"
+)
+
+func main() {
+	var patterns patternList
+	flag.Var(&patterns, "e", "Pattern to search for, the flag can be repeated.")
+	file := flag.String("f", "", "File of patterns, one per line.")
+	onlyMatching := flag.Bool("o", false, "Print byte offset and pattern of each match instead of matching lines.")
+	lineNumbers := flag.Bool("n", false, "Prefix each line with its line number.")
+	leftmost := flag.Bool("leftmost", false, "Report non-overlapping leftmost-longest matches.")
+	flag.Parse()
+
+	if *file != "" {
+		f, err := os.Open(*file)
+		if err != nil {
+			log.Fatalf("fgrep: %v", err)
+		}
+		pp, err := readPatterns(f)
+		f.Close()
+		if err != nil {
+			log.Fatalf("fgrep: %v", err)
+		}
+		patterns = append(patterns, pp...)
+	}
+	if len(patterns) == 0 {
+		log.Fatalf("fgrep: no patterns given, use -e or -f flags")
+	}
+
+	kind := substr.Overlapping
+	if *leftmost {
+		kind = substr.LeftmostLongest
+	}
+	out := bufio.NewWriter(os.Stdout)
+	g := grep{
+		ac:           substr.NewAhoCorasick(patterns, substr.WithMatchKind(kind)),
+		w:            out,
+		withName:     flag.NArg() > 1,
+		lineNumbers:  *lineNumbers,
+		onlyMatching: *onlyMatching,
+	}
+
+	found, err := g.files(flag.Args())
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		log.Fatalf("fgrep: %v", err)
+	}
+	if !found {
+		os.Exit(1)
+	}
+}
+
+// patternList is a flag.Value that collects patterns from repeated flags.
+type patternList []string
+
+func (pp *patternList) String() string {
+	return strings.Join(*pp, ",")
+}
+
+func (pp *patternList) Set(pat string) error {
+	*pp = append(*pp, pat)
+	return nil
+}
+
+// readPatterns reads non-empty patterns, one per line.
+func readPatterns(r io.Reader) ([]string, error) {
+	var pp []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		if pat := scanner.Text(); pat != "" {
+			pp = append(pp, pat)
+		}
+	}
+	return pp, scanner.Err()
+}
+
+// grep prints matches of the patterns found in the inputs.
+type grep struct {
+	ac *substr.AhoCorasick
+	w  io.Writer
+	// withName indicates whether to prefix the output with the input name.
+	withName     bool
+	lineNumbers  bool
+	onlyMatching bool
+}
+
+// files searches the named files, or standard input if there are none.
+// It reports whether anything was found.
+func (g *grep) files(names []string) (bool, error) {
+	if len(names) == 0 {
+		return g.run("(standard input)", os.Stdin)
+	}
+
+	found := false
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return found, err
+		}
+		ok, err := g.run(name, f)
+		f.Close()
+		if err != nil {
+			return found, fmt.Errorf("%s: %w", name, err)
+		}
+		found = found || ok
+	}
+	return found, nil
+}
+
+// run searches the input and reports whether anything was found.
+func (g *grep) run(name string, r io.Reader) (bool, error) {
+	prefix := ""
+	if g.withName {
+		prefix = name + ":"
+	}
+
+	found := false
+	if g.onlyMatching {
+		var werr error
+		err := g.ac.Scan(r, func(m substr.Match) bool {
+			found = true
+			_, werr = fmt.Fprintf(g.w, "%s%d:%s\n", prefix, m.Offset, m.Pattern)
+			return werr == nil
+		})
+		if err == nil {
+			err = werr
+		}
+		return found, err
+	}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if !g.contains(line) {
+			continue
+		}
+		found = true
+
+		var err error
+		if g.lineNumbers {
+			_, err = fmt.Fprintf(g.w, "%s%d:%s\n", prefix, n, line)
+		} else {
+			_, err = fmt.Fprintf(g.w, "%s%s\n", prefix, line)
+		}
+		if err != nil {
+			return found, err
+		}
+	}
+	return found, scanner.Err()
+}
+
+// contains reports whether the line has any of the patterns.
+func (g *grep) contains(line string) bool {
+	found := false
+	// Reading from a string never fails.
+	_ = g.ac.Scan(strings.NewReader(line), func(substr.Match) bool {
+		found = true
+		return false
+	})
+	return found
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/marselester/alg/substr"
+)
+
+const appLog = `12:00:00 started
+12:00:01 dial tcp: connection refused
+12:00:05 ok
+12:00:07 read: i/o timeout
+`
+
+func TestReadPatterns(t *testing.T) {
+	got, err := readPatterns(strings.NewReader("refused\n\ntimeout\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"refused", "timeout"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("readPatterns() = %q, want %q", got, want)
+	}
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name string
+		g    grep
+		text string
+		want string
+	}{
+		{
+			name: "lines",
+			g:    grep{},
+			text: appLog,
+			want: "12:00:01 dial tcp: connection refused\n12:00:07 read: i/o timeout\n",
+		},
+		{
+			name: "line numbers with name",
+			g:    grep{lineNumbers: true, withName: true},
+			text: appLog,
+			want: "app.log:2:12:00:01 dial tcp: connection refused\napp.log:4:12:00:07 read: i/o timeout\n",
+		},
+		{
+			name: "only matching",
+			g:    grep{onlyMatching: true},
+			text: appLog,
+			want: "47:refused\n86:timeout\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			var out bytes.Buffer
+			tc.g.ac = substr.NewAhoCorasick([]string{"refused", "timeout"})
+			tc.g.w = &out
+			found, err := tc.g.run("app.log", strings.NewReader(tc.text))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !found {
+				t.Errorf("run() found nothing")
+			}
+			if got := out.String(); got != tc.want {
+				t.Errorf("run() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunNotFound(t *testing.T) {
+	var out bytes.Buffer
+	g := grep{
+		ac: substr.NewAhoCorasick([]string{"panic"}),
+		w:  &out,
+	}
+	found, err := g.run("app.log", strings.NewReader(appLog))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found || out.Len() != 0 {
+		t.Errorf("run() = %v, %q, want nothing found", found, out.String())
+	}
+}
+
+func TestRunLeftmostLongest(t *testing.T) {
+	var out bytes.Buffer
+	g := grep{
+		ac:           substr.NewAhoCorasick([]string{"he", "she", "hers"}, substr.WithMatchKind(substr.LeftmostLongest)),
+		w:            &out,
+		onlyMatching: true,
+	}
+	if _, err := g.run("", strings.NewReader("ushers")); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := out.String(), "1:she\n"; got != want {
+		t.Errorf("run() = %q, want %q", got, want)
+	}
+}
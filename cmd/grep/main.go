@@ -0,0 +1,149 @@
+/*
+Program grep prints lines of files (or standard input) that contain a match of the regular expression.
+The expression is compiled into NFA by regexp package which supports concatenation, |, *, +, ?,
+grouping, character classes, and the . wildcard, see https://godoc.org/github.com/marselester/alg/regexp.
+
+	$ grep '(A*B|AC)D' tiny.txt
+	AABD
+	ACD
+
+Like the book's GREP client, a line is printed if ".*(re).*" matches the whole line.
+With -o flag only the matched parts of the lines are printed (leftmost-longest, non-empty).
+
+	$ echo 'id: abc12, xyz9; 42' | grep -o '[a-z]+\d+'
+	abc12
+	xyz9
+
+Like grep, the exit status is 1 when nothing is found.
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/marselester/alg/regexp"
+)
+
+func main() {
+	onlyMatching := flag.Bool("o", false, "Print only the matched parts of the lines.")
+	lineNumbers := flag.Bool("n", false, "Prefix each line with its line number.")
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		log.Fatalf("grep: usage: grep [-o] [-n] pattern [file ...]")
+	}
+	g, err := newGrep(flag.Arg(0))
+	if err != nil {
+		log.Fatalf("grep: %v", err)
+	}
+	out := bufio.NewWriter(os.Stdout)
+	g.w = out
+	g.withName = flag.NArg() > 2
+	g.lineNumbers = *lineNumbers
+	g.onlyMatching = *onlyMatching
+
+	found, err := g.files(flag.Args()[1:])
+	if ferr := out.Flush(); err == nil {
+		err = ferr
+	}
+	if err != nil {
+		log.Fatalf("grep: %v", err)
+	}
+	if !found {
+		os.Exit(1)
+	}
+}
+
+// grep prints lines that match the regular expression.
+type grep struct {
+	// re is the regular expression to find the matched parts of a line.
+	re *regexp.Regexp
+	// line matches the whole line that contains re.
+	line *regexp.Regexp
+	w    io.Writer
+	// withName indicates whether to prefix the output with the input name.
+	withName     bool
+	lineNumbers  bool
+	onlyMatching bool
+}
+
+// newGrep compiles the pattern.
+func newGrep(pattern string) (*grep, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, err
+	}
+	return &grep{
+		re:   re,
+		line: regexp.MustCompile(".*(" + pattern + ").*"),
+	}, nil
+}
+
+// files searches the named files, or standard input if there are none.
+// It reports whether anything was found.
+func (g *grep) files(names []string) (bool, error) {
+	if len(names) == 0 {
+		return g.run("(standard input)", os.Stdin)
+	}
+
+	found := false
+	for _, name := range names {
+		f, err := os.Open(name)
+		if err != nil {
+			return found, err
+		}
+		ok, err := g.run(name, f)
+		f.Close()
+		if err != nil {
+			return found, fmt.Errorf("%s: %w", name, err)
+		}
+		found = found || ok
+	}
+	return found, nil
+}
+
+// run searches the input line by line and reports whether anything was found.
+func (g *grep) run(name string, r io.Reader) (bool, error) {
+	prefix := ""
+	if g.withName {
+		prefix = name + ":"
+	}
+
+	found := false
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := scanner.Text()
+		if !g.line.Match(line) {
+			continue
+		}
+		found = true
+
+		p := prefix
+		if g.lineNumbers {
+			p = fmt.Sprintf("%s%d:", prefix, n)
+		}
+		if err := g.print(p, line); err != nil {
+			return found, err
+		}
+	}
+	return found, scanner.Err()
+}
+
+// print writes the matched line, or its matched parts if onlyMatching is set.
+func (g *grep) print(prefix, line string) error {
+	if !g.onlyMatching {
+		_, err := fmt.Fprintf(g.w, "%s%s\n", prefix, line)
+		return err
+	}
+	for _, m := range g.re.FindAll(line) {
+		if _, err := fmt.Fprintf(g.w, "%s%s\n", prefix, m); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+const tiny = `AC
+AD
+AAA
+ABD
+ADD
+BCD
+ABCCBD
+BABAAA
+BABBAAA
+`
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name    string
+		pattern string
+		g       grep
+		text    string
+		want    string
+	}{
+		{
+			name:    "lines",
+			pattern: "(A*B|AC)D",
+			text:    tiny,
+			want:    "ABD\nABCCBD\n",
+		},
+		{
+			name:    "line numbers with name",
+			pattern: "(A*B|AC)D",
+			g:       grep{lineNumbers: true, withName: true},
+			text:    tiny,
+			want:    "tiny.txt:4:ABD\ntiny.txt:7:ABCCBD\n",
+		},
+		{
+			name:    "only matching",
+			pattern: "[a-z]+\\d+",
+			g:       grep{onlyMatching: true},
+			text:    "id: abc12, xyz9; 42\nnothing\n",
+			want:    "abc12\nxyz9\n",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			g, err := newGrep(tc.pattern)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var out bytes.Buffer
+			g.w = &out
+			g.withName, g.lineNumbers, g.onlyMatching = tc.g.withName, tc.g.lineNumbers, tc.g.onlyMatching
+
+			found, err := g.run("tiny.txt", strings.NewReader(tc.text))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !found {
+				t.Errorf("run() found nothing")
+			}
+			if got := out.String(); got != tc.want {
+				t.Errorf("run() = %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestRunNotFound(t *testing.T) {
+	g, err := newGrep("X+")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out bytes.Buffer
+	g.w = &out
+	found, err := g.run("tiny.txt", strings.NewReader(tiny))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if found || out.Len() != 0 {
+		t.Errorf("run() = %v, %q, want nothing found", found, out.String())
+	}
+}
+
+func TestNewGrepError(t *testing.T) {
+	if _, err := newGrep("(A"); err == nil {
+		t.Errorf("newGrep() expected syntax error")
+	}
+}
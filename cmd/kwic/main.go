@@ -1,12 +1,27 @@
-// Program kwic is a keyword-in-context indexing client.
-// It builds a suffix array for the text from standard input, takes a query from a flag,
-// and prints search results.
+/*
+Program kwic is a keyword-in-context indexing client.
+It builds a suffix array for the text from standard input, takes a query from a flag,
+and prints search results.
+
+	$ kwic -query=th < tale.txt
+
+Large corpora can be indexed once and queried repeatedly.
+The build subcommand saves the suffix array index into a file,
+and the query subcommand memory-maps the index, so it doesn't have to be loaded into memory.
+
+	$ kwic build -o=tale.idx tale.txt
+	$ kwic query -index=tale.idx -before=7 -len=7 worst
+	as the worst of tim
+
+When no queries are given, they are read from standard input, one per line.
+*/
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
-	"io/ioutil"
+	"io"
 	"log"
 	"os"
 	"regexp"
@@ -15,6 +30,17 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "build":
+			buildCmd(os.Args[2:])
+			return
+		case "query":
+			queryCmd(os.Args[2:])
+			return
+		}
+	}
+
 	query := flag.String("query", "", "keyword to look for in standard input")
 	ctxlen := flag.Int("len", 15, "number of characters after query to give context")
 	flag.Parse()
@@ -22,15 +48,140 @@ func main() {
 		log.Fatal("kwic: search query must not be blank")
 	}
 
-	b, err := ioutil.ReadAll(os.Stdin)
+	b, err := io.ReadAll(os.Stdin)
 	if err != nil {
 		log.Fatalf("kwic: failed to read from standard input: %v", err)
 	}
 
-	re := regexp.MustCompile(`\s+`)
-	text := re.ReplaceAllString(string(b), " ")
-	idx := suffixarray.New(text)
+	idx := suffixarray.New(normalize(b))
 	for _, s := range idx.Search(*query, *ctxlen) {
 		fmt.Println(s)
 	}
 }
+
+// normalize replaces whitespace sequences with a single space.
+func normalize(b []byte) string {
+	re := regexp.MustCompile(`\s+`)
+	return re.ReplaceAllString(string(b), " ")
+}
+
+// buildCmd builds the index of a file (or standard input) and saves it.
+func buildCmd(args []string) {
+	fs := flag.NewFlagSet("build", flag.ExitOnError)
+	out := fs.String("o", "", "index file to create")
+	fs.Parse(args)
+	if *out == "" {
+		log.Fatal("kwic: index file name must not be blank")
+	}
+
+	in := os.Stdin
+	if fs.NArg() > 0 {
+		f, err := os.Open(fs.Arg(0))
+		if err != nil {
+			log.Fatalf("kwic: %v", err)
+		}
+		defer f.Close()
+		in = f
+	}
+
+	f, err := os.Create(*out)
+	if err != nil {
+		log.Fatalf("kwic: %v", err)
+	}
+	if err = build(in, f); err != nil {
+		f.Close()
+		os.Remove(*out)
+		log.Fatalf("kwic: %v", err)
+	}
+	if err = f.Close(); err != nil {
+		log.Fatalf("kwic: %v", err)
+	}
+}
+
+// build reads the text and writes its index.
+func build(r io.Reader, w io.Writer) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	bw := bufio.NewWriter(w)
+	if _, err = suffixarray.New(normalize(b)).WriteTo(bw); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
+
+// queryCmd searches the memory-mapped index.
+func queryCmd(args []string) {
+	fs := flag.NewFlagSet("query", flag.ExitOnError)
+	index := fs.String("index", "", "index file created by build subcommand")
+	before := fs.Int("before", 15, "number of characters before query to give context")
+	after := fs.Int("len", 15, "number of characters after query to give context")
+	count := fs.Bool("count", false, "print only the number of occurrences")
+	verify := fs.Bool("verify", false, "verify the index checksum before searching")
+	fs.Parse(args)
+
+	data, unmap, err := mmap(*index)
+	if err != nil {
+		log.Fatalf("kwic: %v", err)
+	}
+	defer unmap()
+
+	if *verify {
+		if err = suffixarray.Verify(data); err != nil {
+			log.Fatalf("kwic: %s: %v", *index, err)
+		}
+	}
+	idx, err := suffixarray.Load(data)
+	if err != nil {
+		log.Fatalf("kwic: %s: %v", *index, err)
+	}
+
+	q := querier{
+		idx:    idx,
+		w:      bufio.NewWriter(os.Stdout),
+		before: *before,
+		after:  *after,
+		count:  *count,
+	}
+	if fs.NArg() > 0 {
+		for _, s := range fs.Args() {
+			q.query(s)
+		}
+	} else {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			q.query(scanner.Text())
+		}
+		if err = scanner.Err(); err != nil {
+			log.Fatalf("kwic: failed to read from standard input: %v", err)
+		}
+	}
+	if err = q.w.Flush(); err != nil {
+		log.Fatalf("kwic: %v", err)
+	}
+}
+
+// querier prints the occurrences of the queries in the index.
+type querier struct {
+	idx *suffixarray.KeywordIndex
+	w   *bufio.Writer
+	// before and after are the numbers of characters around the query to give context.
+	before, after int
+	// count indicates whether to print only the number of occurrences.
+	count bool
+}
+
+// query prints the occurrences of the query with their context, or their number.
+func (q *querier) query(s string) {
+	if s == "" {
+		return
+	}
+	if q.count {
+		fmt.Fprintf(q.w, "%d\t%s\n", q.idx.Count(s), s)
+		return
+	}
+	for _, o := range q.idx.Context(s, q.before, q.after) {
+		fmt.Fprintf(q.w, "%s%s%s\n", o.Before, o.Match, o.After)
+	}
+}
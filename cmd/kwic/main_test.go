@@ -0,0 +1,72 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/marselester/alg/suffixarray"
+)
+
+const tinyTale = `it was the best of times
+it was the worst of times
+it was the age of wisdom
+`
+
+func TestBuildQuery(t *testing.T) {
+	name := filepath.Join(t.TempDir(), "tale.idx")
+	f, err := os.Create(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = build(strings.NewReader(tinyTale), f); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	data, unmap, err := mmap(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer unmap()
+	if err = suffixarray.Verify(data); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := suffixarray.Load(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		count bool
+		want  string
+	}{
+		{"worst", false, "as the worst of tim\n"},
+		{"it was", false, "it was the be\n times it was the wo\n times it was the ag\n"},
+		{"it was", true, "3\tit was\n"},
+		{"foolishness", false, ""},
+	}
+	for _, tc := range tests {
+		var out bytes.Buffer
+		q := querier{
+			idx:    idx,
+			w:      bufio.NewWriter(&out),
+			before: 7,
+			after:  7,
+			count:  tc.count,
+		}
+		q.query(tc.query)
+		if err = q.w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+		if got := out.String(); got != tc.want {
+			t.Errorf("query(%q) = %q, want %q", tc.query, got, tc.want)
+		}
+	}
+}
@@ -1,50 +1,121 @@
-// Program parentheses reads in a text stream from standard input
-// and uses a stack to determine whether its parentheses are properly balanced.
-// For example, [()]{}{[()()]()} is balanced, whereas [(]) is not.
+/*
+Program parentheses reads in a text stream from standard input
+and uses a stack to determine whether its parentheses are properly balanced.
+For example, [()]{}{[()()]()} is balanced, whereas [(]) is not.
+Each line of standard input is checked separately.
+
+When file names are given, each file is checked as a whole and the position
+of the first mismatch is reported. Files are streamed, so they can be large.
+String literals and comments are skipped according to -lang flag:
+
+	parentheses -lang=go main.go
+	main.go:12:3: found '}', want ')' to close '(' at 10:12
+
+Supported languages are plain (no literals), json, go (also works for C-like languages) and shell.
+Custom delimiters can be set with -pairs flag, e.g., -pairs='()<>'.
+*/
 package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
+	"log"
 	"os"
+	"unicode/utf8"
 
-	"github.com/marselester/alg/stack"
+	"github.com/marselester/alg/stack/brackets"
 )
 
 func main() {
-	scanner := bufio.NewScanner(os.Stdin)
-	var expr string
-	for scanner.Scan() {
-		expr = scanner.Text()
-		fmt.Println(isBalanced(expr))
+	lang := flag.String("lang", "plain", "Language that defines string literals and comments: plain, json, go, shell.")
+	pairs := flag.String("pairs", "()[]{}", "Opening and closing delimiters one after another.")
+	flag.Parse()
+
+	opts, err := options(*lang, *pairs)
+	if err != nil {
+		log.Fatalf("parentheses: %v", err)
 	}
-}
+	c := brackets.New(opts...)
 
-func isBalanced(expr string) bool {
-	s := stack.LinkedList{}
-	for _, r := range expr {
-		switch par := string(r); par {
-		case "[", "(", "{":
-			s.Push(par)
-		case "]", ")", "}":
-			if ok := bracketsMatch(s.Pop(), par); !ok {
-				return false
-			}
+	if flag.NArg() == 0 {
+		scanner := bufio.NewScanner(os.Stdin)
+		for scanner.Scan() {
+			fmt.Println(c.IsBalanced(scanner.Text()))
 		}
+		if err = scanner.Err(); err != nil {
+			log.Fatalf("parentheses: %v", err)
+		}
+		return
+	}
+
+	ok := true
+	for _, name := range flag.Args() {
+		if err = checkFile(c, name); err != nil {
+			fmt.Printf("%s:%v\n", name, err)
+			ok = false
+		}
+	}
+	if !ok {
+		os.Exit(1)
 	}
+}
 
-	allMatched := s.Size() == 0
-	return allMatched
+func checkFile(c *brackets.Checker, name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	return c.Check(f)
 }
 
-func bracketsMatch(openpar, closepar string) bool {
-	switch openpar {
-	case "[":
-		return closepar == "]"
-	case "(":
-		return closepar == ")"
-	case "{":
-		return closepar == "}"
+// options returns checker options for the language and delimiter pairs.
+func options(lang, pairs string) ([]brackets.Option, error) {
+	pp, err := parsePairs(pairs)
+	if err != nil {
+		return nil, err
+	}
+	opts := []brackets.Option{brackets.WithPairs(pp...)}
+
+	switch lang {
+	case "plain":
+	case "json":
+		opts = append(opts, brackets.WithQuotes('"'))
+	case "go":
+		opts = append(opts,
+			brackets.WithQuotes('"', '\''),
+			brackets.WithRawQuotes('`'),
+			brackets.WithLineComment("//"),
+			brackets.WithBlockComment("/*", "*/"),
+		)
+	case "shell":
+		opts = append(opts,
+			brackets.WithQuotes('"'),
+			brackets.WithRawQuotes('\''),
+			brackets.WithLineComment("#"),
+		)
+	default:
+		return nil, fmt.Errorf("unknown language %q", lang)
 	}
-	return false
+	return opts, nil
+}
+
+// parsePairs splits a string into pairs of opening and closing delimiters, e.g., "()[]".
+func parsePairs(s string) ([]brackets.Pair, error) {
+	var (
+		pp []brackets.Pair
+		rr = []rune(s)
+	)
+	if len(rr) == 0 || len(rr)%2 != 0 || !utf8.ValidString(s) {
+		return nil, fmt.Errorf("pairs must have even number of delimiters: %q", s)
+	}
+	for i := 0; i < len(rr); i += 2 {
+		pp = append(pp, brackets.Pair{Open: rr[i], Close: rr[i+1]})
+	}
+	return pp, nil
+}
+
+func isBalanced(expr string) bool {
+	return brackets.New().IsBalanced(expr)
 }
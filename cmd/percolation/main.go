@@ -0,0 +1,173 @@
+/*
+Program percolation estimates the percolation threshold using Monte Carlo simulation.
+
+A system is modeled as n-by-n grid of sites. Each site is either open or blocked.
+The system percolates if there is a path of neighboring (left, right, up, down) open sites
+from the top row to the bottom row. For example, think of porous material where water
+can flow through the open sites from the surface to the bottom.
+
+When sites are opened independently with probability p, there is a threshold value p*
+such that a large grid almost never percolates when p < p* and almost always percolates when p > p*.
+There is no known mathematical solution for p*, but it can be estimated:
+all sites are blocked at first, then random sites are opened until the system percolates,
+and the fraction of open sites is an estimate of p*.
+Repeating the experiment trials times gives a sample mean and a 95% confidence interval.
+
+	$ percolation -n=200 -trials=100 -seed=1
+	mean: 0.592030
+	stddev: 0.009060
+	95% confidence interval: [0.590255; 0.593806]
+	elapsed: 251.661893ms
+
+Connectivity of the sites is checked with union-find algorithm. Two virtual sites
+are connected to the top and the bottom row, so the system percolates
+when the virtual sites are connected.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"math"
+	"math/rand"
+	"time"
+
+	"github.com/marselester/alg/unionfind"
+	"github.com/marselester/alg/unionfind/pcunion"
+	"github.com/marselester/alg/unionfind/qfind"
+	"github.com/marselester/alg/unionfind/qunion"
+	"github.com/marselester/alg/unionfind/wqunion"
+)
+
+func main() {
+	n := flag.Int("n", 200, "grid size n-by-n")
+	trials := flag.Int("trials", 100, "number of experiments")
+	alg := flag.String("alg", "wqunion", "union-find algorithm: qfind, qunion, wqunion, pcunion")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	flag.Parse()
+
+	if *n <= 0 || *trials <= 1 {
+		log.Fatalf("percolation: grid size must be positive and there must be at least two trials")
+	}
+	if _, err := newNetwork(*alg, 1); err != nil {
+		log.Fatalf("percolation: %v", err)
+	}
+
+	r := rand.New(rand.NewSource(*seed))
+	thresholds := make([]float64, *trials)
+	begun := time.Now()
+	for i := range thresholds {
+		thresholds[i] = threshold(*n, *alg, r)
+	}
+	elapsed := time.Since(begun)
+
+	mean, stddev := stats(thresholds)
+	// 1.96 is the 97.5th percentile of the standard normal distribution.
+	margin := 1.96 * stddev / math.Sqrt(float64(*trials))
+	fmt.Printf("mean: %f\n", mean)
+	fmt.Printf("stddev: %f\n", stddev)
+	fmt.Printf("95%% confidence interval: [%f; %f]\n", mean-margin, mean+margin)
+	fmt.Printf("elapsed: %v\n", elapsed)
+}
+
+// newNetwork creates a network of n sites using the given algorithm.
+func newNetwork(alg string, n int) (unionfind.Network, error) {
+	switch alg {
+	case "qfind":
+		return qfind.New(n), nil
+	case "qunion":
+		return qunion.New(n), nil
+	case "wqunion":
+		return wqunion.New(n), nil
+	case "pcunion":
+		return pcunion.New(n), nil
+	}
+	return nil, fmt.Errorf("unknown algorithm %q", alg)
+}
+
+// grid represents n-by-n percolation system.
+// Site at row i and column j has index i*n + j in the network,
+// the virtual top and bottom sites have indexes n*n and n*n + 1.
+type grid struct {
+	n    int
+	open []bool
+	net  unionfind.Network
+}
+
+// newGrid creates a grid where all sites are blocked.
+func newGrid(n int, alg string) *grid {
+	net, err := newNetwork(alg, n*n+2)
+	if err != nil {
+		panic(err)
+	}
+	return &grid{
+		n:    n,
+		open: make([]bool, n*n),
+		net:  net,
+	}
+}
+
+// Open opens the site at row i and column j, and connects it to its open neighbors.
+func (g *grid) Open(i, j int) {
+	site := i*g.n + j
+	if g.open[site] {
+		return
+	}
+	g.open[site] = true
+
+	if i == 0 {
+		g.net.Connect(site, g.n*g.n)
+	}
+	if i == g.n-1 {
+		g.net.Connect(site, g.n*g.n+1)
+	}
+	if i > 0 && g.open[site-g.n] {
+		g.net.Connect(site, site-g.n)
+	}
+	if i < g.n-1 && g.open[site+g.n] {
+		g.net.Connect(site, site+g.n)
+	}
+	if j > 0 && g.open[site-1] {
+		g.net.Connect(site, site-1)
+	}
+	if j < g.n-1 && g.open[site+1] {
+		g.net.Connect(site, site+1)
+	}
+}
+
+// Percolates tells whether the top row is connected to the bottom row.
+func (g *grid) Percolates() bool {
+	return g.net.IsConnected(g.n*g.n, g.n*g.n+1)
+}
+
+// threshold opens random sites of n-by-n grid until it percolates,
+// and returns the fraction of open sites.
+// The sites are opened in the order of a random permutation,
+// so the same site is never picked twice.
+func threshold(n int, alg string, r *rand.Rand) float64 {
+	g := newGrid(n, alg)
+	var opened int
+	for _, site := range r.Perm(n * n) {
+		g.Open(site/n, site%n)
+		opened++
+		if g.Percolates() {
+			break
+		}
+	}
+	return float64(opened) / float64(n*n)
+}
+
+// stats returns the sample mean and standard deviation.
+func stats(x []float64) (mean, stddev float64) {
+	for _, v := range x {
+		mean += v
+	}
+	mean /= float64(len(x))
+
+	for _, v := range x {
+		stddev += (v - mean) * (v - mean)
+	}
+	stddev = math.Sqrt(stddev / float64(len(x)-1))
+	return mean, stddev
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+func TestGrid(t *testing.T) {
+	g := newGrid(3, "wqunion")
+	g.Open(0, 1)
+	g.Open(1, 1)
+	g.Open(2, 0)
+	if g.Percolates() {
+		t.Fatalf("Percolates() = true, want false")
+	}
+	g.Open(2, 1)
+	if !g.Percolates() {
+		t.Errorf("Percolates() = false, want true")
+	}
+}
+
+func TestThreshold(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	if got := threshold(1, "wqunion", r); got != 1 {
+		t.Errorf("threshold(1) = %f, want 1", got)
+	}
+
+	for _, alg := range []string{"qfind", "qunion", "wqunion", "pcunion"} {
+		x := make([]float64, 20)
+		for i := range x {
+			x[i] = threshold(20, alg, r)
+		}
+		// The threshold is approximately 0.593.
+		if mean, _ := stats(x); mean < 0.5 || mean > 0.7 {
+			t.Errorf("%s threshold(20) mean = %f, want ~0.593", alg, mean)
+		}
+	}
+}
+
+func TestStats(t *testing.T) {
+	mean, stddev := stats([]float64{2, 4, 4, 4, 5, 5, 7, 9})
+	if mean != 5 {
+		t.Errorf("mean = %f, want 5", mean)
+	}
+	if want := math.Sqrt(32.0 / 7); math.Abs(stddev-want) > 1e-9 {
+		t.Errorf("stddev = %f, want %f", stddev, want)
+	}
+}
@@ -0,0 +1,154 @@
+/*
+Program uf reads a sequence of connections from standard input,
+runs a chosen union-find algorithm, and reports timing and tree depth statistics.
+The input is in the format of the book's tinyUF.txt, mediumUF.txt and largeUF.txt files:
+the number of sites n followed by pairs of sites p q.
+
+	10
+	4 3
+	3 8
+	6 5
+
+The quick-union trees become tall on large inputs which makes the algorithm quadratic,
+while the weighted quick-union keeps them logarithmic.
+Here connections is the number of pairs that weren't connected before.
+
+	$ (echo 1000000; awk 'BEGIN { for (i = 0; i < 2000000; i++) print int(rand()*1000000), int(rand()*1000000) }') | uf -alg=wqunion
+	algorithm: wqunion
+	sites: 1000000
+	connections: 981044
+	components: 18956
+	elapsed: 184.169324ms
+	max depth: 8
+	avg depth: 2.03
+*/
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/marselester/alg/unionfind"
+	"github.com/marselester/alg/unionfind/pcunion"
+	"github.com/marselester/alg/unionfind/qfind"
+	"github.com/marselester/alg/unionfind/qunion"
+	"github.com/marselester/alg/unionfind/wqunion"
+)
+
+func main() {
+	alg := flag.String("alg", "wqunion", "union-find algorithm: qfind, qunion, wqunion, pcunion")
+	flag.Parse()
+
+	s, err := run(*alg, bufio.NewReader(os.Stdin))
+	if err != nil {
+		log.Fatalf("uf: %v", err)
+	}
+
+	fmt.Printf("algorithm: %s\n", *alg)
+	fmt.Printf("sites: %d\n", s.sites)
+	fmt.Printf("connections: %d\n", s.connections)
+	fmt.Printf("components: %d\n", s.components)
+	fmt.Printf("elapsed: %v\n", s.elapsed)
+	if s.hasDepth {
+		fmt.Printf("max depth: %d\n", s.maxDepth)
+		fmt.Printf("avg depth: %0.2f\n", s.avgDepth)
+	}
+}
+
+// stats describes a union-find run.
+type stats struct {
+	sites       int
+	connections int
+	components  int
+	elapsed     time.Duration
+	// hasDepth indicates whether the algorithm represents components as trees,
+	// so maxDepth and avgDepth are known.
+	hasDepth bool
+	maxDepth int
+	avgDepth float64
+}
+
+// depther is implemented by union-find algorithms that link sites into trees.
+type depther interface {
+	Depth(p int) int
+}
+
+// newNetwork creates a network of n sites using the given algorithm.
+func newNetwork(alg string, n int) (unionfind.Network, error) {
+	switch alg {
+	case "qfind":
+		return qfind.New(n), nil
+	case "qunion":
+		return qunion.New(n), nil
+	case "wqunion":
+		return wqunion.New(n), nil
+	case "pcunion":
+		return pcunion.New(n), nil
+	}
+	return nil, fmt.Errorf("unknown algorithm %q", alg)
+}
+
+// run connects the sites from input and measures how long it took.
+// Reading of the input is not included in the elapsed time.
+func run(alg string, input io.Reader) (stats, error) {
+	var s stats
+	scanner := bufio.NewScanner(input)
+	scanner.Split(bufio.ScanWords)
+	var nums []int
+	for scanner.Scan() {
+		v, err := strconv.Atoi(scanner.Text())
+		if err != nil {
+			return s, fmt.Errorf("invalid site: %w", err)
+		}
+		nums = append(nums, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return s, err
+	}
+	if len(nums) == 0 {
+		return s, fmt.Errorf("number of sites is missing")
+	}
+	if len(nums)%2 == 0 {
+		return s, fmt.Errorf("site %d has no pair", nums[len(nums)-1])
+	}
+
+	s.sites = nums[0]
+	pairs := nums[1:]
+	for _, p := range pairs {
+		if p < 0 || p >= s.sites {
+			return s, fmt.Errorf("site %d is out of range [0; %d)", p, s.sites)
+		}
+	}
+	net, err := newNetwork(alg, s.sites)
+	if err != nil {
+		return s, err
+	}
+
+	begun := time.Now()
+	for i := 0; i < len(pairs); i += 2 {
+		if !net.IsConnected(pairs[i], pairs[i+1]) {
+			net.Connect(pairs[i], pairs[i+1])
+			s.connections++
+		}
+	}
+	s.elapsed = time.Since(begun)
+	s.components = net.Count()
+
+	if d, ok := net.(depther); ok && s.sites > 0 {
+		s.hasDepth = true
+		var total int
+		for p := 0; p < s.sites; p++ {
+			depth := d.Depth(p)
+			total += depth
+			s.maxDepth = max(s.maxDepth, depth)
+		}
+		s.avgDepth = float64(total) / float64(s.sites)
+	}
+	return s, nil
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+const tinyUF = `10
+4 3
+3 8
+6 5
+9 4
+2 1
+8 9
+5 0
+7 2
+6 1
+1 0
+6 7
+`
+
+func TestRun(t *testing.T) {
+	var tt = []struct {
+		alg      string
+		hasDepth bool
+	}{
+		{"qfind", false},
+		{"qunion", true},
+		{"wqunion", true},
+		{"pcunion", true},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.alg, func(t *testing.T) {
+			s, err := run(tc.alg, strings.NewReader(tinyUF))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if s.sites != 10 || s.connections != 8 || s.components != 2 {
+				t.Errorf("run() = %d sites, %d connections, %d components, want 10, 8, 2", s.sites, s.connections, s.components)
+			}
+			if s.hasDepth != tc.hasDepth {
+				t.Errorf("run() has depth %v, want %v", s.hasDepth, tc.hasDepth)
+			}
+		})
+	}
+}
+
+func TestRunError(t *testing.T) {
+	var tt = []struct {
+		alg   string
+		input string
+		want  string
+	}{
+		{"wqunion", "", "number of sites is missing"},
+		{"wqunion", "10\n1 2\n3", "site 3 has no pair"},
+		{"wqunion", "10\n1 x", `invalid site: strconv.Atoi: parsing "x": invalid syntax`},
+		{"wqunion", "10\n1 10", "site 10 is out of range [0; 10)"},
+		{"bogus", "10\n1 2", `unknown algorithm "bogus"`},
+	}
+
+	for _, tc := range tt {
+		_, err := run(tc.alg, strings.NewReader(tc.input))
+		if err == nil || err.Error() != tc.want {
+			t.Errorf("run(%q) error = %v, want %s", tc.input, err, tc.want)
+		}
+	}
+}
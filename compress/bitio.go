@@ -0,0 +1,161 @@
+/*
+Package compress implements lossless data compression algorithms from the book:
+run-length encoding, Huffman coding, LZW, and a bzip2-like pipeline of
+Burrows-Wheeler transform, move-to-front encoding, and Huffman coding.
+
+The compressed data is a stream of bits rather than bytes,
+see BitWriter and BitReader which are the counterparts of BinaryStdOut and BinaryStdIn from the book.
+*/
+package compress
+
+import (
+	"bufio"
+	"errors"
+	"io"
+)
+
+// ErrCorrupt indicates that the compressed data is malformed.
+var ErrCorrupt = errors.New("compress: corrupt input")
+
+// Codec compresses and expands byte streams.
+type Codec interface {
+	// Compress reads the original data from src and writes the compressed data to dst.
+	Compress(dst io.Writer, src io.Reader) error
+	// Expand reads the compressed data from src and writes the original data to dst.
+	Expand(dst io.Writer, src io.Reader) error
+}
+
+// BitWriter writes a stream of bits, the most significant bit of each byte goes first.
+// The bits are buffered, so Flush must be called after the last write.
+// Once an error occurs, the subsequent writes are no-op and return that error.
+type BitWriter struct {
+	w *bufio.Writer
+	// buf keeps up to 8 bits that haven't been written yet.
+	buf byte
+	n   uint
+	err error
+}
+
+// NewBitWriter returns a writer of bits to w.
+func NewBitWriter(w io.Writer) *BitWriter {
+	return &BitWriter{w: bufio.NewWriter(w)}
+}
+
+// WriteBit writes a single bit.
+func (bw *BitWriter) WriteBit(bit bool) error {
+	if bw.err != nil {
+		return bw.err
+	}
+
+	bw.buf <<= 1
+	if bit {
+		bw.buf |= 1
+	}
+	if bw.n++; bw.n == 8 {
+		bw.err = bw.w.WriteByte(bw.buf)
+		bw.buf, bw.n = 0, 0
+	}
+	return bw.err
+}
+
+// WriteBits writes n least significant bits of v starting from the most significant one, n is up to 64.
+func (bw *BitWriter) WriteBits(v uint64, n int) error {
+	for i := n - 1; i >= 0; i-- {
+		if err := bw.WriteBit(v&(1<<i) != 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// WriteByte writes 8 bits of c.
+func (bw *BitWriter) WriteByte(c byte) error {
+	// An aligned byte is written directly.
+	if bw.n == 0 && bw.err == nil {
+		bw.err = bw.w.WriteByte(c)
+		return bw.err
+	}
+	return bw.WriteBits(uint64(c), 8)
+}
+
+// Flush pads the last byte with zero bits and writes the buffered data.
+func (bw *BitWriter) Flush() error {
+	for bw.n != 0 && bw.err == nil {
+		bw.WriteBit(false)
+	}
+	if bw.err != nil {
+		return bw.err
+	}
+	bw.err = bw.w.Flush()
+	return bw.err
+}
+
+// BitReader reads a stream of bits written by BitWriter.
+type BitReader struct {
+	r io.ByteReader
+	// buf keeps the bits of the current byte that haven't been read yet.
+	buf byte
+	n   uint
+}
+
+// NewBitReader returns a reader of bits from r.
+func NewBitReader(r io.Reader) *BitReader {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	return &BitReader{r: br}
+}
+
+// ReadBit reads a single bit. It returns io.EOF when there are no bits left.
+func (br *BitReader) ReadBit() (bool, error) {
+	if br.n == 0 {
+		c, err := br.r.ReadByte()
+		if err != nil {
+			return false, err
+		}
+		br.buf, br.n = c, 8
+	}
+
+	br.n--
+	return br.buf&(1<<br.n) != 0, nil
+}
+
+// ReadBits reads n bits (up to 64) and returns them as the least significant bits of the result.
+// It returns io.EOF if there are no bits left, and io.ErrUnexpectedEOF if the stream ends in the middle.
+func (br *BitReader) ReadBits(n int) (uint64, error) {
+	var v uint64
+	for i := 0; i < n; i++ {
+		bit, err := br.ReadBit()
+		if err == io.EOF && i > 0 {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return 0, err
+		}
+		v <<= 1
+		if bit {
+			v |= 1
+		}
+	}
+	return v, nil
+}
+
+// ReadByte reads 8 bits.
+func (br *BitReader) ReadByte() (byte, error) {
+	// An aligned byte is read directly.
+	if br.n == 0 {
+		return br.r.ReadByte()
+	}
+	v, err := br.ReadBits(8)
+	return byte(v), err
+}
+
+// unexpectedEOF converts io.EOF into io.ErrUnexpectedEOF
+// when the stream must not end yet, e.g., in the middle of a header.
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
@@ -0,0 +1,46 @@
+package compress
+
+import (
+	"bytes"
+	"io"
+	"testing"
+)
+
+func TestBitWriter(t *testing.T) {
+	var buf bytes.Buffer
+	w := NewBitWriter(&buf)
+	w.WriteBit(true)
+	w.WriteBits(0b0101, 4)
+	w.WriteByte(0xff)
+	w.WriteBits(0x123, 12)
+	if err := w.Flush(); err != nil {
+		t.Fatal(err)
+	}
+
+	// 1 0101 11111111 000100100011 and 7 padding zeros.
+	want := []byte{0b10101111, 0b11111000, 0b10010001, 0b10000000}
+	if got := buf.Bytes(); !bytes.Equal(got, want) {
+		t.Errorf("BitWriter wrote %08b, want %08b", got, want)
+	}
+
+	r := NewBitReader(&buf)
+	if bit, _ := r.ReadBit(); !bit {
+		t.Errorf("ReadBit() = false, want true")
+	}
+	if v, _ := r.ReadBits(4); v != 0b0101 {
+		t.Errorf("ReadBits(4) = %b, want 101", v)
+	}
+	if c, _ := r.ReadByte(); c != 0xff {
+		t.Errorf("ReadByte() = %x, want ff", c)
+	}
+	if v, _ := r.ReadBits(12); v != 0x123 {
+		t.Errorf("ReadBits(12) = %x, want 123", v)
+	}
+	// Only 7 padding bits are left.
+	if _, err := r.ReadBits(12); err != io.ErrUnexpectedEOF {
+		t.Errorf("ReadBits(12) error = %v, want %v", err, io.ErrUnexpectedEOF)
+	}
+	if _, err := r.ReadBit(); err != io.EOF {
+		t.Errorf("ReadBit() error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,88 @@
+package compress
+
+import (
+	"io"
+
+	"github.com/marselester/alg/bwt"
+)
+
+// DefaultBlockSize is the default size of the blocks the Burrows-Wheeler pipeline transforms independently.
+const DefaultBlockSize = 900 * 1000
+
+/*
+BurrowsWheeler compresses data like bzip2: the input is split into blocks,
+and each block goes through the pipeline:
+
+	Burrows-Wheeler transform groups equal characters together
+	move-to-front encoding turns the groups into runs of small values (mostly zeros)
+	Huffman coding assigns short codewords to the frequent small values
+
+Each block is written as 1 bit, the 32-bit index of the BWT sentinel, and Huffman coded data.
+The end of data is marked by 0 bit.
+
+The larger the block, the better the compression, and the more memory is needed.
+The zero BlockSize means DefaultBlockSize.
+*/
+type BurrowsWheeler struct {
+	BlockSize int
+}
+
+// Compress transforms and encodes the input block by block.
+func (c BurrowsWheeler) Compress(dst io.Writer, src io.Reader) error {
+	size := c.BlockSize
+	if size <= 0 {
+		size = DefaultBlockSize
+	}
+
+	w := NewBitWriter(dst)
+	block := make([]byte, size)
+	for {
+		n, err := io.ReadFull(src, block)
+		if err == io.EOF {
+			break
+		}
+		if err != nil && err != io.ErrUnexpectedEOF {
+			return err
+		}
+
+		transformed, primary := bwt.Encode(block[:n])
+		w.WriteBit(true)
+		w.WriteBits(uint64(primary), 32)
+		huffmanWrite(w, moveToFront(transformed))
+	}
+	w.WriteBit(false)
+	return w.Flush()
+}
+
+// Expand decodes and inverts the transform block by block.
+func (BurrowsWheeler) Expand(dst io.Writer, src io.Reader) error {
+	r := NewBitReader(src)
+	for {
+		more, err := r.ReadBit()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if !more {
+			return nil
+		}
+
+		primary, err := r.ReadBits(32)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		data, err := huffmanRead(r)
+		if err != nil {
+			return err
+		}
+		if primary > uint64(len(data)) {
+			return ErrCorrupt
+		}
+		text, err := bwt.Decode(moveToFrontInverse(data), int(primary))
+		if err != nil {
+			return ErrCorrupt
+		}
+		if _, err = dst.Write(text); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,224 @@
+package compress
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var codecs = []struct {
+	name  string
+	codec Codec
+}{
+	{"RunLength", RunLength{}},
+	{"Huffman", Huffman{}},
+	{"LZW", LZW{}},
+	{"BurrowsWheeler", BurrowsWheeler{}},
+	{"BurrowsWheeler/small blocks", BurrowsWheeler{BlockSize: 7}},
+}
+
+// inputs returns the data to test compression round trips.
+func inputs() map[string][]byte {
+	r := rand.New(rand.NewSource(1))
+	random := make([]byte, 5000)
+	r.Read(random)
+
+	return map[string][]byte{
+		"empty":      {},
+		"one byte":   {'a'},
+		"zero byte":  {0},
+		"abra":       []byte("ABRACADABRA!"),
+		"tale":       []byte(strings.Repeat("it was the best of times it was the worst of times\n", 50)),
+		"runs":       bytes.Repeat([]byte{0, 0, 0, 0, 0xff, 0xff}, 300),
+		"zeros":      make([]byte, 1000),
+		"random":     random,
+		"all bytes":  allBytes(),
+		"lzw growth": []byte(strings.Repeat("abcdefghijklmnopqrstuvwxyz", 500)),
+	}
+}
+
+func allBytes() []byte {
+	b := make([]byte, 256)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, c := range codecs {
+		for name, data := range inputs() {
+			var compressed, expanded bytes.Buffer
+			if err := c.codec.Compress(&compressed, bytes.NewReader(data)); err != nil {
+				t.Fatalf("%s: Compress(%s) error: %v", c.name, name, err)
+			}
+			if err := c.codec.Expand(&expanded, &compressed); err != nil {
+				t.Fatalf("%s: Expand(%s) error: %v", c.name, name, err)
+			}
+			if !bytes.Equal(expanded.Bytes(), data) {
+				t.Errorf("%s: Expand(Compress(%s)) = %q, want %q", c.name, name, expanded.Bytes(), data)
+			}
+		}
+	}
+}
+
+func TestCompressionRatio(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec Codec
+		data  []byte
+		// max is the max size of the compressed data in percent of the original size.
+		max int
+	}{
+		{"RunLength", RunLength{}, make([]byte, 1000), 10},
+		{"Huffman", Huffman{}, []byte(strings.Repeat("aaaaaaab", 1000)), 20},
+		{"LZW", LZW{}, []byte(strings.Repeat("it was the best of times ", 100)), 20},
+		{"BurrowsWheeler", BurrowsWheeler{}, []byte(strings.Repeat("it was the best of times ", 100)), 20},
+	}
+
+	for _, tc := range tests {
+		var compressed bytes.Buffer
+		if err := tc.codec.Compress(&compressed, bytes.NewReader(tc.data)); err != nil {
+			t.Fatal(err)
+		}
+		if got := 100 * compressed.Len() / len(tc.data); got > tc.max {
+			t.Errorf("%s compressed to %d%%, want at most %d%%", tc.name, got, tc.max)
+		}
+	}
+}
+
+func TestLZWCodewords(t *testing.T) {
+	var compressed bytes.Buffer
+	if err := (LZW{}).Compress(&compressed, strings.NewReader("ABRACADABRABRABRA")); err != nil {
+		t.Fatal(err)
+	}
+
+	// The book's example with 256 single-character codewords and 256 as the end of data.
+	want := []uint64{'A', 'B', 'R', 'A', 'C', 'A', 'D', 257, 259, 258, 264, 'A', 256}
+	r := NewBitReader(&compressed)
+	for i, w := range want {
+		got, err := r.ReadBits(lzwWidth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != w {
+			t.Errorf("codeword %d = %d, want %d", i, got, w)
+		}
+	}
+}
+
+func TestExpandCorrupt(t *testing.T) {
+	for _, c := range codecs {
+		if c.name == "RunLength" {
+			// Any sequence of run lengths is valid.
+			continue
+		}
+
+		var compressed bytes.Buffer
+		if err := c.codec.Compress(&compressed, strings.NewReader("ABRACADABRA!")); err != nil {
+			t.Fatal(err)
+		}
+		truncated := compressed.Bytes()[:compressed.Len()/2]
+		err := c.codec.Expand(io.Discard, bytes.NewReader(truncated))
+		if !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, ErrCorrupt) {
+			t.Errorf("%s: Expand(truncated) error = %v, want unexpected EOF", c.name, err)
+		}
+	}
+}
+
+func TestExpandCrafted(t *testing.T) {
+	tests := []struct {
+		name  string
+		codec Codec
+		write func(w *BitWriter)
+	}{
+		{
+			name:  "Huffman/single leaf trie",
+			codec: Huffman{},
+			write: func(w *BitWriter) {
+				w.WriteBit(true)
+				w.WriteByte('a')
+				w.WriteBits(1<<28, 64)
+			},
+		},
+		{
+			name:  "Huffman/deep trie",
+			codec: Huffman{},
+			write: func(w *BitWriter) {
+				for i := 0; i < 300; i++ {
+					w.WriteBit(false)
+				}
+			},
+		},
+		{
+			name:  "BurrowsWheeler/sentinel reached early",
+			codec: BurrowsWheeler{},
+			write: func(w *BitWriter) {
+				w.WriteBit(true)
+				w.WriteBits(2, 32)
+				huffmanWrite(w, moveToFront([]byte("ba")))
+				w.WriteBit(false)
+			},
+		},
+		{
+			name:  "BurrowsWheeler/primary out of range",
+			codec: BurrowsWheeler{},
+			write: func(w *BitWriter) {
+				w.WriteBit(true)
+				w.WriteBits(3, 32)
+				huffmanWrite(w, moveToFront([]byte("ab")))
+				w.WriteBit(false)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		var crafted bytes.Buffer
+		w := NewBitWriter(&crafted)
+		tc.write(w)
+		if err := w.Flush(); err != nil {
+			t.Fatal(err)
+		}
+
+		var out bytes.Buffer
+		if err := tc.codec.Expand(&out, &crafted); err != ErrCorrupt {
+			t.Errorf("%s: Expand() error = %v, want %v", tc.name, err, ErrCorrupt)
+		}
+		if out.Len() > 0 {
+			t.Errorf("%s: Expand() wrote %d bytes", tc.name, out.Len())
+		}
+	}
+}
+
+func TestMoveToFront(t *testing.T) {
+	data := []byte("bananaaa")
+	want := []byte{'b', 'b', 'n', 1, 1, 1, 0, 0}
+	got := moveToFront(data)
+	if !bytes.Equal(got, want) {
+		t.Errorf("moveToFront(%q) = %v, want %v", data, got, want)
+	}
+	if got = moveToFrontInverse(got); !bytes.Equal(got, data) {
+		t.Errorf("moveToFrontInverse() = %q, want %q", got, data)
+	}
+}
+
+func BenchmarkCompress(b *testing.B) {
+	data := []byte(strings.Repeat("it was the best of times it was the worst of times\n", 2000))
+	// Small blocks are only useful to test block boundaries.
+	for _, c := range codecs[:4] {
+		b.Run(c.name, func(b *testing.B) {
+			var compressed bytes.Buffer
+			b.SetBytes(int64(len(data)))
+			for i := 0; i < b.N; i++ {
+				compressed.Reset()
+				if err := c.codec.Compress(&compressed, bytes.NewReader(data)); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(compressed.Len())/float64(len(data)), "ratio")
+		})
+	}
+}
@@ -0,0 +1,193 @@
+package compress
+
+import (
+	"io"
+
+	"github.com/marselester/alg/sort/pqueue"
+)
+
+/*
+Huffman compresses data using a prefix-free code where frequent characters have short codewords.
+The code is represented as a binary trie: the characters are in the leaves,
+and the codeword of a character is the path from the root to its leaf (0 for left, 1 for right).
+
+The trie is built bottom-up: each character is a single-node trie weighted by its frequency,
+then the two tries with the smallest frequencies are merged into a new trie with the sum of their weights
+until a single trie remains. The resulting code is optimal among prefix-free codes.
+
+The compressed data consists of the trie (preorder traversal: 1 and a character for a leaf,
+0 for an internal node), the number of characters (64 bits), and the codewords.
+*/
+type Huffman struct{}
+
+// huffnode is a node of Huffman trie.
+type huffnode struct {
+	ch          byte
+	freq        int
+	left, right *huffnode
+}
+
+func (n *huffnode) isLeaf() bool {
+	return n.left == nil && n.right == nil
+}
+
+// Compress reads the whole input to count the character frequencies and encodes it.
+func (Huffman) Compress(dst io.Writer, src io.Reader) error {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+
+	w := NewBitWriter(dst)
+	huffmanWrite(w, data)
+	return w.Flush()
+}
+
+// Expand reads the trie and decodes the characters.
+func (Huffman) Expand(dst io.Writer, src io.Reader) error {
+	data, err := huffmanRead(NewBitReader(src))
+	if err != nil {
+		return err
+	}
+	_, err = dst.Write(data)
+	return err
+}
+
+// huffmanWrite writes the trie, the data length, and the encoded data.
+// The write errors are reported by w.Flush.
+func huffmanWrite(w *BitWriter, data []byte) {
+	var freq [256]int
+	for _, c := range data {
+		freq[c]++
+	}
+	root := buildTrie(freq)
+
+	var codes [256]string
+	buildCode(&codes, root, "")
+
+	writeTrie(w, root)
+	w.WriteBits(uint64(len(data)), 64)
+	for _, c := range data {
+		for _, bit := range []byte(codes[c]) {
+			w.WriteBit(bit == '1')
+		}
+	}
+}
+
+// huffmanRead reads the trie, the data length, and decodes the data.
+// A trie of a single leaf is never written (see buildTrie),
+// otherwise its empty codeword would let a tiny input claim an arbitrary length.
+func huffmanRead(r *BitReader) ([]byte, error) {
+	root, err := readTrie(r, 0)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+	if root.isLeaf() {
+		return nil, ErrCorrupt
+	}
+	n, err := r.ReadBits(64)
+	if err != nil {
+		return nil, unexpectedEOF(err)
+	}
+
+	var data []byte
+	for i := uint64(0); i < n; i++ {
+		x := root
+		for !x.isLeaf() {
+			bit, err := r.ReadBit()
+			if err != nil {
+				return nil, unexpectedEOF(err)
+			}
+			if bit {
+				x = x.right
+			} else {
+				x = x.left
+			}
+		}
+		data = append(data, x.ch)
+	}
+	return data, nil
+}
+
+// buildTrie builds Huffman trie using a priority queue of the tries ordered by their frequencies.
+func buildTrie(freq [256]int) *huffnode {
+	// There are at most 256 leaves and 255 internal nodes.
+	nodes := make([]*huffnode, 0, 511)
+	pq := pqueue.NewIndexMinHeap(511)
+	insert := func(n *huffnode) {
+		nodes = append(nodes, n)
+		pq.Insert(len(nodes)-1, float64(n.freq))
+	}
+	for c, f := range freq {
+		if f > 0 {
+			insert(&huffnode{ch: byte(c), freq: f})
+		}
+	}
+	// The trie must have at least two leaves, so every character has a non-empty codeword.
+	for c := 0; pq.Size() < 2; c++ {
+		if freq[c] == 0 {
+			insert(&huffnode{ch: byte(c)})
+		}
+	}
+
+	for pq.Size() > 1 {
+		left, _ := pq.Min()
+		right, _ := pq.Min()
+		insert(&huffnode{
+			freq:  nodes[left].freq + nodes[right].freq,
+			left:  nodes[left],
+			right: nodes[right],
+		})
+	}
+	root, _ := pq.Min()
+	return nodes[root]
+}
+
+// buildCode makes a lookup table from the trie: the codeword of each character as a string of 0s and 1s.
+func buildCode(codes *[256]string, n *huffnode, code string) {
+	if n.isLeaf() {
+		codes[n.ch] = code
+		return
+	}
+	buildCode(codes, n.left, code+"0")
+	buildCode(codes, n.right, code+"1")
+}
+
+// writeTrie writes the trie in preorder.
+func writeTrie(w *BitWriter, n *huffnode) {
+	if n.isLeaf() {
+		w.WriteBit(true)
+		w.WriteByte(n.ch)
+		return
+	}
+	w.WriteBit(false)
+	writeTrie(w, n.left)
+	writeTrie(w, n.right)
+}
+
+// readTrie reads the trie written in preorder.
+// The depth guards against malformed input: a trie of 256 leaves is no deeper than 255.
+func readTrie(r *BitReader, depth int) (*huffnode, error) {
+	if depth > 255 {
+		return nil, ErrCorrupt
+	}
+
+	isLeaf, err := r.ReadBit()
+	if err != nil {
+		return nil, err
+	}
+	if isLeaf {
+		ch, err := r.ReadByte()
+		return &huffnode{ch: ch}, err
+	}
+
+	left, err := readTrie(r, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	right, err := readTrie(r, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	return &huffnode{left: left, right: right}, nil
+}
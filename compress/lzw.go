@@ -0,0 +1,121 @@
+package compress
+
+import (
+	"io"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/marselester/alg/strsearch"
+)
+
+const (
+	// lzwRadix is the number of input characters, the code lzwRadix marks the end of data.
+	lzwRadix = 256
+	// lzwCodes is the number of codewords.
+	lzwCodes = 1 << lzwWidth
+	// lzwWidth is the codeword width in bits.
+	lzwWidth = 12
+)
+
+/*
+LZW compresses data with Lempel-Ziv-Welch algorithm which maintains a codebook
+of variable-length strings associated with fixed-length (12-bit) codewords.
+The codebook initially has single-character strings, and the compression repeats:
+
+	find the longest string s in the codebook that is a prefix of the unscanned input
+	write the codeword of s
+	add s followed by the next input character to the codebook
+
+The codebook is a ternary search trie, so the longest prefix match is efficient.
+The expansion maintains the same codebook as an array indexed by codewords,
+it's built by the same rules one step behind the compression.
+*/
+type LZW struct{}
+
+// Compress encodes the input with the codewords.
+func (LZW) Compress(dst io.Writer, src io.Reader) error {
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return err
+	}
+	// The trie keys are Unicode strings, so each byte is represented as a rune of the same value.
+	var b strings.Builder
+	for _, c := range data {
+		b.WriteRune(rune(c))
+	}
+	input := b.String()
+
+	st := strsearch.TernaryTrie{}
+	for c := 0; c < lzwRadix; c++ {
+		st.Put(string(rune(c)), strconv.Itoa(c))
+	}
+
+	w := NewBitWriter(dst)
+	code := lzwRadix + 1
+	for input != "" {
+		s := st.LongestPrefixOf(input)
+		codeword, _ := strconv.Atoi(st.Get(s))
+		w.WriteBits(uint64(codeword), lzwWidth)
+
+		t := len(s)
+		if t < len(input) && code < lzwCodes {
+			_, width := utf8.DecodeRuneInString(input[t:])
+			st.Put(input[:t+width], strconv.Itoa(code))
+			code++
+		}
+		input = input[t:]
+	}
+	w.WriteBits(lzwRadix, lzwWidth)
+	return w.Flush()
+}
+
+// Expand decodes the codewords.
+func (LZW) Expand(dst io.Writer, src io.Reader) error {
+	r := NewBitReader(src)
+	st := make([]string, lzwCodes)
+	for c := 0; c < lzwRadix; c++ {
+		st[c] = string([]byte{byte(c)})
+	}
+	code := lzwRadix + 1
+
+	codeword, err := r.ReadBits(lzwWidth)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if codeword == lzwRadix {
+		return nil
+	}
+	if codeword > lzwRadix {
+		return ErrCorrupt
+	}
+
+	val := st[codeword]
+	for {
+		if _, err = io.WriteString(dst, val); err != nil {
+			return err
+		}
+		if codeword, err = r.ReadBits(lzwWidth); err != nil {
+			return unexpectedEOF(err)
+		}
+		if codeword == lzwRadix {
+			return nil
+		}
+
+		var s string
+		switch {
+		case int(codeword) < code:
+			s = st[codeword]
+		// The codeword is being defined: its string is the previous one followed by its first character.
+		case int(codeword) == code:
+			s = val + val[:1]
+		default:
+			return ErrCorrupt
+		}
+		if code < lzwCodes {
+			st[code] = val + s[:1]
+			code++
+		}
+		val = s
+	}
+}
@@ -0,0 +1,43 @@
+package compress
+
+/*
+moveToFront encodes each byte as its index in a list of all the byte values,
+and then moves that byte to the front of the list.
+The recently seen bytes get small indices, so the runs of equal bytes
+(typical for the Burrows-Wheeler transform output) become runs of zeros.
+*/
+func moveToFront(data []byte) []byte {
+	var list [256]byte
+	for i := range list {
+		list[i] = byte(i)
+	}
+
+	out := make([]byte, len(data))
+	for i, c := range data {
+		j := 0
+		for list[j] != c {
+			j++
+		}
+		out[i] = byte(j)
+		copy(list[1:j+1], list[:j])
+		list[0] = c
+	}
+	return out
+}
+
+// moveToFrontInverse decodes the indices by maintaining the same list as moveToFront.
+func moveToFrontInverse(data []byte) []byte {
+	var list [256]byte
+	for i := range list {
+		list[i] = byte(i)
+	}
+
+	out := make([]byte, len(data))
+	for i, j := range data {
+		c := list[j]
+		out[i] = c
+		copy(list[1:int(j)+1], list[:j])
+		list[0] = c
+	}
+	return out
+}
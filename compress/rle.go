@@ -0,0 +1,70 @@
+package compress
+
+import "io"
+
+// runLengthWidth is the number of bits to encode a run length, so the runs are up to 255 bits long.
+const runLengthWidth = 8
+
+/*
+RunLength compresses a bitstream by encoding the lengths of alternating runs of 0s and 1s,
+starting with a run of 0s. The run lengths are 8-bit counts:
+a run longer than 255 bits is split into runs of 255 separated by an empty run of the other bit.
+
+It's effective for data with long runs of repeated bits, e.g., bitmaps,
+but it expands a typical text, since its runs are short.
+*/
+type RunLength struct{}
+
+// Compress encodes the run lengths of the bits.
+func (RunLength) Compress(dst io.Writer, src io.Reader) error {
+	r := NewBitReader(src)
+	w := NewBitWriter(dst)
+	var (
+		run uint64
+		old bool
+	)
+	for {
+		bit, err := r.ReadBit()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		if bit != old {
+			w.WriteBits(run, runLengthWidth)
+			run = 0
+			old = !old
+		} else if run == 1<<runLengthWidth-1 {
+			w.WriteBits(run, runLengthWidth)
+			w.WriteBits(0, runLengthWidth)
+			run = 0
+		}
+		run++
+	}
+	w.WriteBits(run, runLengthWidth)
+	return w.Flush()
+}
+
+// Expand writes the runs of alternating bits.
+func (RunLength) Expand(dst io.Writer, src io.Reader) error {
+	r := NewBitReader(src)
+	w := NewBitWriter(dst)
+	var bit bool
+	for {
+		run, err := r.ReadBits(runLengthWidth)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		for i := uint64(0); i < run; i++ {
+			w.WriteBit(bit)
+		}
+		bit = !bit
+	}
+	return w.Flush()
+}
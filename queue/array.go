@@ -1,32 +1,63 @@
 package queue
 
-// Array represents a queue of strings that is backed by array.
+import "iter"
+
+// Array represents a queue of strings that is backed by array, see ArrayOf.
+type Array = ArrayOf[string]
+
+// ArrayOf represents a queue of items of type T that is backed by array.
 // Queue grows at a cost of allocating a new array and copying items there.
 // When queue shrinks, it uses the same underlying array.
 // The full array will be kept in memory until it is no longer referenced.
 //
 // Zero value is usable; initially queue has zero capacity.
 // Note, operations are not concurrency safe.
-type Array struct {
-	items []string
+type ArrayOf[T any] struct {
+	items []T
 }
 
 // Enqueue adds an item to the end of the queue.
-func (q *Array) Enqueue(item string) {
+func (q *ArrayOf[T]) Enqueue(item T) {
 	q.items = append(q.items, item)
 }
 
 // Dequeue returns an item from the beginning of the queue.
-func (q *Array) Dequeue() string {
+// When queue is empty, zero value of T (empty string) is returned.
+func (q *ArrayOf[T]) Dequeue() T {
+	var v T
 	if len(q.items) == 0 {
-		return ""
+		return v
 	}
-	v := q.items[0]
+	v = q.items[0]
+	// Clean up the slot so the item can be garbage collected.
+	var zero T
+	q.items[0] = zero
 	q.items = q.items[1:]
 	return v
 }
 
+// Peek returns an item from the beginning of the queue without removing it.
+// When queue is empty, it returns false.
+func (q *ArrayOf[T]) Peek() (v T, ok bool) {
+	if len(q.items) == 0 {
+		return v, false
+	}
+	return q.items[0], true
+}
+
 // Size returns the number of items in the queue.
-func (q *Array) Size() int {
+func (q *ArrayOf[T]) Size() int {
 	return len(q.items)
 }
+
+// All returns an iterator over items from the beginning to the end of the queue.
+// The queue must not be modified during the iteration.
+func (q *ArrayOf[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range q.items {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
@@ -48,3 +48,35 @@ func TestArrayLayoutAfterEnqueueDequeue(t *testing.T) {
 		t.Errorf("Dequeue() got items %v, want none", q.items)
 	}
 }
+
+func TestArrayOfPeek(t *testing.T) {
+	q := ArrayOf[int]{}
+	if _, ok := q.Peek(); ok {
+		t.Errorf("Peek() = _, true, want false")
+	}
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	if got, ok := q.Peek(); !ok || got != 1 {
+		t.Errorf("Peek() = %d, %v, want 1, true", got, ok)
+	}
+	if q.Size() != 2 {
+		t.Errorf("Peek() count is %d, want 2", q.Size())
+	}
+}
+
+func TestArrayOfAll(t *testing.T) {
+	q := Array{}
+	var items = []string{"a", "b", "c"}
+	for _, v := range items {
+		q.Enqueue(v)
+	}
+
+	var got []string
+	for v := range q.All() {
+		got = append(got, v)
+	}
+	if !equal(got, items) {
+		t.Errorf("All() = %q, want %q", got, items)
+	}
+}
@@ -0,0 +1,112 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned when the queue has been closed.
+var ErrClosed = errors.New("queue: closed")
+
+// Blocking is a bounded FIFO queue safe for concurrent use, where Enqueue waits
+// while the queue is full, and Dequeue waits while the queue is empty.
+// Waiting can be canceled with a context.
+//
+// It's a RingBuffer guarded by a mutex. Whenever the queue changes,
+// a broadcast channel is closed to wake up the waiting goroutines,
+// and a new channel is made for the next change.
+// Zero value is unusable, please use NewBlocking.
+type Blocking[T any] struct {
+	mu  sync.Mutex
+	buf *RingBufferOf[T]
+	// changed is closed when an item is added or removed, or the queue is closed.
+	changed chan struct{}
+	closed  bool
+}
+
+// NewBlocking returns a blocking queue of a fixed size capacity.
+// Capacity less than one is treated as one.
+func NewBlocking[T any](capacity int) *Blocking[T] {
+	return &Blocking[T]{
+		buf:     NewRingBufferOf[T](max(1, capacity)),
+		changed: make(chan struct{}),
+	}
+}
+
+// broadcast wakes up the waiting goroutines. It must be called with the mutex held.
+func (q *Blocking[T]) broadcast() {
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
+
+// Enqueue adds an item to the end of the queue, waiting for a free slot if necessary.
+// It returns ErrClosed if the queue is closed, or the context's error if the context is done
+// before the item was added.
+func (q *Blocking[T]) Enqueue(ctx context.Context, item T) error {
+	for {
+		q.mu.Lock()
+		if q.closed {
+			q.mu.Unlock()
+			return ErrClosed
+		}
+		if q.buf.Enqueue(item) {
+			q.broadcast()
+			q.mu.Unlock()
+			return nil
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Dequeue returns an item from the beginning of the queue, waiting for an item if necessary.
+// Items left in a closed queue can still be dequeued, after that ErrClosed is returned.
+// The context's error is returned if the context is done before an item was received.
+func (q *Blocking[T]) Dequeue(ctx context.Context) (v T, err error) {
+	for {
+		q.mu.Lock()
+		if item, ok := q.buf.Dequeue(); ok {
+			q.broadcast()
+			q.mu.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.mu.Unlock()
+			return v, ErrClosed
+		}
+		changed := q.changed
+		q.mu.Unlock()
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return v, ctx.Err()
+		}
+	}
+}
+
+// Close closes the queue: Enqueue fails from now on, and Dequeue fails once the queue is drained.
+// The waiting goroutines are woken up. Closing a closed queue has no effect.
+func (q *Blocking[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return
+	}
+	q.closed = true
+	q.broadcast()
+}
+
+// Size returns the number of items in the queue.
+func (q *Blocking[T]) Size() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.buf.Size()
+}
@@ -0,0 +1,130 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestBlocking(t *testing.T) {
+	ctx := context.Background()
+	q := NewBlocking[int](1)
+	if err := q.Enqueue(ctx, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The queue is full, so Enqueue waits until the deadline.
+	timeoutCtx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := q.Enqueue(timeoutCtx, 2); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Enqueue() = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	if v, err := q.Dequeue(ctx); err != nil || v != 1 {
+		t.Errorf("Dequeue() = %d, %v, want 1, nil", v, err)
+	}
+
+	// The queue is empty, so Dequeue waits until the context is canceled.
+	cancelCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	if _, err := q.Dequeue(cancelCtx); !errors.Is(err, context.Canceled) {
+		t.Errorf("Dequeue() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestBlockingClose(t *testing.T) {
+	ctx := context.Background()
+	q := NewBlocking[string](2)
+	q.Enqueue(ctx, "a")
+	q.Close()
+	q.Close()
+
+	if err := q.Enqueue(ctx, "b"); err != ErrClosed {
+		t.Errorf("Enqueue() = %v, want %v", err, ErrClosed)
+	}
+	// The remaining items are drained first.
+	if v, err := q.Dequeue(ctx); err != nil || v != "a" {
+		t.Errorf("Dequeue() = %q, %v, want a, nil", v, err)
+	}
+	if _, err := q.Dequeue(ctx); err != ErrClosed {
+		t.Errorf("Dequeue() = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestBlockingCloseWakesUp(t *testing.T) {
+	q := NewBlocking[int](1)
+	errc := make(chan error)
+	go func() {
+		_, err := q.Dequeue(context.Background())
+		errc <- err
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	if err := <-errc; err != ErrClosed {
+		t.Errorf("Dequeue() = %v, want %v", err, ErrClosed)
+	}
+}
+
+func TestBlockingConcurrent(t *testing.T) {
+	const (
+		producers = 4
+		perWorker = 5000
+	)
+	ctx := context.Background()
+	q := NewBlocking[int](8)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 1; i <= perWorker; i++ {
+				if err := q.Enqueue(ctx, i); err != nil {
+					t.Error(err)
+					return
+				}
+			}
+		}()
+	}
+	go func() {
+		wg.Wait()
+		q.Close()
+	}()
+
+	sums := make(chan int)
+	for c := 0; c < 4; c++ {
+		go func() {
+			var sum int
+			for {
+				v, err := q.Dequeue(ctx)
+				if err != nil {
+					break
+				}
+				sum += v
+			}
+			sums <- sum
+		}()
+	}
+
+	var total int
+	for c := 0; c < 4; c++ {
+		total += <-sums
+	}
+	if want := producers * perWorker * (perWorker + 1) / 2; total != want {
+		t.Errorf("sum of dequeued items = %d, want %d", total, want)
+	}
+}
+
+func BenchmarkBlocking(b *testing.B) {
+	ctx := context.Background()
+	q := NewBlocking[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			q.Enqueue(ctx, 1)
+			q.Dequeue(ctx)
+		}
+	})
+}
@@ -0,0 +1,141 @@
+package queue
+
+import "iter"
+
+// Deque (double-ended queue) is a generalization of a stack and a queue
+// that supports adding and removing items from either the front or the back.
+//
+// This implementation uses a resizing circular array: it doubles the array
+// when it's full and halves it when it's one-quarter full,
+// so the array is always between 25% and 100% full.
+// The time per operation is constant (amortized).
+//
+// Zero value is usable; initially deque has zero capacity.
+// Note, operations are not concurrency safe.
+type Deque[T any] struct {
+	items []T
+	// n indicates number of items in the deque.
+	n int
+	// first is an index in the array of the item at the front of the deque.
+	first int
+}
+
+// NewDeque creates a deque with given capacity.
+// Negative capacity is ignored, zero value is used instead.
+func NewDeque[T any](capacity int) *Deque[T] {
+	if capacity < 0 {
+		capacity = 0
+	}
+	return &Deque[T]{
+		items: make([]T, capacity),
+	}
+}
+
+// index returns the array index of the i-th item from the front.
+func (d *Deque[T]) index(i int) int {
+	return (d.first + i) % len(d.items)
+}
+
+// resize moves the items to a new array of the given capacity
+// so that the front item is at index 0.
+func (d *Deque[T]) resize(capacity int) {
+	items := make([]T, capacity)
+	for i := 0; i < d.n; i++ {
+		items[i] = d.items[d.index(i)]
+	}
+	d.items = items
+	d.first = 0
+}
+
+// grow doubles the array when it's full.
+func (d *Deque[T]) grow() {
+	if d.n < len(d.items) {
+		return
+	}
+	d.resize(max(1, 2*len(d.items)))
+}
+
+// shrink halves the array when it's one-quarter full.
+func (d *Deque[T]) shrink() {
+	if d.n > 0 && d.n == len(d.items)/4 {
+		d.resize(len(d.items) / 2)
+	}
+}
+
+// PushFront adds an item to the front of the deque.
+func (d *Deque[T]) PushFront(item T) {
+	d.grow()
+	d.first = (d.first - 1 + len(d.items)) % len(d.items)
+	d.items[d.first] = item
+	d.n++
+}
+
+// PushBack adds an item to the back of the deque.
+func (d *Deque[T]) PushBack(item T) {
+	d.grow()
+	d.items[d.index(d.n)] = item
+	d.n++
+}
+
+// PopFront removes and returns an item from the front of the deque.
+// When deque is empty, it returns false.
+func (d *Deque[T]) PopFront() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	var zero T
+	v, d.items[d.first] = d.items[d.first], zero
+	d.first = d.index(1)
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// PopBack removes and returns an item from the back of the deque.
+// When deque is empty, it returns false.
+func (d *Deque[T]) PopBack() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	var zero T
+	last := d.index(d.n - 1)
+	v, d.items[last] = d.items[last], zero
+	d.n--
+	d.shrink()
+	return v, true
+}
+
+// PeekFront returns an item from the front of the deque without removing it.
+// When deque is empty, it returns false.
+func (d *Deque[T]) PeekFront() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.items[d.first], true
+}
+
+// PeekBack returns an item from the back of the deque without removing it.
+// When deque is empty, it returns false.
+func (d *Deque[T]) PeekBack() (v T, ok bool) {
+	if d.n == 0 {
+		return v, false
+	}
+	return d.items[d.index(d.n-1)], true
+}
+
+// Size returns the number of items in the deque.
+func (d *Deque[T]) Size() int {
+	return d.n
+}
+
+// All returns an iterator over items from the front to the back of the deque.
+// The deque must not be modified during the iteration.
+func (d *Deque[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.n; i++ {
+			if !yield(d.items[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package queue
+
+import "testing"
+
+func TestDeque(t *testing.T) {
+	d := Deque[string]{}
+	if _, ok := d.PopFront(); ok {
+		t.Errorf("PopFront() = _, true, want false")
+	}
+	if _, ok := d.PopBack(); ok {
+		t.Errorf("PopBack() = _, true, want false")
+	}
+
+	d.PushBack("b")
+	d.PushFront("a")
+	d.PushBack("c")
+	d.PushFront("0")
+
+	var got []string
+	for v := range d.All() {
+		got = append(got, v)
+	}
+	want := []string{"0", "a", "b", "c"}
+	if !equal(got, want) {
+		t.Errorf("All() = %q, want %q", got, want)
+	}
+
+	if v, ok := d.PeekFront(); !ok || v != "0" {
+		t.Errorf("PeekFront() = %q, %v, want 0, true", v, ok)
+	}
+	if v, ok := d.PeekBack(); !ok || v != "c" {
+		t.Errorf("PeekBack() = %q, %v, want c, true", v, ok)
+	}
+	if v, ok := d.PopBack(); !ok || v != "c" {
+		t.Errorf("PopBack() = %q, %v, want c, true", v, ok)
+	}
+	if v, ok := d.PopFront(); !ok || v != "0" {
+		t.Errorf("PopFront() = %q, %v, want 0, true", v, ok)
+	}
+	if d.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", d.Size())
+	}
+}
+
+func TestDequeResize(t *testing.T) {
+	d := NewDeque[int](-1)
+	for i := 0; i < 100; i++ {
+		d.PushFront(i)
+	}
+	if len(d.items) != 128 {
+		t.Errorf("capacity = %d, want 128", len(d.items))
+	}
+
+	for i := 0; i < 100; i++ {
+		v, ok := d.PopBack()
+		if !ok || v != i {
+			t.Fatalf("PopBack() = %d, %v, want %d, true", v, ok, i)
+		}
+	}
+	if len(d.items) > 4 {
+		t.Errorf("capacity = %d, want <= 4", len(d.items))
+	}
+	if d.Size() != 0 {
+		t.Errorf("Size() = %d, want 0", d.Size())
+	}
+}
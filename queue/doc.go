@@ -1,3 +1,9 @@
 // Package queue implements a queue data type that is based on
 // the first-in-first-out (FIFO) policy.
+// It also provides a deque (double-ended queue) and a randomized queue
+// which removes a random item.
+//
+// The queues are not safe for concurrent use except for SPSC, MPMC and Blocking queues.
+// SPSC and MPMC are lock-free, and they don't wait: Enqueue and Dequeue fail when
+// the queue is full or empty. Blocking queue waits for a free slot or an item instead.
 package queue
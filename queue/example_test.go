@@ -32,3 +32,15 @@ func ExampleRingBuffer() {
 	// false
 	// fizz true
 }
+
+func ExampleDeque() {
+	d := queue.Deque[int]{}
+	d.PushBack(2)
+	d.PushFront(1)
+	d.PushBack(3)
+	for v := range d.All() {
+		fmt.Print(v, " ")
+	}
+	fmt.Println(d.PopBack())
+	// Output: 1 2 3 3 true
+}
@@ -1,28 +1,33 @@
 package queue
 
-// LinkedList represents a queue of strings based on linked-list data structure.
+import "iter"
+
+// LinkedList represents a queue of strings based on linked-list data structure, see LinkedListOf.
+type LinkedList = LinkedListOf[string]
+
+// LinkedListOf represents a queue of items of type T based on linked-list data structure.
 // The space required is always proportional to the size of of the collection.
 // The time per operation is always independent of the size of the collection.
 //
-// The zero value for LinkedList is ready to use.
+// The zero value for LinkedListOf is ready to use.
 // Note, operations are not concurrency safe.
-type LinkedList struct {
+type LinkedListOf[T any] struct {
 	// first is the node at the the beginning of the queue.
-	first *node
+	first *node[T]
 	// last is the node at the end of the queue.
-	last *node
+	last *node[T]
 	// n is the number of items in the queue.
 	n int
 }
 
-type node struct {
-	item string
-	next *node
+type node[T any] struct {
+	item T
+	next *node[T]
 }
 
 // Enqueue adds an item to the end of the queue.
-func (q *LinkedList) Enqueue(item string) {
-	newnode := node{item: item}
+func (q *LinkedListOf[T]) Enqueue(item T) {
+	newnode := node[T]{item: item}
 	if q.last != nil {
 		q.last.next = &newnode
 	}
@@ -34,12 +39,14 @@ func (q *LinkedList) Enqueue(item string) {
 }
 
 // Dequeue returns an item from the beginning of the queue.
-func (q *LinkedList) Dequeue() string {
+// When queue is empty, zero value of T (empty string) is returned.
+func (q *LinkedListOf[T]) Dequeue() T {
+	var v T
 	if q.first == nil {
-		return ""
+		return v
 	}
 
-	v := q.first.item
+	v = q.first.item
 	q.first = q.first.next
 	q.n--
 	if q.first == nil {
@@ -48,7 +55,28 @@ func (q *LinkedList) Dequeue() string {
 	return v
 }
 
+// Peek returns an item from the beginning of the queue without removing it.
+// When queue is empty, it returns false.
+func (q *LinkedListOf[T]) Peek() (v T, ok bool) {
+	if q.first == nil {
+		return v, false
+	}
+	return q.first.item, true
+}
+
 // Size returns the number of items in the queue.
-func (q *LinkedList) Size() int {
+func (q *LinkedListOf[T]) Size() int {
 	return q.n
 }
+
+// All returns an iterator over items from the beginning to the end of the queue.
+// The queue must not be modified during the iteration.
+func (q *LinkedListOf[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := q.first; n != nil; n = n.next {
+			if !yield(n.item) {
+				return
+			}
+		}
+	}
+}
@@ -35,7 +35,7 @@ func TestLinkedListEnqueueFirstItem(t *testing.T) {
 	q := LinkedList{}
 
 	q.Enqueue("fizz")
-	want := node{item: "fizz"}
+	want := node[string]{item: "fizz"}
 	if *q.first != want {
 		t.Errorf("Enqueue(fizz) first is %v, want %v", *q.first, want)
 	}
@@ -76,3 +76,38 @@ func TestLinkedListDequeueLastItem(t *testing.T) {
 		t.Errorf("Dequeue() count is %d, want 0", q.Size())
 	}
 }
+
+func TestLinkedListOfPeek(t *testing.T) {
+	q := LinkedListOf[int]{}
+	if _, ok := q.Peek(); ok {
+		t.Errorf("Peek() = _, true, want false")
+	}
+
+	q.Enqueue(1)
+	q.Enqueue(2)
+	if got, ok := q.Peek(); !ok || got != 1 {
+		t.Errorf("Peek() = %d, %v, want 1, true", got, ok)
+	}
+	if q.Size() != 2 {
+		t.Errorf("Peek() count is %d, want 2", q.Size())
+	}
+}
+
+func TestLinkedListOfAll(t *testing.T) {
+	q := LinkedList{}
+	var items = []string{"a", "b", "c"}
+	for _, v := range items {
+		q.Enqueue(v)
+	}
+
+	var got []string
+	for v := range q.All() {
+		got = append(got, v)
+		if v == "b" {
+			break
+		}
+	}
+	if want := items[:2]; !equal(got, want) {
+		t.Errorf("All() = %q, want %q", got, want)
+	}
+}
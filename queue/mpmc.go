@@ -0,0 +1,112 @@
+package queue
+
+import "sync/atomic"
+
+// MPMC is a lock-free bounded FIFO queue for multiple producers and multiple consumers
+// designed by Dmitry Vyukov
+// https://www.1024cores.net/home/lock-free-algorithms/queues/bounded-mpmc-queue.
+//
+// Like in a RingBuffer, items are stored in a circular array, but each slot (cell)
+// also has a sequence number that tells whose turn it is to use the slot.
+// A producer at position pos may write into the cell when its sequence equals pos,
+// then it sets the sequence to pos+1 to let a consumer in.
+// A consumer at position pos may read the cell when its sequence equals pos+1,
+// then it sets the sequence to pos+capacity, i.e., the position of the producer on the next lap.
+// Producers (and consumers) compete for positions with a single compare-and-swap,
+// and they never wait for each other while holding a lock.
+//
+// The capacity is rounded up to a power of 2.
+// Zero value is unusable, please use NewMPMC.
+type MPMC[T any] struct {
+	cells []cell[T]
+	mask  uint64
+	_     cacheLinePad
+	// writePos is the position of the next enqueue.
+	writePos atomic.Uint64
+	_        cacheLinePad
+	// readPos is the position of the next dequeue.
+	readPos atomic.Uint64
+	_       cacheLinePad
+}
+type cell[T any] struct {
+	seq  atomic.Uint64
+	item T
+}
+
+// NewMPMC returns multi-producer multi-consumer queue
+// with capacity rounded up to a power of 2.
+func NewMPMC[T any](capacity int) *MPMC[T] {
+	n := powerOfTwo(capacity)
+	q := MPMC[T]{
+		cells: make([]cell[T], n),
+		mask:  uint64(n - 1),
+	}
+	for i := range q.cells {
+		q.cells[i].seq.Store(uint64(i))
+	}
+	return &q
+}
+
+// Enqueue adds an item to the end of the queue.
+// When queue is full, it returns false.
+func (q *MPMC[T]) Enqueue(item T) bool {
+	pos := q.writePos.Load()
+	for {
+		c := &q.cells[pos&q.mask]
+		seq := c.seq.Load()
+		switch dif := int64(seq - pos); {
+		// The cell is free, try to claim the position.
+		case dif == 0:
+			if q.writePos.CompareAndSwap(pos, pos+1) {
+				c.item = item
+				c.seq.Store(pos + 1)
+				return true
+			}
+			pos = q.writePos.Load()
+		// The cell still holds an item from the previous lap, so the queue is full.
+		case dif < 0:
+			return false
+		// Another producer has claimed the position.
+		default:
+			pos = q.writePos.Load()
+		}
+	}
+}
+
+// Dequeue returns an item from the beginning of the queue.
+// When queue is empty, it returns false.
+func (q *MPMC[T]) Dequeue() (v T, ok bool) {
+	pos := q.readPos.Load()
+	for {
+		c := &q.cells[pos&q.mask]
+		seq := c.seq.Load()
+		switch dif := int64(seq - (pos + 1)); {
+		// The cell has an item, try to claim the position.
+		case dif == 0:
+			if q.readPos.CompareAndSwap(pos, pos+1) {
+				var zero T
+				v, c.item = c.item, zero
+				c.seq.Store(pos + q.mask + 1)
+				return v, true
+			}
+			pos = q.readPos.Load()
+		// The item hasn't been written yet, so the queue is empty.
+		case dif < 0:
+			return v, false
+		// Another consumer has claimed the position.
+		default:
+			pos = q.readPos.Load()
+		}
+	}
+}
+
+// Size returns the approximate number of items in the queue.
+// It's only a snapshot when the queue is used concurrently.
+func (q *MPMC[T]) Size() int {
+	r := q.readPos.Load()
+	w := q.writePos.Load()
+	if w < r {
+		return 0
+	}
+	return int(w - r)
+}
@@ -0,0 +1,135 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestMPMC(t *testing.T) {
+	q := NewMPMC[string](2)
+	if !q.Enqueue("a") || !q.Enqueue("b") {
+		t.Fatalf("Enqueue() = false, want true")
+	}
+	if q.Enqueue("c") {
+		t.Errorf("Enqueue(c) = true, want false")
+	}
+	if q.Size() != 2 {
+		t.Errorf("Size() = %d, want 2", q.Size())
+	}
+	for _, want := range []string{"a", "b"} {
+		if v, ok := q.Dequeue(); !ok || v != want {
+			t.Fatalf("Dequeue() = %q, %v, want %q, true", v, ok, want)
+		}
+	}
+	if _, ok := q.Dequeue(); ok {
+		t.Errorf("Dequeue() = _, true, want false")
+	}
+	// The cells are reused on the next lap.
+	if !q.Enqueue("c") {
+		t.Errorf("Enqueue(c) = false, want true")
+	}
+	if v, ok := q.Dequeue(); !ok || v != "c" {
+		t.Errorf("Dequeue() = %q, %v, want c, true", v, ok)
+	}
+}
+
+func TestMPMCConcurrent(t *testing.T) {
+	const (
+		producers = 4
+		consumers = 4
+		perWorker = 10000
+		n         = producers * perWorker
+	)
+	q := NewMPMC[int](16)
+
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int) {
+			defer wg.Done()
+			for i := 0; i < perWorker; {
+				if q.Enqueue(p*perWorker + i) {
+					i++
+				} else {
+					runtime.Gosched()
+				}
+			}
+		}(p)
+	}
+
+	results := make(chan []int, consumers)
+	var received sync.WaitGroup
+	var mu sync.Mutex
+	var total int
+	for c := 0; c < consumers; c++ {
+		received.Add(1)
+		go func() {
+			defer received.Done()
+			var got []int
+			for {
+				mu.Lock()
+				finished := total == n
+				mu.Unlock()
+				if finished {
+					break
+				}
+				v, ok := q.Dequeue()
+				if !ok {
+					runtime.Gosched()
+					continue
+				}
+				got = append(got, v)
+				mu.Lock()
+				total++
+				mu.Unlock()
+			}
+			results <- got
+		}()
+	}
+	wg.Wait()
+	received.Wait()
+	close(results)
+
+	seen := make([]bool, n)
+	for got := range results {
+		for _, v := range got {
+			if seen[v] {
+				t.Fatalf("item %d was dequeued twice", v)
+			}
+			seen[v] = true
+		}
+	}
+	for v, ok := range seen {
+		if !ok {
+			t.Fatalf("item %d was lost", v)
+		}
+	}
+}
+
+func BenchmarkMPMC(b *testing.B) {
+	q := NewMPMC[int](1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			for !q.Enqueue(1) {
+				runtime.Gosched()
+			}
+			for {
+				if _, ok := q.Dequeue(); ok {
+					break
+				}
+				runtime.Gosched()
+			}
+		}
+	})
+}
+
+func BenchmarkChannelMPMC(b *testing.B) {
+	ch := make(chan int, 1024)
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			ch <- 1
+			<-ch
+		}
+	})
+}
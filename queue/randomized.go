@@ -0,0 +1,67 @@
+package queue
+
+import (
+	"iter"
+	"math/rand"
+)
+
+// RandomizedQueue is a queue where the item removed is chosen uniformly at random
+// among items in the queue. For example, it can be used to deal cards from a shuffled deck.
+//
+// This implementation uses an array: Dequeue swaps a random item with the last one
+// and removes the last one, so the operations take constant (amortized) time.
+//
+// Zero value is usable; initially queue has zero capacity.
+// Note, operations are not concurrency safe.
+type RandomizedQueue[T any] struct {
+	items []T
+}
+
+// Enqueue adds an item to the queue.
+func (q *RandomizedQueue[T]) Enqueue(item T) {
+	q.items = append(q.items, item)
+}
+
+// Dequeue removes and returns a random item.
+// When queue is empty, it returns false.
+func (q *RandomizedQueue[T]) Dequeue() (v T, ok bool) {
+	if len(q.items) == 0 {
+		return v, false
+	}
+	i := rand.Intn(len(q.items))
+	last := len(q.items) - 1
+	v = q.items[i]
+	q.items[i] = q.items[last]
+	// Clean up the slot so the item can be garbage collected.
+	var zero T
+	q.items[last] = zero
+	q.items = q.items[:last]
+	return v, true
+}
+
+// Sample returns a random item without removing it.
+// When queue is empty, it returns false.
+func (q *RandomizedQueue[T]) Sample() (v T, ok bool) {
+	if len(q.items) == 0 {
+		return v, false
+	}
+	return q.items[rand.Intn(len(q.items))], true
+}
+
+// Size returns the number of items in the queue.
+func (q *RandomizedQueue[T]) Size() int {
+	return len(q.items)
+}
+
+// All returns an iterator over items in random order.
+// Each iteration has its own order independent of the others.
+// The queue must not be modified during the iteration.
+func (q *RandomizedQueue[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, i := range rand.Perm(len(q.items)) {
+			if !yield(q.items[i]) {
+				return
+			}
+		}
+	}
+}
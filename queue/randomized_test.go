@@ -0,0 +1,61 @@
+package queue
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestRandomizedQueue(t *testing.T) {
+	q := RandomizedQueue[string]{}
+	if _, ok := q.Dequeue(); ok {
+		t.Errorf("Dequeue() = _, true, want false")
+	}
+	if _, ok := q.Sample(); ok {
+		t.Errorf("Sample() = _, true, want false")
+	}
+
+	items := []string{"a", "b", "c", "d", "e"}
+	for _, v := range items {
+		q.Enqueue(v)
+	}
+	if v, ok := q.Sample(); !ok || v < "a" || v > "e" {
+		t.Errorf("Sample() = %q, %v, want an item, true", v, ok)
+	}
+
+	var iterated []string
+	for v := range q.All() {
+		iterated = append(iterated, v)
+	}
+	sort.Strings(iterated)
+	if !equal(iterated, items) {
+		t.Errorf("All() = %q, want %q", iterated, items)
+	}
+
+	var got []string
+	for q.Size() > 0 {
+		v, _ := q.Dequeue()
+		got = append(got, v)
+	}
+	sort.Strings(got)
+	if !equal(got, items) {
+		t.Errorf("Dequeue() = %q, want %q", got, items)
+	}
+}
+
+func TestRandomizedQueueUniform(t *testing.T) {
+	const trials = 30000
+	counts := make(map[int]int)
+	for i := 0; i < trials; i++ {
+		q := RandomizedQueue[int]{}
+		q.Enqueue(0)
+		q.Enqueue(1)
+		q.Enqueue(2)
+		v, _ := q.Dequeue()
+		counts[v]++
+	}
+	for v := 0; v < 3; v++ {
+		if counts[v] < trials/3-1000 || counts[v] > trials/3+1000 {
+			t.Errorf("item %d was dequeued %d times, want ~%d", v, counts[v], trials/3)
+		}
+	}
+}
@@ -1,6 +1,11 @@
 package queue
 
-// RingBuffer (circular queue) is a FIFO data structure of a fixed size
+import "iter"
+
+// RingBuffer is a ring buffer of strings, see RingBufferOf.
+type RingBuffer = RingBufferOf[string]
+
+// RingBufferOf (circular queue) is a FIFO data structure of a fixed size
 // https://en.wikipedia.org/wiki/Circular_buffer.
 // When the buffer is empty, the consumer waits until data is deposited;
 // when the buffer is full, the producer waits to deposit data.
@@ -8,10 +13,10 @@ package queue
 // for storing log files.
 //
 // This implementation uses an array representation.
-// Zero value is unusable (it has zero capacity), please use NewRingBuffer.
+// Zero value is unusable (it has zero capacity), please use NewRingBufferOf.
 // Note, operations are not concurrency safe.
-type RingBuffer struct {
-	items []string
+type RingBufferOf[T any] struct {
+	items []T
 	// n indicates number of items in the queue.
 	n int
 	// writePos is an index in the array where a new item will be written at (enqueue).
@@ -20,19 +25,24 @@ type RingBuffer struct {
 	readPos int
 }
 
-// NewRingBuffer returns a ring buffer of a fixed size capacity.
+// NewRingBuffer returns a ring buffer of strings of a fixed size capacity.
 func NewRingBuffer(capacity int) *RingBuffer {
+	return NewRingBufferOf[string](capacity)
+}
+
+// NewRingBufferOf returns a ring buffer of items of type T of a fixed size capacity.
+func NewRingBufferOf[T any](capacity int) *RingBufferOf[T] {
 	if capacity < 0 {
 		capacity = 0
 	}
-	return &RingBuffer{
-		items: make([]string, capacity),
+	return &RingBufferOf[T]{
+		items: make([]T, capacity),
 	}
 }
 
 // Enqueue adds an item to the end of the queue.
 // When queue is full, it returns false.
-func (r *RingBuffer) Enqueue(item string) bool {
+func (r *RingBufferOf[T]) Enqueue(item T) bool {
 	// Queue is full.
 	if r.n == len(r.items) {
 		return false
@@ -44,23 +54,45 @@ func (r *RingBuffer) Enqueue(item string) bool {
 }
 
 // Dequeue returns an item from the beginning of the queue.
-func (r *RingBuffer) Dequeue() (string, bool) {
+func (r *RingBufferOf[T]) Dequeue() (v T, ok bool) {
 	// Queue is empty.
 	if r.n == 0 {
-		return "", false
+		return v, false
 	}
-	v := r.items[r.readPos]
-	r.items[r.readPos] = "" // Clean up so it's easier to test.
+	v = r.items[r.readPos]
+	var zero T
+	r.items[r.readPos] = zero // Clean up so it's easier to test.
 	r.readPos = next(r.readPos, len(r.items))
 	r.n--
 	return v, true
 }
 
+// Peek returns an item from the beginning of the queue without removing it.
+// When queue is empty, it returns false.
+func (r *RingBufferOf[T]) Peek() (v T, ok bool) {
+	if r.n == 0 {
+		return v, false
+	}
+	return r.items[r.readPos], true
+}
+
 // Size returns the number of items in the queue.
-func (r *RingBuffer) Size() int {
+func (r *RingBufferOf[T]) Size() int {
 	return r.n
 }
 
+// All returns an iterator over items from the beginning to the end of the queue.
+// The queue must not be modified during the iteration.
+func (r *RingBufferOf[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i, pos := 0, r.readPos; i < r.n; i, pos = i+1, next(pos, len(r.items)) {
+			if !yield(r.items[pos]) {
+				return
+			}
+		}
+	}
+}
+
 // next moves cursor i in a circle with fixed capacity.
 // For example, a circle has capacity of 3 elements, then
 // next positions of a cursor are 0 -> 1 -> 2 -> 0 -> 1 and so on.
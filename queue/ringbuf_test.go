@@ -88,3 +88,25 @@ func TestNext(t *testing.T) {
 		}
 	}
 }
+
+func TestRingBufferOfAll(t *testing.T) {
+	rb := NewRingBufferOf[string](3)
+	rb.Enqueue("1")
+	rb.Enqueue("2")
+	rb.Dequeue()
+	rb.Enqueue("3")
+	rb.Enqueue("4")
+
+	if got, ok := rb.Peek(); !ok || got != "2" {
+		t.Errorf("Peek() = %q, %v; want 2, true", got, ok)
+	}
+
+	var got []string
+	for v := range rb.All() {
+		got = append(got, v)
+	}
+	want := []string{"2", "3", "4"}
+	if !equal(got, want) {
+		t.Errorf("All() = %q, want %q", got, want)
+	}
+}
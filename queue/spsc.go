@@ -0,0 +1,83 @@
+package queue
+
+import "sync/atomic"
+
+// cacheLinePad separates fields written by different goroutines,
+// so they don't end up in the same CPU cache line (false sharing).
+type cacheLinePad [64]byte
+
+// SPSC is a lock-free bounded FIFO queue for a single producer goroutine
+// and a single consumer goroutine. It's a RingBuffer whose read and write positions
+// are atomic: the producer is the only one who advances the write position,
+// and the consumer is the only one who advances the read position,
+// so no compare-and-swap loops are needed.
+//
+// The positions grow monotonically and are mapped into the array with a bit mask,
+// hence the capacity is rounded up to a power of 2.
+// Zero value is unusable, please use NewSPSC.
+type SPSC[T any] struct {
+	items []T
+	mask  uint64
+	_     cacheLinePad
+	// writePos is a number of items enqueued so far, it's changed only by the producer.
+	writePos atomic.Uint64
+	_        cacheLinePad
+	// readPos is a number of items dequeued so far, it's changed only by the consumer.
+	readPos atomic.Uint64
+	_       cacheLinePad
+}
+
+// NewSPSC returns single-producer single-consumer queue
+// with capacity rounded up to a power of 2.
+func NewSPSC[T any](capacity int) *SPSC[T] {
+	n := powerOfTwo(capacity)
+	return &SPSC[T]{
+		items: make([]T, n),
+		mask:  uint64(n - 1),
+	}
+}
+
+// Enqueue adds an item to the end of the queue.
+// When queue is full, it returns false.
+// It must be called only by the producer goroutine.
+func (q *SPSC[T]) Enqueue(item T) bool {
+	w := q.writePos.Load()
+	if w-q.readPos.Load() == uint64(len(q.items)) {
+		return false
+	}
+	q.items[w&q.mask] = item
+	// The store publishes the item to the consumer.
+	q.writePos.Store(w + 1)
+	return true
+}
+
+// Dequeue returns an item from the beginning of the queue.
+// When queue is empty, it returns false.
+// It must be called only by the consumer goroutine.
+func (q *SPSC[T]) Dequeue() (v T, ok bool) {
+	r := q.readPos.Load()
+	if r == q.writePos.Load() {
+		return v, false
+	}
+	var zero T
+	v, q.items[r&q.mask] = q.items[r&q.mask], zero
+	// The store gives the slot back to the producer.
+	q.readPos.Store(r + 1)
+	return v, true
+}
+
+// Size returns the number of items in the queue.
+// It's only a snapshot when the queue is used concurrently.
+func (q *SPSC[T]) Size() int {
+	r := q.readPos.Load()
+	return int(q.writePos.Load() - r)
+}
+
+// powerOfTwo returns the smallest power of 2 that is not less than n and 2.
+func powerOfTwo(n int) int {
+	p := 2
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
@@ -0,0 +1,105 @@
+package queue
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+)
+
+func TestSPSC(t *testing.T) {
+	q := NewSPSC[int](3)
+	if len(q.items) != 4 {
+		t.Errorf("NewSPSC(3) capacity = %d, want 4", len(q.items))
+	}
+	for i := 0; i < 4; i++ {
+		if !q.Enqueue(i) {
+			t.Fatalf("Enqueue(%d) = false, want true", i)
+		}
+	}
+	if q.Enqueue(4) {
+		t.Errorf("Enqueue(4) = true, want false")
+	}
+	if q.Size() != 4 {
+		t.Errorf("Size() = %d, want 4", q.Size())
+	}
+	for i := 0; i < 4; i++ {
+		if v, ok := q.Dequeue(); !ok || v != i {
+			t.Fatalf("Dequeue() = %d, %v, want %d, true", v, ok, i)
+		}
+	}
+	if _, ok := q.Dequeue(); ok {
+		t.Errorf("Dequeue() = _, true, want false")
+	}
+}
+
+func TestSPSCConcurrent(t *testing.T) {
+	const n = 100000
+	q := NewSPSC[int](64)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < n; {
+			if q.Enqueue(i) {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+	}()
+
+	// Items must arrive in order.
+	for want := 0; want < n; {
+		v, ok := q.Dequeue()
+		if !ok {
+			runtime.Gosched()
+			continue
+		}
+		if v != want {
+			t.Fatalf("Dequeue() = %d, want %d", v, want)
+		}
+		want++
+	}
+	wg.Wait()
+}
+
+func BenchmarkSPSC(b *testing.B) {
+	q := NewSPSC[int](1024)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < b.N; {
+			if _, ok := q.Dequeue(); ok {
+				i++
+			} else {
+				runtime.Gosched()
+			}
+		}
+		close(done)
+	}()
+
+	for i := 0; i < b.N; {
+		if q.Enqueue(i) {
+			i++
+		} else {
+			runtime.Gosched()
+		}
+	}
+	<-done
+}
+
+func BenchmarkChannelSPSC(b *testing.B) {
+	ch := make(chan int, 1024)
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < b.N; i++ {
+			<-ch
+		}
+		close(done)
+	}()
+
+	for i := 0; i < b.N; i++ {
+		ch <- i
+	}
+	<-done
+}
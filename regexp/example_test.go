@@ -0,0 +1,18 @@
+package regexp_test
+
+import (
+	"fmt"
+
+	"github.com/marselester/alg/regexp"
+)
+
+func Example() {
+	re := regexp.MustCompile("(A*B|AC)D")
+	fmt.Println(re.Match("AABD"), re.Match("AAC"))
+
+	re = regexp.MustCompile("[a-z]+\\d+")
+	fmt.Println(re.FindAll("id: abc12, xyz9; 42"))
+	// Output:
+	// true false
+	// [abc12 xyz9]
+}
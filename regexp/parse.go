@@ -0,0 +1,206 @@
+package regexp
+
+import (
+	"unicode/utf8"
+)
+
+// item is an NFA state: either a metacharacter or a character matcher.
+type item struct {
+	// op is a metacharacter (, ), |, *, +, ?, or zero if the state matches a character.
+	op byte
+	// char is the character to match when class is nil.
+	char rune
+	// class is a set of characters to match.
+	class *class
+	// pos is a byte offset of the item in the pattern.
+	pos int
+}
+
+// matches reports whether the state has a match transition on the character c.
+func (it *item) matches(c rune) bool {
+	switch {
+	case it.op != 0:
+		return false
+	case it.class != nil:
+		return it.class.contains(c)
+	}
+	return it.char == c
+}
+
+// class is a set of characters defined by ranges, e.g., [a-z0-9].
+type class struct {
+	ranges  []runeRange
+	negated bool
+}
+type runeRange struct {
+	lo, hi rune
+}
+
+// contains reports whether the character c belongs to the class.
+func (cl *class) contains(c rune) bool {
+	for _, r := range cl.ranges {
+		if r.lo <= c && c <= r.hi {
+			return !cl.negated
+		}
+	}
+	return cl.negated
+}
+
+var (
+	// anyChar is a class of any character: it excludes nothing.
+	anyChar = &class{negated: true}
+	// shorthands are predefined classes, e.g., \d.
+	shorthands = map[rune][]runeRange{
+		'd': {{'0', '9'}},
+		'w': {{'0', '9'}, {'A', 'Z'}, {'_', '_'}, {'a', 'z'}},
+		's': {{'\t', '\n'}, {'\f', '\r'}, {' ', ' '}},
+	}
+)
+
+/*
+parse converts the pattern into a sequence of NFA states.
+It checks that parentheses are balanced and each repetition operator has an operand,
+e.g., "a(b", "*a", "(|*)", "a**" are rejected.
+Empty alternatives and groups such as "a|" and "()" match an empty string.
+*/
+func parse(pattern string) ([]item, error) {
+	var (
+		items []item
+		// depth is the number of open parentheses.
+		depth int
+		// operand reports whether the previous item can be followed by a repetition operator
+		// (character, class, or right parenthesis).
+		operand bool
+	)
+	for i := 0; i < len(pattern); {
+		c, width := utf8.DecodeRuneInString(pattern[i:])
+		it := item{pos: i}
+		switch c {
+		case '(':
+			depth++
+			it.op = '('
+			operand = false
+		case ')':
+			if depth == 0 {
+				return nil, &SyntaxError{Pos: i, Msg: "unexpected )"}
+			}
+			depth--
+			it.op = ')'
+			operand = true
+		case '|':
+			it.op = '|'
+			operand = false
+		case '*', '+', '?':
+			if !operand {
+				return nil, &SyntaxError{Pos: i, Msg: "missing operand for " + string(c)}
+			}
+			it.op = byte(c)
+			operand = false
+		case '.':
+			it.class = anyChar
+			operand = true
+		case '[':
+			cl, n, err := parseClass(pattern, i)
+			if err != nil {
+				return nil, err
+			}
+			it.class = cl
+			width = n
+			operand = true
+		case '\\':
+			if i+width == len(pattern) {
+				return nil, &SyntaxError{Pos: i, Msg: "trailing backslash"}
+			}
+			esc, n := utf8.DecodeRuneInString(pattern[i+width:])
+			if rr, ok := shorthands[esc]; ok {
+				it.class = &class{ranges: rr}
+			} else {
+				it.char = esc
+			}
+			width += n
+			operand = true
+		default:
+			it.char = c
+			operand = true
+		}
+
+		items = append(items, it)
+		i += width
+	}
+
+	if depth != 0 {
+		return nil, &SyntaxError{Pos: len(pattern), Msg: "missing )"}
+	}
+	return items, nil
+}
+
+// parseClass parses a character class starting at i-th byte of the pattern, e.g., [^a-z_],
+// and returns its width in bytes.
+func parseClass(pattern string, i int) (*class, int, error) {
+	cl := class{}
+	j := i + 1
+	if j < len(pattern) && pattern[j] == '^' {
+		cl.negated = true
+		j++
+	}
+
+	for first := true; ; first = false {
+		if j >= len(pattern) {
+			return nil, 0, &SyntaxError{Pos: i, Msg: "missing ]"}
+		}
+		// The ] right after [ or [^ is a literal.
+		if pattern[j] == ']' && !first {
+			return &cl, j + 1 - i, nil
+		}
+
+		start := j
+		lo, n, err := classChar(pattern, j)
+		if err != nil {
+			return nil, 0, err
+		}
+		if lo.ranges != nil {
+			cl.ranges = append(cl.ranges, lo.ranges...)
+			j += n
+			continue
+		}
+		j += n
+
+		r := runeRange{lo: lo.char, hi: lo.char}
+		// The - is a literal at the end of the class.
+		if j+1 < len(pattern) && pattern[j] == '-' && pattern[j+1] != ']' {
+			hi, n, err := classChar(pattern, j+1)
+			if err != nil {
+				return nil, 0, err
+			}
+			if hi.ranges != nil || hi.char < lo.char {
+				return nil, 0, &SyntaxError{Pos: start, Msg: "invalid character class range"}
+			}
+			r.hi = hi.char
+			j += 1 + n
+		}
+		cl.ranges = append(cl.ranges, r)
+	}
+}
+
+// classChar parses a possibly escaped character of a class at j-th byte of the pattern
+// and returns its width in bytes. A shorthand such as \d is returned as ranges.
+func classChar(pattern string, j int) (classItem, int, error) {
+	c, width := utf8.DecodeRuneInString(pattern[j:])
+	if c != '\\' {
+		return classItem{char: c}, width, nil
+	}
+	if j+width == len(pattern) {
+		return classItem{}, 0, &SyntaxError{Pos: j, Msg: "trailing backslash"}
+	}
+	esc, n := utf8.DecodeRuneInString(pattern[j+width:])
+	if rr, ok := shorthands[esc]; ok {
+		return classItem{ranges: rr}, width + n, nil
+	}
+	return classItem{char: esc}, width + n, nil
+}
+
+// classItem is either a character or a shorthand class inside of a character class.
+type classItem struct {
+	char   rune
+	ranges []runeRange
+}
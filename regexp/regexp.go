@@ -0,0 +1,232 @@
+/*
+Package regexp implements regular expressions by simulating a nondeterministic finite-state automaton (NFA)
+built from the pattern as described in the book.
+The supported syntax is:
+
+	xy     concatenation
+	x|y    x or y
+	x*     zero or more x
+	x+     one or more x
+	x?     zero or one x
+	(x)    grouping
+	.      any character
+	[abc]  character class, ranges [a-z] and negation [^0-9] are allowed
+	\d     digit [0-9]
+	\w     word character [0-9A-Za-z_]
+	\s     whitespace [\t\n\f\r ]
+	\x     character x escaped, e.g., \* or \.
+
+The NFA has a state for each character of the pattern (plus an accept state).
+A character state has a match transition to the next state if the text character matches it,
+and the metacharacters (parentheses and operators) have epsilon transitions kept in a digraph
+that can be taken without scanning a text character.
+The simulation keeps a set of states the NFA could be in,
+so it determines whether a text of length n matches a pattern of length m in time proportional to n*m
+in the worst case, without backtracking.
+*/
+package regexp
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/marselester/alg/digraph"
+	"github.com/marselester/alg/stack"
+)
+
+// SyntaxError describes a malformed pattern and a position (byte offset) where it was detected.
+type SyntaxError struct {
+	Pos int
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("position %d: %s", e.Pos, e.Msg)
+}
+
+// Regexp is a compiled regular expression, it's safe for concurrent use.
+// Zero value is unusable, please use Compile.
+type Regexp struct {
+	pattern string
+	// items are the NFA states except the accept state whose index is len(items).
+	items []item
+	// epsilon is a digraph of epsilon transitions between the states.
+	epsilon *digraph.AdjacencyList
+}
+
+// Compile parses the pattern and builds the NFA.
+func Compile(pattern string) (*Regexp, error) {
+	items, err := parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	// The pattern is wrapped in parentheses, so the top-level alternatives are handled as a group.
+	items = append([]item{{op: '('}}, items...)
+	items = append(items, item{op: ')', pos: len(pattern)})
+	return &Regexp{
+		pattern: pattern,
+		items:   items,
+		epsilon: epsilonTransitions(items),
+	}, nil
+}
+
+// MustCompile is like Compile, but it panics if the pattern can't be parsed.
+func MustCompile(pattern string) *Regexp {
+	re, err := Compile(pattern)
+	if err != nil {
+		panic(fmt.Sprintf("regexp: Compile(%q): %v", pattern, err))
+	}
+	return re
+}
+
+// String returns the source pattern of the regular expression.
+func (re *Regexp) String() string {
+	return re.pattern
+}
+
+/*
+epsilonTransitions builds the digraph of epsilon transitions using a stack to keep track of
+the positions of left parentheses and or operators:
+
+	left parenthesis: push its index on the stack and add an edge to the next state
+	or operator: push its index on the stack
+	right parenthesis: pop the or operators (if any) up to the matching left parenthesis lp,
+		add an edge from lp to the state after each or, and from each or to the right parenthesis
+	closure x* (the lookahead is *): add edges from x to * and back,
+		where x is either a single character or the left parenthesis of a group
+	one or more x+: add an edge from + back to x
+	zero or one x?: add an edge from x to ?
+
+The operators and parentheses also have an edge to the next state.
+*/
+func epsilonTransitions(items []item) *digraph.AdjacencyList {
+	m := len(items)
+	g := digraph.NewAdjacencyList(m + 1)
+	ops := stack.NewArrayOf[int](0)
+	for i := 0; i < m; i++ {
+		lp := i
+		switch items[i].op {
+		case '(', '|':
+			ops.Push(i)
+		case ')':
+			var ors []int
+			for {
+				p := ops.Pop()
+				if items[p].op == '(' {
+					lp = p
+					break
+				}
+				ors = append(ors, p)
+			}
+			for _, or := range ors {
+				g.Add(lp, or+1)
+				g.Add(or, i)
+			}
+		}
+
+		if i+1 < m {
+			switch items[i+1].op {
+			case '*':
+				g.Add(lp, i+1)
+				g.Add(i+1, lp)
+			case '+':
+				g.Add(i+1, lp)
+			case '?':
+				g.Add(lp, i+1)
+			}
+		}
+
+		switch items[i].op {
+		case '(', ')', '*', '+', '?':
+			g.Add(i, i+1)
+		}
+	}
+	return g
+}
+
+// reachable returns the states reachable from the given states by epsilon transitions.
+func (re *Regexp) reachable(states []int) []int {
+	if len(states) == 0 {
+		return nil
+	}
+	return digraph.DepthFirstSearch(re.epsilon, states...)
+}
+
+// step returns the states after matching the character c in any of the given states.
+func (re *Regexp) step(states []int, c rune) []int {
+	var next []int
+	for _, v := range states {
+		if v < len(re.items) && re.items[v].matches(c) {
+			next = append(next, v+1)
+		}
+	}
+	return re.reachable(next)
+}
+
+// accepts reports whether the accept state is among the states.
+func (re *Regexp) accepts(states []int) bool {
+	// The states are sorted since DepthFirstSearch returns them in order.
+	return len(states) > 0 && states[len(states)-1] == len(re.items)
+}
+
+// Match reports whether the regular expression matches the entire string s.
+// Use ".*x.*" pattern to check whether s contains a match of x.
+func (re *Regexp) Match(s string) bool {
+	states := re.reachable([]int{0})
+	for _, c := range s {
+		if states = re.step(states, c); len(states) == 0 {
+			return false
+		}
+	}
+	return re.accepts(states)
+}
+
+// longest returns the end of the longest match starting at i-th byte of s, or -1 if there is none.
+func (re *Regexp) longest(s string, i int) int {
+	end := -1
+	states := re.reachable([]int{0})
+	for {
+		if re.accepts(states) {
+			end = i
+		}
+		if i == len(s) {
+			return end
+		}
+		c, width := utf8.DecodeRuneInString(s[i:])
+		if states = re.step(states, c); len(states) == 0 {
+			return end
+		}
+		i += width
+	}
+}
+
+// FindAllIndex returns the start and end byte offsets of successive non-overlapping matches in s.
+// The leftmost match is chosen, and the longest one if several matches start at the same offset.
+// Empty matches are not reported.
+//
+// The NFA is simulated from every offset where a match might start, and each simulation may scan to the end of s,
+// so it takes O(n²·m) time in the worst case for a text of length n and a regexp of length m,
+// e.g., a*b on a long line of a's (grep -o takes this path).
+func (re *Regexp) FindAllIndex(s string) [][2]int {
+	var loc [][2]int
+	for i := 0; i < len(s); {
+		if end := re.longest(s, i); end > i {
+			loc = append(loc, [2]int{i, end})
+			i = end
+			continue
+		}
+		_, width := utf8.DecodeRuneInString(s[i:])
+		i += width
+	}
+	return loc
+}
+
+// FindAll returns successive non-overlapping matches in s, see FindAllIndex.
+func (re *Regexp) FindAll(s string) []string {
+	var matches []string
+	for _, loc := range re.FindAllIndex(s) {
+		matches = append(matches, s[loc[0]:loc[1]])
+	}
+	return matches
+}
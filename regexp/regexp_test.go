@@ -0,0 +1,196 @@
+package regexp
+
+import (
+	"errors"
+	"math/rand"
+	"reflect"
+	stdregexp "regexp"
+	"strings"
+	"testing"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    bool
+	}{
+		{"(A*B|AC)D", "AABD", true},
+		{"(A*B|AC)D", "ACD", true},
+		{"(A*B|AC)D", "AAAAAAAAAAAAAABD", true},
+		{"(A*B|AC)D", "AAAAAAAAAAAAAAAC", false},
+		{"(A*B|AC)D", "ABCD", false},
+		{"((A*B|AC)D)", "BD", true},
+		{"(.*AB((C|D|E)F)*G)", "XXABCFDFG", true},
+		{"(.*AB((C|D|E)F)*G)", "ABFG", false},
+		{"A|B|C", "B", true},
+		{"A|B|C", "AB", false},
+		{"(AB)+", "ABAB", true},
+		{"(AB)+", "", false},
+		{"colou?r", "color", true},
+		{"colou?r", "colour", true},
+		{"colou?r", "colouur", false},
+		{"[a-c]+x", "abcabx", true},
+		{"[a-c]+x", "abdx", false},
+		{"[^0-9]*", "abc", true},
+		{"[^0-9]*", "ab1", false},
+		{"\\d+(\\.\\d+)?", "3.14", true},
+		{"\\d+(\\.\\d+)?", "3.", false},
+		{"\\w+@\\w+\\.com", "gopher_1@example.com", true},
+		{"a\\*", "a*", true},
+		{"a\\*", "aa", false},
+		{"[]a]+", "]a]", true},
+		{"[a-]+", "a-a", true},
+		{"日本.", "日本語", true},
+		{"a|", "", true},
+		{"()", "", true},
+		{"", "", true},
+		{"", "a", false},
+		{"(a*)*b", "aaab", true},
+	}
+
+	for _, tc := range tests {
+		re, err := Compile(tc.pattern)
+		if err != nil {
+			t.Fatalf("Compile(%q) error: %v", tc.pattern, err)
+		}
+		if got := re.Match(tc.text); got != tc.want {
+			t.Errorf("Compile(%q).Match(%q) = %v, want %v", tc.pattern, tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestCompileError(t *testing.T) {
+	tests := []struct {
+		pattern string
+		want    SyntaxError
+	}{
+		{"a(b", SyntaxError{Pos: 3, Msg: "missing )"}},
+		{"ab)", SyntaxError{Pos: 2, Msg: "unexpected )"}},
+		{"*a", SyntaxError{Pos: 0, Msg: "missing operand for *"}},
+		{"a**", SyntaxError{Pos: 2, Msg: "missing operand for *"}},
+		{"(|+)", SyntaxError{Pos: 2, Msg: "missing operand for +"}},
+		{"a\\", SyntaxError{Pos: 1, Msg: "trailing backslash"}},
+		{"[a-z", SyntaxError{Pos: 0, Msg: "missing ]"}},
+		{"x[z-a]", SyntaxError{Pos: 2, Msg: "invalid character class range"}},
+	}
+
+	for _, tc := range tests {
+		_, err := Compile(tc.pattern)
+		var got *SyntaxError
+		if !errors.As(err, &got) {
+			t.Errorf("Compile(%q) error = %v, want SyntaxError", tc.pattern, err)
+			continue
+		}
+		if *got != tc.want {
+			t.Errorf("Compile(%q) error = %v, want %v", tc.pattern, got, &tc.want)
+		}
+	}
+}
+
+func TestMustCompilePanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MustCompile() expected panic")
+		}
+	}()
+	MustCompile("(")
+}
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		pattern string
+		text    string
+		want    []string
+	}{
+		{"\\d+", "a1b22c333", []string{"1", "22", "333"}},
+		{"ab|abc", "abcab", []string{"abc", "ab"}},
+		{"a*", "baaab", []string{"aaa"}},
+		{"x", "abc", nil},
+		{"[^ ]+", "to be  or", []string{"to", "be", "or"}},
+		{"語+", "日本語語", []string{"語語"}},
+	}
+
+	for _, tc := range tests {
+		got := MustCompile(tc.pattern).FindAll(tc.text)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Compile(%q).FindAll(%q) = %q, want %q", tc.pattern, tc.text, got, tc.want)
+		}
+	}
+}
+
+// TestRandom compares the NFA with the standard library's leftmost-longest (POSIX) regular expressions.
+func TestRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		pattern := randomPattern(r, 3)
+		re := MustCompile(pattern)
+		std := stdregexp.MustCompilePOSIX(pattern)
+		full := stdregexp.MustCompilePOSIX("^(" + pattern + ")$")
+
+		for j := 0; j < 10; j++ {
+			text := randomText(r, "abc", r.Intn(8))
+			if got, want := re.Match(text), full.MatchString(text); got != want {
+				t.Fatalf("Compile(%q).Match(%q) = %v, want %v", pattern, text, got, want)
+			}
+
+			var want [][2]int
+			for _, loc := range std.FindAllStringIndex(text, -1) {
+				if loc[0] != loc[1] {
+					want = append(want, [2]int{loc[0], loc[1]})
+				}
+			}
+			if got := re.FindAllIndex(text); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Compile(%q).FindAllIndex(%q) = %v, want %v", pattern, text, got, want)
+			}
+		}
+	}
+}
+
+// randomPattern generates a pattern over a, b characters with nesting up to the depth.
+func randomPattern(r *rand.Rand, depth int) string {
+	var b strings.Builder
+	for n := 1 + r.Intn(3); n > 0; n-- {
+		switch k := r.Intn(6); {
+		case k == 0 && depth > 0:
+			b.WriteString("(" + randomPattern(r, depth-1) + "|" + randomPattern(r, depth-1) + ")")
+		case k == 1 && depth > 0:
+			b.WriteString("(" + randomPattern(r, depth-1) + ")")
+		case k == 2:
+			b.WriteString("[ab]")
+		case k == 3:
+			b.WriteString(".")
+		default:
+			b.WriteByte("ab"[r.Intn(2)])
+		}
+		if k := r.Intn(5); k < 3 {
+			b.WriteByte("*+?"[k])
+		}
+	}
+	return b.String()
+}
+
+func randomText(r *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+func BenchmarkMatch(b *testing.B) {
+	text := strings.Repeat("a", 30)
+	pattern := strings.Repeat("a?", 30) + strings.Repeat("a", 30)
+	b.Run("NFA", func(b *testing.B) {
+		re := MustCompile(pattern)
+		for i := 0; i < b.N; i++ {
+			re.Match(text)
+		}
+	})
+	b.Run("stdlib", func(b *testing.B) {
+		re := stdregexp.MustCompile("^" + pattern + "$")
+		for i := 0; i < b.N; i++ {
+			re.MatchString(text)
+		}
+	})
+}
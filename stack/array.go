@@ -1,45 +1,81 @@
 package stack
 
-// Array is a stack of strings that is backed by array.
+import "iter"
+
+// Array is a stack of strings that is backed by array, see ArrayOf.
+type Array = ArrayOf[string]
+
+// ArrayOf is a stack of items of type T that is backed by array.
 // Stack grows at a cost of allocating a new array and copying items there.
 // When stack shrinks, it uses the same underlying array.
 // The full array will be kept in memory until it is no longer referenced.
 //
 // Zero value is usable; initially stack has zero capacity.
 // Note, operations are not concurrency safe.
-type Array struct {
-	items []string
+type ArrayOf[T any] struct {
+	items []T
 }
 
-// NewArray creates a stack with given capacity.
+// NewArray creates a stack of strings with given capacity.
 // Negative capacity is ignored, zero value is used instead.
 func NewArray(capacity int) *Array {
+	return NewArrayOf[string](capacity)
+}
+
+// NewArrayOf creates a stack of items of type T with given capacity.
+// Negative capacity is ignored, zero value is used instead.
+func NewArrayOf[T any](capacity int) *ArrayOf[T] {
 	if capacity < 0 {
 		capacity = 0
 	}
-	return &Array{
-		items: make([]string, 0, capacity),
+	return &ArrayOf[T]{
+		items: make([]T, 0, capacity),
 	}
 }
 
 // Push adds an item to the top of the stack.
-func (s *Array) Push(v string) {
+func (s *ArrayOf[T]) Push(v T) {
 	s.items = append(s.items, v)
 }
 
 // Pop removes and returns the most recently added item.
-// When stack is empty, empty string is returned.
-func (s *Array) Pop() string {
+// When stack is empty, zero value of T (empty string) is returned.
+func (s *ArrayOf[T]) Pop() T {
+	var v T
 	i := len(s.items) - 1
 	if i == -1 {
-		return ""
+		return v
 	}
-	v := s.items[i]
+	v = s.items[i]
+	// Clean up the slot so the item can be garbage collected.
+	var zero T
+	s.items[i] = zero
 	s.items = s.items[:i]
 	return v
 }
 
+// Peek returns the most recently added item without removing it.
+// When stack is empty, it returns false.
+func (s *ArrayOf[T]) Peek() (v T, ok bool) {
+	if len(s.items) == 0 {
+		return v, false
+	}
+	return s.items[len(s.items)-1], true
+}
+
 // Size returns the number of items in the stack.
-func (s *Array) Size() int {
+func (s *ArrayOf[T]) Size() int {
 	return len(s.items)
 }
+
+// All returns an iterator over items from the top to the bottom of the stack.
+// The stack must not be modified during the iteration.
+func (s *ArrayOf[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for i := len(s.items) - 1; i >= 0; i-- {
+			if !yield(s.items[i]) {
+				return
+			}
+		}
+	}
+}
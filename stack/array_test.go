@@ -107,3 +107,35 @@ func TestNewArrayZero(t *testing.T) {
 		t.Errorf("Pop() = %q, want %q", got, want)
 	}
 }
+
+func TestArrayOfPeek(t *testing.T) {
+	s := ArrayOf[int]{}
+	if _, ok := s.Peek(); ok {
+		t.Errorf("Peek() = _, true, want false")
+	}
+
+	s.Push(1)
+	s.Push(2)
+	if got, ok := s.Peek(); !ok || got != 2 {
+		t.Errorf("Peek() = %d, %v, want 2, true", got, ok)
+	}
+	if s.Size() != 2 {
+		t.Errorf("Peek() count is %d, want 2", s.Size())
+	}
+}
+
+func TestArrayOfAll(t *testing.T) {
+	s := NewArrayOf[int](0)
+	for i := 1; i <= 3; i++ {
+		s.Push(i)
+	}
+
+	var got []int
+	for v := range s.All() {
+		got = append(got, v)
+	}
+	want := []int{3, 2, 1}
+	if len(got) != len(want) || got[0] != 3 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
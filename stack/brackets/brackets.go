@@ -0,0 +1,254 @@
+/*
+Package brackets validates that delimiters such as parentheses, brackets and braces are properly balanced.
+For example, [()]{}{[()()]()} is balanced, whereas [(]) is not.
+
+A stack keeps opening delimiters that haven't been closed yet:
+an opening delimiter is pushed onto the stack, a closing delimiter must match the one popped from the stack.
+The text is balanced when the stack is empty at the end of input.
+String literals and comments can be skipped, so "(" or // ) don't affect the balance of a source file.
+
+The input is read as a stream of runes, so the memory used is proportional
+to the nesting depth rather than the size of the text.
+*/
+package brackets
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/marselester/alg/stack"
+)
+
+// Position describes a location in the text.
+type Position struct {
+	// Offset is a byte offset starting at 0.
+	Offset int
+	// Line is a line number starting at 1.
+	Line int
+	// Column is a rune number in the line starting at 1.
+	Column int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// MismatchError describes the first mismatch found in the text.
+type MismatchError struct {
+	// Pos is where the mismatch was detected: a position of unexpected closing delimiter
+	// or the end of input.
+	Pos Position
+	// OpenPos is a position of the opening delimiter, string literal or comment that wasn't properly closed.
+	// It is zero Position when a closing delimiter has no opening one.
+	OpenPos Position
+	Msg     string
+}
+
+func (e *MismatchError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Pos, e.Msg)
+}
+
+// Checker validates balanced delimiters.
+// Zero value is unusable, please use New.
+type Checker struct {
+	config
+	// closing maps a closing delimiter to its opening one.
+	closing map[rune]rune
+	// opening maps an opening delimiter to its closing one.
+	opening map[rune]rune
+}
+
+// New returns a Checker of DefaultPairs which doesn't recognize string literals and comments
+// unless configured with options.
+func New(options ...Option) *Checker {
+	c := Checker{
+		closing: make(map[rune]rune),
+		opening: make(map[rune]rune),
+	}
+	c.pairs = DefaultPairs
+	for _, opt := range options {
+		opt(&c.config)
+	}
+	for _, p := range c.pairs {
+		c.opening[p.Open] = p.Close
+		c.closing[p.Close] = p.Open
+	}
+	return &c
+}
+
+// Lexer states.
+const (
+	stateCode = iota
+	stateString
+	stateRawString
+	stateLineComment
+	stateBlockComment
+)
+
+// opener is an opening delimiter (or a quote) and its position.
+type opener struct {
+	r   rune
+	pos Position
+}
+
+// CheckString is a convenience wrapper of Check, see Check.
+func (c *Checker) CheckString(s string) error {
+	return c.Check(strings.NewReader(s))
+}
+
+// IsBalanced reports whether delimiters in s are properly balanced.
+func (c *Checker) IsBalanced(s string) bool {
+	return c.CheckString(s) == nil
+}
+
+// Check reads the text from r and returns *MismatchError describing the first mismatch.
+// It returns nil if the delimiters are balanced, or the error returned by r.
+func (c *Checker) Check(r io.Reader) error {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+
+	var (
+		opened stack.ArrayOf[opener]
+		// literal is a quote or a comment that is currently open.
+		literal opener
+		state   = stateCode
+		pos     = Position{Line: 1, Column: 1}
+	)
+	for {
+		ch, size, err := br.ReadRune()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		cur := pos
+		pos.advance(ch, size)
+
+		switch state {
+		case stateCode:
+			switch {
+			case c.lineComment != "" && c.skipPrefix(br, ch, c.lineComment, &pos):
+				state = stateLineComment
+				literal = opener{ch, cur}
+			case c.blockStart != "" && c.skipPrefix(br, ch, c.blockStart, &pos):
+				state = stateBlockComment
+				literal = opener{ch, cur}
+			case slices.Contains(c.quotes, ch):
+				state = stateString
+				literal = opener{ch, cur}
+			case slices.Contains(c.rawQuotes, ch):
+				state = stateRawString
+				literal = opener{ch, cur}
+			default:
+				if _, ok := c.opening[ch]; ok {
+					opened.Push(opener{ch, cur})
+					break
+				}
+				want, ok := c.closing[ch]
+				if !ok {
+					break
+				}
+				top, ok := opened.Peek()
+				if !ok {
+					return &MismatchError{
+						Pos: cur,
+						Msg: fmt.Sprintf("unexpected %q", ch),
+					}
+				}
+				if top.r != want {
+					return &MismatchError{
+						Pos:     cur,
+						OpenPos: top.pos,
+						Msg:     fmt.Sprintf("found %q, want %q to close %q at %s", ch, c.opening[top.r], top.r, top.pos),
+					}
+				}
+				opened.Pop()
+			}
+
+		case stateString:
+			switch ch {
+			case '\\':
+				// Skip the escaped rune.
+				ch, size, err = br.ReadRune()
+				if err != nil && err != io.EOF {
+					return err
+				}
+				if err == nil {
+					pos.advance(ch, size)
+				}
+			case literal.r:
+				state = stateCode
+			}
+
+		case stateRawString:
+			if ch == literal.r {
+				state = stateCode
+			}
+
+		case stateLineComment:
+			if ch == '\n' {
+				state = stateCode
+			}
+
+		case stateBlockComment:
+			if c.skipPrefix(br, ch, c.blockEnd, &pos) {
+				state = stateCode
+			}
+		}
+	}
+
+	switch state {
+	case stateString, stateRawString:
+		return &MismatchError{Pos: pos, OpenPos: literal.pos, Msg: fmt.Sprintf("unterminated string literal opened at %s", literal.pos)}
+	case stateBlockComment:
+		return &MismatchError{Pos: pos, OpenPos: literal.pos, Msg: fmt.Sprintf("unterminated comment opened at %s", literal.pos)}
+	}
+	if top, ok := opened.Peek(); ok {
+		return &MismatchError{
+			Pos:     pos,
+			OpenPos: top.pos,
+			Msg:     fmt.Sprintf("unclosed %q opened at %s", top.r, top.pos),
+		}
+	}
+	return nil
+}
+
+// skipPrefix reports whether the rune ch that was just read and the following runes form the prefix.
+// If so, the rest of the prefix is discarded from the reader and the position is advanced.
+func (c *Checker) skipPrefix(br *bufio.Reader, ch rune, prefix string, pos *Position) bool {
+	first, size := utf8.DecodeRuneInString(prefix)
+	if first != ch {
+		return false
+	}
+	rest := prefix[size:]
+	if rest == "" {
+		return true
+	}
+	b, err := br.Peek(len(rest))
+	if err != nil || string(b) != rest {
+		return false
+	}
+	br.Discard(len(rest))
+	for _, r := range rest {
+		pos.advance(r, utf8.RuneLen(r))
+	}
+	return true
+}
+
+// advance moves the position past the rune r of the given size in bytes.
+func (p *Position) advance(r rune, size int) {
+	p.Offset += size
+	if r == '\n' {
+		p.Line++
+		p.Column = 1
+		return
+	}
+	p.Column++
+}
@@ -0,0 +1,132 @@
+package brackets
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCheckerIsBalanced(t *testing.T) {
+	tt := []struct {
+		expr string
+		want bool
+	}{
+		{"", true},
+		{"[", false},
+		{"{", false},
+		{"[)", false},
+		{"[]]", false},
+		{"[(])", false},
+		{"[]", true},
+		{"()", true},
+		{"{}", true},
+		{"[()]{}{[()()]()}", true},
+		{"a(b)c[d]", true},
+	}
+
+	c := New()
+	for _, tc := range tt {
+		if got := c.IsBalanced(tc.expr); got != tc.want {
+			t.Errorf("IsBalanced(%q) = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestCheckerMismatch(t *testing.T) {
+	tt := []struct {
+		expr    string
+		pos     Position
+		openPos Position
+	}{
+		{"())", Position{Offset: 2, Line: 1, Column: 3}, Position{}},
+		{"[(])", Position{Offset: 2, Line: 1, Column: 3}, Position{Offset: 1, Line: 1, Column: 2}},
+		{"{\n  (\n}", Position{Offset: 6, Line: 3, Column: 1}, Position{Offset: 4, Line: 2, Column: 3}},
+		{"[\n(", Position{Offset: 3, Line: 2, Column: 2}, Position{Offset: 2, Line: 2, Column: 1}},
+		{"ж)", Position{Offset: 2, Line: 1, Column: 2}, Position{}},
+	}
+
+	c := New()
+	for _, tc := range tt {
+		err := c.CheckString(tc.expr)
+		var merr *MismatchError
+		if !errors.As(err, &merr) {
+			t.Errorf("CheckString(%q) = %v, want MismatchError", tc.expr, err)
+			continue
+		}
+		if merr.Pos != tc.pos || merr.OpenPos != tc.openPos {
+			t.Errorf("CheckString(%q) positions = %+v %+v, want %+v %+v", tc.expr, merr.Pos, merr.OpenPos, tc.pos, tc.openPos)
+		}
+	}
+}
+
+func TestCheckerPairs(t *testing.T) {
+	c := New(WithPairs(Pair{'<', '>'}, Pair{'(', ')'}))
+	tt := []struct {
+		expr string
+		want bool
+	}{
+		{"<(>)", false},
+		{"<()>", true},
+		{"<[>", true},
+		{"<", false},
+	}
+	for _, tc := range tt {
+		if got := c.IsBalanced(tc.expr); got != tc.want {
+			t.Errorf("IsBalanced(%q) = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestCheckerLiterals(t *testing.T) {
+	c := New(
+		WithQuotes('"', '\''),
+		WithRawQuotes('`'),
+		WithLineComment("//"),
+		WithBlockComment("/*", "*/"),
+	)
+	tt := []struct {
+		expr string
+		want bool
+	}{
+		{`f("(")`, true},
+		{`f('}')`, true},
+		{`f("\")")`, true},
+		{"f(`\\`)", true},
+		{"f() // )\n", true},
+		{"f( // )\n)", true},
+		{"f( /* ) */ )", true},
+		{"f(/* ) *)", false},
+		{`f(")`, false},
+		{"f(/)", true},
+		{"f(a / b)", true},
+		{`"(" )`, false},
+	}
+	for _, tc := range tt {
+		if got := c.IsBalanced(tc.expr); got != tc.want {
+			t.Errorf("IsBalanced(%q) = %v, want %v", tc.expr, got, tc.want)
+		}
+	}
+}
+
+func TestCheckerStream(t *testing.T) {
+	const depth = 100000
+	text := strings.Repeat("[(", depth) + strings.Repeat(")]", depth)
+	c := New()
+	if err := c.Check(strings.NewReader(text)); err != nil {
+		t.Errorf("Check() = %v, want nil", err)
+	}
+	if err := c.Check(strings.NewReader(text + "}")); err == nil {
+		t.Errorf("Check() = nil, want error")
+	}
+}
+
+func BenchmarkChecker(b *testing.B) {
+	text := strings.Repeat(`{"a": [1, 2, {"b": "(]"}]}`, 1000)
+	c := New(WithQuotes('"'))
+	b.SetBytes(int64(len(text)))
+	for i := 0; i < b.N; i++ {
+		if err := c.CheckString(text); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
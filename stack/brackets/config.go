@@ -0,0 +1,71 @@
+package brackets
+
+// Pair is a pair of opening and closing delimiters, e.g., '(' and ')'.
+type Pair struct {
+	Open  rune
+	Close rune
+}
+
+// DefaultPairs are parentheses, square brackets and curly braces.
+var DefaultPairs = []Pair{
+	{'(', ')'},
+	{'[', ']'},
+	{'{', '}'},
+}
+
+type config struct {
+	pairs []Pair
+	// quotes start and end string literals where backslash escapes the next rune.
+	quotes []rune
+	// rawQuotes start and end string literals without escape sequences.
+	rawQuotes []rune
+	// lineComment starts a comment which ends at a newline.
+	lineComment string
+	// blockStart and blockEnd delimit a block comment.
+	blockStart string
+	blockEnd   string
+}
+
+// Option configures a Checker, see New.
+type Option func(*config)
+
+// WithPairs defines delimiters to be balanced instead of DefaultPairs.
+func WithPairs(pairs ...Pair) Option {
+	return func(c *config) {
+		c.pairs = pairs
+	}
+}
+
+// WithQuotes defines runes that delimit string literals, e.g., '"' and '\''.
+// Delimiters inside of a string literal are ignored,
+// a backslash escapes the next rune so \" doesn't end the literal.
+func WithQuotes(quotes ...rune) Option {
+	return func(c *config) {
+		c.quotes = quotes
+	}
+}
+
+// WithRawQuotes defines runes that delimit raw string literals, e.g., '`' in Go.
+// Unlike WithQuotes, a backslash has no special meaning inside of a raw string literal.
+func WithRawQuotes(quotes ...rune) Option {
+	return func(c *config) {
+		c.rawQuotes = quotes
+	}
+}
+
+// WithLineComment defines a prefix of a comment that lasts until the end of the line, e.g., "//" or "#".
+// Delimiters inside of the comment are ignored.
+func WithLineComment(prefix string) Option {
+	return func(c *config) {
+		c.lineComment = prefix
+	}
+}
+
+// WithBlockComment defines start and end of a block comment, e.g., "/*" and "*/".
+// Delimiters inside of the comment are ignored.
+func WithBlockComment(start, end string) Option {
+	return func(c *config) {
+		c.blockStart = start
+		c.blockEnd = end
+	}
+}
@@ -0,0 +1,16 @@
+package brackets_test
+
+import (
+	"fmt"
+
+	"github.com/marselester/alg/stack/brackets"
+)
+
+func ExampleChecker_CheckString() {
+	c := brackets.New(brackets.WithQuotes('"'))
+	fmt.Println(c.CheckString(`{"a": [1, "]"]}`))
+	fmt.Println(c.CheckString(`{"a": [1, 2}`))
+	// Output:
+	// <nil>
+	// 1:12: found '}', want ']' to close '[' at 1:7
+}
@@ -21,3 +21,15 @@ func ExampleLinkedList() {
 	fmt.Printf("%q %q %q", s.Pop(), s.Pop(), s.Pop())
 	// Output: "bazz" "fizz" ""
 }
+
+func ExampleArrayOf() {
+	s := stack.ArrayOf[int]{}
+	s.Push(1)
+	s.Push(2)
+	s.Push(3)
+	for v := range s.All() {
+		fmt.Print(v, " ")
+	}
+	fmt.Println(s.Pop())
+	// Output: 3 2 1 3
+}
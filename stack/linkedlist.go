@@ -1,43 +1,70 @@
 package stack
 
-// LinkedList represents a stack of strings based on linked-list data structure.
+import "iter"
+
+// LinkedList represents a stack of strings based on linked-list data structure, see LinkedListOf.
+type LinkedList = LinkedListOf[string]
+
+// LinkedListOf represents a stack of items of type T based on linked-list data structure.
 // The space required is always proportional to the size of of the collection.
 // The time per operation is always independent of the size of the collection.
 //
-// The zero value for LinkedList is ready to use.
+// The zero value for LinkedListOf is ready to use.
 // Note, operations are not concurrency safe.
-type LinkedList struct {
+type LinkedListOf[T any] struct {
 	// first is the most recently added node (the top of the stack).
-	first *node
+	first *node[T]
 	// n is the number of items in the stack.
 	n int
 }
 
-type node struct {
-	item string
-	next *node
+type node[T any] struct {
+	item T
+	next *node[T]
 }
 
 // Push adds an item to the top of the stack.
-func (s *LinkedList) Push(item string) {
-	first := node{item: item, next: s.first}
+func (s *LinkedListOf[T]) Push(item T) {
+	first := node[T]{item: item, next: s.first}
 	s.first = &first
 	s.n++
 }
 
 // Pop removes and returns the most recently added item.
-// When stack is empty, empty string is returned.
-func (s *LinkedList) Pop() string {
+// When stack is empty, zero value of T (empty string) is returned.
+func (s *LinkedListOf[T]) Pop() T {
+	var v T
 	if s.first == nil {
-		return ""
+		return v
 	}
-	v := s.first.item
+	v = s.first.item
 	s.first = s.first.next
 	s.n--
 	return v
 }
 
+// Peek returns the most recently added item without removing it.
+// When stack is empty, it returns false.
+func (s *LinkedListOf[T]) Peek() (v T, ok bool) {
+	if s.first == nil {
+		return v, false
+	}
+	return s.first.item, true
+}
+
 // Size returns the number of items in the stack.
-func (s *LinkedList) Size() int {
+func (s *LinkedListOf[T]) Size() int {
 	return s.n
 }
+
+// All returns an iterator over items from the top to the bottom of the stack.
+// The stack must not be modified during the iteration.
+func (s *LinkedListOf[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for n := s.first; n != nil; n = n.next {
+			if !yield(n.item) {
+				return
+			}
+		}
+	}
+}
@@ -49,3 +49,34 @@ func TestLinkedListLayoutAfterPushPop(t *testing.T) {
 		t.Errorf("Pop() has node %v, want nil", s.first)
 	}
 }
+
+func TestLinkedListOfPeek(t *testing.T) {
+	s := LinkedListOf[int]{}
+	if _, ok := s.Peek(); ok {
+		t.Errorf("Peek() = _, true, want false")
+	}
+
+	s.Push(1)
+	s.Push(2)
+	if got, ok := s.Peek(); !ok || got != 2 {
+		t.Errorf("Peek() = %d, %v, want 2, true", got, ok)
+	}
+	if s.Size() != 2 {
+		t.Errorf("Peek() count is %d, want 2", s.Size())
+	}
+}
+
+func TestLinkedListOfAll(t *testing.T) {
+	s := LinkedList{}
+	s.Push("fizz")
+	s.Push("bazz")
+
+	var got []string
+	for v := range s.All() {
+		got = append(got, v)
+	}
+	want := []string{"bazz", "fizz"}
+	if !equal(got, want) {
+		t.Errorf("All() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,489 @@
+package strsearch
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+// Kinds of adaptive radix tree nodes by the max number of children.
+const (
+	node4 = iota
+	node16
+	node48
+	node256
+)
+
+/*
+AdaptiveRadixTree (ART) is a trie where each node adapts its representation of child links
+to the number of children (Leis, Kemper, Neumann, "The Adaptive Radix Tree", 2013).
+An R-way trie wastes space on null links, because nodes typically have just a few children.
+ART picks the smallest of four node kinds that fits the children:
+
+	node4 and node16 keep up to 4 or 16 sorted key bytes and corresponding child links
+	node48 keeps 256 one-byte indexes into an array of up to 48 child links
+	node256 is an R-way trie node with 256 child links
+
+A node grows into a larger kind when it's full and shrinks when it becomes sparse,
+so the space consumption per key is bounded regardless of the key distribution.
+Like RadixTrie, it collapses chains of nodes with a single child (path compression):
+each node keeps the bytes of the key that follow the byte that leads to the node.
+
+Zero value is an empty tree ready to use.
+*/
+type AdaptiveRadixTree struct {
+	root artnode
+	// n is the number of keys in the tree.
+	n int
+}
+type artnode struct {
+	kind uint8
+	// size is the number of children.
+	size int
+	// prefix is a compressed path: bytes of the key after the one that leads to the node.
+	prefix string
+	// value associated with a key.
+	value string
+	// keys are sorted bytes corresponding to the children in node4 and node16.
+	// In node48, keys maps a byte to the child index plus one, zero means no child.
+	// It's not used in node256 where the children are indexed by the byte.
+	keys     []byte
+	children []*artnode
+}
+
+// find returns the child corresponding to the byte c or nil.
+func (n *artnode) find(c byte) *artnode {
+	switch n.kind {
+	case node4:
+		for i := 0; i < n.size; i++ {
+			if n.keys[i] == c {
+				return n.children[i]
+			}
+		}
+	case node16:
+		if i, ok := n.search(c); ok {
+			return n.children[i]
+		}
+	case node48:
+		if i := n.keys[c]; i != 0 {
+			return n.children[i-1]
+		}
+	case node256:
+		return n.children[c]
+	}
+	return nil
+}
+
+// search returns an index of the byte c in sorted keys of node4 or node16.
+// If there is no such byte, it returns the index where it should be inserted.
+func (n *artnode) search(c byte) (int, bool) {
+	i := sort.Search(n.size, func(i int) bool {
+		return n.keys[i] >= c
+	})
+	return i, i < n.size && n.keys[i] == c
+}
+
+// add inserts a child corresponding to the byte c, the node grows if it's full.
+func (n *artnode) add(c byte, child *artnode) {
+	switch n.kind {
+	case node4, node16:
+		if n.children == nil {
+			n.keys = make([]byte, 0, 4)
+			n.children = make([]*artnode, 0, 4)
+		}
+		if n.size == cap(n.children) {
+			n.grow()
+			n.add(c, child)
+			return
+		}
+		i, _ := n.search(c)
+		n.keys = slices.Insert(n.keys, i, c)
+		n.children = slices.Insert(n.children, i, child)
+	case node48:
+		if n.size == 48 {
+			n.grow()
+			n.add(c, child)
+			return
+		}
+		n.children = append(n.children, child)
+		n.keys[c] = byte(len(n.children))
+	case node256:
+		n.children[c] = child
+	}
+	n.size++
+}
+
+// replace sets a new child corresponding to the existing byte c.
+func (n *artnode) replace(c byte, child *artnode) {
+	switch n.kind {
+	case node4, node16:
+		i, _ := n.search(c)
+		n.children[i] = child
+	case node48:
+		n.children[n.keys[c]-1] = child
+	case node256:
+		n.children[c] = child
+	}
+}
+
+// remove deletes a child corresponding to the existing byte c, the node shrinks if it becomes sparse.
+func (n *artnode) remove(c byte) {
+	switch n.kind {
+	case node4, node16:
+		i, _ := n.search(c)
+		n.keys = slices.Delete(n.keys, i, i+1)
+		n.children = slices.Delete(n.children, i, i+1)
+	case node48:
+		// Move the last child into the freed slot to keep the children dense.
+		i, last := n.keys[c]-1, len(n.children)-1
+		for b := range n.keys {
+			if int(n.keys[b]) == last+1 {
+				n.keys[b] = i + 1
+				break
+			}
+		}
+		n.children[i] = n.children[last]
+		n.children[last] = nil
+		n.children = n.children[:last]
+		n.keys[c] = 0
+	case node256:
+		n.children[c] = nil
+	}
+	n.size--
+
+	// Shrink thresholds are lower than capacities of the smaller kinds
+	// to avoid growing and shrinking back and forth.
+	switch {
+	case n.kind == node16 && n.size <= 3,
+		n.kind == node48 && n.size <= 12,
+		n.kind == node256 && n.size <= 40:
+		n.shrink()
+	}
+}
+
+// grow converts the node into the next larger kind.
+func (n *artnode) grow() {
+	keys, children := n.keys, n.children
+	switch n.kind {
+	case node4:
+		n.kind = node16
+		n.keys = make([]byte, len(keys), 16)
+		n.children = make([]*artnode, len(children), 16)
+		copy(n.keys, keys)
+		copy(n.children, children)
+	case node16:
+		n.kind = node48
+		n.keys = make([]byte, 256)
+		n.children = make([]*artnode, len(children), 48)
+		copy(n.children, children)
+		for i, c := range keys {
+			n.keys[c] = byte(i + 1)
+		}
+	case node48:
+		n.kind = node256
+		n.keys = nil
+		n.children = make([]*artnode, 256)
+		for c, i := range keys {
+			if i != 0 {
+				n.children[c] = children[i-1]
+			}
+		}
+	}
+}
+
+// shrink converts the node into the next smaller kind.
+func (n *artnode) shrink() {
+	var (
+		keys     []byte
+		children []*artnode
+	)
+	n.each(func(c byte, child *artnode) {
+		keys = append(keys, c)
+		children = append(children, child)
+	})
+
+	n.kind--
+	switch n.kind {
+	case node4:
+		n.keys = make([]byte, len(keys), 4)
+		n.children = make([]*artnode, len(children), 4)
+		copy(n.keys, keys)
+		copy(n.children, children)
+	case node16:
+		n.keys = make([]byte, len(keys), 16)
+		n.children = make([]*artnode, len(children), 16)
+		copy(n.keys, keys)
+		copy(n.children, children)
+	case node48:
+		n.keys = make([]byte, 256)
+		n.children = make([]*artnode, len(children), 48)
+		copy(n.children, children)
+		for i, c := range keys {
+			n.keys[c] = byte(i + 1)
+		}
+	}
+}
+
+// each calls fn for every child in order of their bytes.
+func (n *artnode) each(fn func(c byte, child *artnode)) {
+	switch n.kind {
+	case node4, node16:
+		for i := 0; i < n.size; i++ {
+			fn(n.keys[i], n.children[i])
+		}
+	case node48:
+		for c, i := range n.keys {
+			if i != 0 {
+				fn(byte(c), n.children[i-1])
+			}
+		}
+	case node256:
+		for c, child := range n.children {
+			if child != nil {
+				fn(byte(c), child)
+			}
+		}
+	}
+}
+
+// only returns the byte and the child of a node that has exactly one child.
+func (n *artnode) only() (c byte, child *artnode) {
+	n.each(func(b byte, ch *artnode) {
+		c, child = b, ch
+	})
+	return c, child
+}
+
+// Get searches the value associated with a given key.
+// At each node it follows the child link corresponding to the next key byte,
+// then it checks that the compressed path of the child matches the key.
+func (t *AdaptiveRadixTree) Get(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	n := &t.root
+	for key != "" {
+		child := n.find(key[0])
+		if child == nil || !strings.HasPrefix(key[1:], child.prefix) {
+			return ""
+		}
+		key = key[1+len(child.prefix):]
+		n = child
+	}
+	return n.value
+}
+
+/*
+Put inserts a key with value into a tree. It follows the child links until one of the following holds:
+
+	the key is exhausted, so the node's value is updated
+	there is no child link for the next byte, so a new node with the rest of the key as its prefix is added
+	the key diverges in the middle of a compressed path, so the path is split at the point of divergence
+
+Putting an empty value deletes the key.
+*/
+func (t *AdaptiveRadixTree) Put(key, value string) {
+	if key == "" {
+		return
+	}
+	if value == "" {
+		t.Delete(key)
+		return
+	}
+
+	n := &t.root
+	for key != "" {
+		c, rest := key[0], key[1:]
+		child := n.find(c)
+		if child == nil {
+			// Leaves are node4 without children, their arrays are allocated on demand.
+			n.add(c, &artnode{prefix: rest, value: value})
+			t.n++
+			return
+		}
+
+		l := commonPrefixLen(rest, child.prefix)
+		if l < len(child.prefix) {
+			// Split the compressed path, so the common part leads to a new branching node.
+			mid := &artnode{prefix: child.prefix[:l]}
+			mid.add(child.prefix[l], child)
+			child.prefix = child.prefix[l+1:]
+			n.replace(c, mid)
+			child = mid
+		}
+		key = rest[l:]
+		n = child
+	}
+
+	if n.value == "" {
+		t.n++
+	}
+	n.value = value
+}
+
+// Size returns the number of keys in the tree.
+func (t *AdaptiveRadixTree) Size() int {
+	return t.n
+}
+
+// Contains reports whether the tree has a value for the key.
+func (t *AdaptiveRadixTree) Contains(key string) bool {
+	return t.Get(key) != ""
+}
+
+/*
+Delete removes the key from the tree.
+It finds the node corresponding to the key and sets its value to empty string.
+To keep paths compressed, a node without children is removed,
+and a node with a single child is merged with that child.
+*/
+func (t *AdaptiveRadixTree) Delete(key string) {
+	if key == "" {
+		return
+	}
+	t.delete(&t.root, key)
+}
+
+// delete removes the key from the subtree rooted at n and reports whether the key was found.
+func (t *AdaptiveRadixTree) delete(n *artnode, key string) bool {
+	if key == "" {
+		if n.value == "" {
+			return false
+		}
+		n.value = ""
+		t.n--
+		return true
+	}
+
+	c, rest := key[0], key[1:]
+	child := n.find(c)
+	if child == nil || !strings.HasPrefix(rest, child.prefix) || !t.delete(child, rest[len(child.prefix):]) {
+		return false
+	}
+
+	if child.value == "" {
+		switch child.size {
+		case 0:
+			n.remove(c)
+		case 1:
+			b, grandchild := child.only()
+			grandchild.prefix = child.prefix + string(b) + grandchild.prefix
+			n.replace(c, grandchild)
+		}
+	}
+	return true
+}
+
+// Keys returns all the keys in the tree in sorted order.
+func (t *AdaptiveRadixTree) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+/*
+KeysWithPrefix returns the keys that start with the prefix in sorted order.
+It follows the child links until the prefix is exhausted, possibly in the middle of a compressed path,
+and collects the keys from the subtree rooted at the node where the search stopped.
+*/
+func (t *AdaptiveRadixTree) KeysWithPrefix(prefix string) []string {
+	var (
+		keys []string
+		path []byte
+		n    = &t.root
+	)
+	for prefix != "" {
+		child := n.find(prefix[0])
+		if child == nil {
+			return nil
+		}
+		c, rest := prefix[0], prefix[1:]
+		switch {
+		case strings.HasPrefix(rest, child.prefix):
+			prefix = rest[len(child.prefix):]
+		case strings.HasPrefix(child.prefix, rest):
+			prefix = ""
+		default:
+			return nil
+		}
+		path = append(append(path, c), child.prefix...)
+		n = child
+	}
+
+	t.collect(n, path, &keys)
+	return keys
+}
+
+// collect appends to keys all the keys of the subtree rooted at n.
+// The prefix is a string corresponding to the path from the root to n including n's compressed path.
+func (t *AdaptiveRadixTree) collect(n *artnode, prefix []byte, keys *[]string) {
+	if n.value != "" {
+		*keys = append(*keys, string(prefix))
+	}
+	n.each(func(c byte, child *artnode) {
+		t.collect(child, append(append(prefix, c), child.prefix...), keys)
+	})
+}
+
+/*
+KeysThatMatch returns the keys that match the pattern in sorted order,
+where a period matches any character (byte), e.g., ".he" matches "she" and "the".
+Only the keys of the same length as the pattern are considered.
+*/
+func (t *AdaptiveRadixTree) KeysThatMatch(pattern string) []string {
+	var keys []string
+	if pattern != "" {
+		t.collectMatch(&t.root, nil, pattern, &keys)
+	}
+	return keys
+}
+
+// collectMatch appends to keys all the keys of the subtree rooted at n that match the pattern.
+// The prefix is a string corresponding to the path from the root to n including n's compressed path.
+func (t *AdaptiveRadixTree) collectMatch(n *artnode, prefix []byte, pattern string, keys *[]string) {
+	d := len(prefix)
+	if d == len(pattern) {
+		if n.value != "" {
+			*keys = append(*keys, string(prefix))
+		}
+		return
+	}
+
+	visit := func(c byte, child *artnode) {
+		if d+1+len(child.prefix) > len(pattern) || !matchLabel(child.prefix, pattern[d+1:]) {
+			return
+		}
+		t.collectMatch(child, append(append(prefix, c), child.prefix...), pattern, keys)
+	}
+	if c := pattern[d]; c != '.' {
+		if child := n.find(c); child != nil {
+			visit(c, child)
+		}
+		return
+	}
+	n.each(visit)
+}
+
+/*
+LongestPrefixOf returns the longest key that is a prefix of the query, e.g.,
+"shell" is the longest prefix of "shellsort" given keys "she" and "shell".
+It follows the child links keeping track of the last node with a value.
+*/
+func (t *AdaptiveRadixTree) LongestPrefixOf(query string) string {
+	length := 0
+	n := &t.root
+	for i := 0; ; {
+		if n.value != "" {
+			length = i
+		}
+		if i == len(query) {
+			break
+		}
+		child := n.find(query[i])
+		if child == nil || !strings.HasPrefix(query[i+1:], child.prefix) {
+			break
+		}
+		i += 1 + len(child.prefix)
+		n = child
+	}
+	return query[:length]
+}
@@ -0,0 +1,100 @@
+package strsearch
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestAdaptiveRadixTree_nodeKinds(t *testing.T) {
+	tests := []struct {
+		size int
+		want uint8
+	}{
+		{1, node4},
+		{4, node4},
+		{5, node16},
+		{16, node16},
+		{17, node48},
+		{48, node48},
+		{49, node256},
+		{256, node256},
+	}
+	for _, tc := range tests {
+		art := AdaptiveRadixTree{}
+		for c := 0; c < tc.size; c++ {
+			art.Put(string([]byte{byte(c)}), "v")
+		}
+		if art.root.kind != tc.want || art.root.size != tc.size {
+			t.Errorf("AdaptiveRadixTree with %d keys got root kind %d (%d children), want %d", tc.size, art.root.kind, art.root.size, tc.want)
+		}
+	}
+}
+
+func TestAdaptiveRadixTree_shrink(t *testing.T) {
+	art := AdaptiveRadixTree{}
+	var want []string
+	for c := 0; c < 256; c++ {
+		k := string([]byte{byte(c)}) + "x"
+		art.Put(k, k)
+		want = append(want, k)
+	}
+
+	// Delete keys in a shuffled order checking that node remains consistent while it shrinks.
+	r := rand.New(rand.NewSource(1))
+	for _, i := range r.Perm(256) {
+		k := string([]byte{byte(i)}) + "x"
+		art.Delete(k)
+		j := slices.Index(want, k)
+		want = slices.Delete(want, j, j+1)
+
+		if got := art.Keys(); !slices.Equal(got, want) {
+			t.Fatalf("Delete(%q) keys = %q, want %q", k, got, want)
+		}
+		for _, k := range want {
+			if got := art.Get(k); got != k {
+				t.Fatalf("Get(%q) = %q, want %q", k, got, k)
+			}
+		}
+	}
+	if art.root.kind != node4 || art.root.size != 0 {
+		t.Errorf("empty tree root kind %d (%d children), want %d", art.root.kind, art.root.size, node4)
+	}
+}
+
+func TestAdaptiveRadixTree_random(t *testing.T) {
+	art := AdaptiveRadixTree{}
+	want := make(map[string]string)
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 20000; i++ {
+		b := make([]byte, 1+r.Intn(4))
+		for j := range b {
+			b[j] = byte(r.Intn(64))
+		}
+		k := string(b)
+		if r.Intn(3) == 0 {
+			art.Delete(k)
+			delete(want, k)
+		} else {
+			art.Put(k, fmt.Sprint(i))
+			want[k] = fmt.Sprint(i)
+		}
+	}
+
+	if art.Size() != len(want) {
+		t.Errorf("Size() = %d, want %d", art.Size(), len(want))
+	}
+	wantKeys := make([]string, 0, len(want))
+	for k, v := range want {
+		wantKeys = append(wantKeys, k)
+		if got := art.Get(k); got != v {
+			t.Errorf("Get(%q) = %q, want %q", k, got, v)
+		}
+	}
+	sort.Strings(wantKeys)
+	if got := art.Keys(); !slices.Equal(got, wantKeys) {
+		t.Errorf("Keys() mismatch: got %d keys, want %d", len(got), len(wantKeys))
+	}
+}
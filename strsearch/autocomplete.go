@@ -0,0 +1,266 @@
+package strsearch
+
+import (
+	"container/heap"
+	"math"
+	"unicode/utf8"
+)
+
+// Completion is a term suggested by Autocomplete and the term's weight.
+type Completion struct {
+	Term   string
+	Weight int
+}
+
+/*
+Autocomplete suggests the heaviest terms (e.g., the most popular queries) that start with a given prefix.
+It's a ternary search trie (see TernaryTrie) where each node also stores the max weight of the terms
+in the subtrie rooted at that node (including the left and right links).
+
+To find the top k completions, it doesn't scan all the terms that start with the prefix.
+Instead it runs a best-first search: subtries are kept in a priority queue ordered by their max weight,
+so the heaviest subtrie is expanded first, and the search stops once k terms were taken off the queue.
+Since a subtrie's max weight is never less than the weights in it, the terms come off the queue
+in order of decreasing weight.
+
+Zero value is an empty autocomplete ready to use.
+*/
+type Autocomplete struct {
+	root *acnode
+	// n is the number of terms.
+	n int
+}
+type acnode struct {
+	char rune
+	// isTerm indicates whether the path to the node spells a term with the given weight.
+	isTerm bool
+	weight int
+	// max is the max weight of the terms in the subtrie rooted at the node.
+	max   int
+	left  *acnode
+	mid   *acnode
+	right *acnode
+}
+
+// update recomputes the max weight of the subtrie rooted at n.
+func (n *acnode) update() {
+	n.max = math.MinInt
+	if n.isTerm {
+		n.max = n.weight
+	}
+	for _, child := range [...]*acnode{n.left, n.mid, n.right} {
+		if child != nil && child.max > n.max {
+			n.max = child.max
+		}
+	}
+}
+
+// Put inserts a term with the weight or updates the weight of the existing term.
+// The max weights are recomputed on the way back up from the term's node.
+func (a *Autocomplete) Put(term string, weight int) {
+	if term == "" {
+		return
+	}
+	a.root = a.put(a.root, term, weight, 0)
+}
+
+func (a *Autocomplete) put(n *acnode, term string, weight, i int) *acnode {
+	char, width := utf8.DecodeRuneInString(term[i:])
+	if n == nil {
+		n = &acnode{char: char}
+	}
+
+	switch {
+	case char < n.char:
+		n.left = a.put(n.left, term, weight, i)
+	case char > n.char:
+		n.right = a.put(n.right, term, weight, i)
+	default:
+		if isLastChar := i+width == len(term); isLastChar {
+			if !n.isTerm {
+				a.n++
+			}
+			n.isTerm = true
+			n.weight = weight
+		} else {
+			n.mid = a.put(n.mid, term, weight, i+width)
+		}
+	}
+
+	n.update()
+	return n
+}
+
+// Size returns the number of terms.
+func (a *Autocomplete) Size() int {
+	return a.n
+}
+
+// Weight returns the weight of the term.
+func (a *Autocomplete) Weight(term string) (weight int, ok bool) {
+	if term == "" {
+		return 0, false
+	}
+	n := a.get(a.root, term, 0)
+	if n == nil || !n.isTerm {
+		return 0, false
+	}
+	return n.weight, true
+}
+
+// get returns the node corresponding to the last character of the key in the subtrie rooted at n.
+func (a *Autocomplete) get(n *acnode, key string, i int) *acnode {
+	for n != nil {
+		char, width := utf8.DecodeRuneInString(key[i:])
+		switch {
+		case char < n.char:
+			n = n.left
+		case char > n.char:
+			n = n.right
+		case i+width == len(key):
+			return n
+		default:
+			i += width
+			n = n.mid
+		}
+	}
+	return nil
+}
+
+// TopK returns up to k heaviest terms that start with the prefix in order of decreasing weight.
+// Terms of equal weight come in no particular order.
+func (a *Autocomplete) TopK(prefix string, k int) []Completion {
+	var pq acqueue
+	if prefix == "" {
+		pq.pushSubtrie(a.root, "")
+		return pq.top(k)
+	}
+
+	n := a.get(a.root, prefix, 0)
+	if n == nil {
+		return nil
+	}
+	pq.pushTerm(n, prefix)
+	pq.pushSubtrie(n.mid, prefix)
+	return pq.top(k)
+}
+
+/*
+Fuzzy returns up to k heaviest terms that start with a string within maxEdits edit distance of the prefix,
+e.g., "hte" matches "the" and "theory" within 2 edits (Levenshtein distance counts insertions,
+deletions and substitutions of characters). The terms are in order of decreasing weight
+regardless of the distance. Distances of 1 and 2 are practical, larger ones match too many terms.
+
+The trie is traversed depth-first while the last row of the edit distance table is maintained
+for the path from the root: each character on the path adds a new row computed from the previous one.
+Once the distance between the whole prefix and the path is within maxEdits, all the terms
+below that node match, so the subtrie is handed over to the best-first search.
+A branch is pruned when all the distances in the row exceed maxEdits.
+*/
+func (a *Autocomplete) Fuzzy(prefix string, k, maxEdits int) []Completion {
+	q := []rune(prefix)
+	// row[i] is the edit distance between the first i characters of the prefix and an empty path.
+	row := make([]int, len(q)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	var pq acqueue
+	if row[len(q)] <= maxEdits {
+		pq.pushSubtrie(a.root, "")
+		return pq.top(k)
+	}
+	a.fuzzy(a.root, "", q, row, maxEdits, &pq)
+	return pq.top(k)
+}
+
+// fuzzy pushes onto the queue the subtries whose paths are within maxEdits of the query.
+// The row contains edit distances between the query prefixes and the path to n excluding n's character.
+func (a *Autocomplete) fuzzy(n *acnode, path string, q []rune, row []int, maxEdits int, pq *acqueue) {
+	if n == nil {
+		return
+	}
+	a.fuzzy(n.left, path, q, row, maxEdits, pq)
+	a.fuzzy(n.right, path, q, row, maxEdits, pq)
+
+	next := make([]int, len(row))
+	next[0] = row[0] + 1
+	closest := next[0]
+	for i := 1; i <= len(q); i++ {
+		cost := 1
+		if q[i-1] == n.char {
+			cost = 0
+		}
+		next[i] = min(row[i]+1, next[i-1]+1, row[i-1]+cost)
+		closest = min(closest, next[i])
+	}
+
+	term := path + string(n.char)
+	if next[len(q)] <= maxEdits {
+		pq.pushTerm(n, term)
+		pq.pushSubtrie(n.mid, term)
+		return
+	}
+	if closest <= maxEdits {
+		a.fuzzy(n.mid, term, q, next, maxEdits, pq)
+	}
+}
+
+// acitem is either a term or a subtrie in the priority queue.
+type acitem struct {
+	n *acnode
+	// prefix is the term itself or the path to the subtrie excluding its root character.
+	prefix   string
+	isTerm   bool
+	priority int
+}
+
+// acqueue is a max priority queue of terms and subtries that implements heap.Interface.
+type acqueue []acitem
+
+func (pq acqueue) Len() int           { return len(pq) }
+func (pq acqueue) Less(i, j int) bool { return pq[i].priority > pq[j].priority }
+func (pq acqueue) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i] }
+func (pq *acqueue) Push(x any)        { *pq = append(*pq, x.(acitem)) }
+func (pq *acqueue) Pop() any {
+	old := *pq
+	it := old[len(old)-1]
+	*pq = old[:len(old)-1]
+	return it
+}
+
+// pushSubtrie adds the subtrie rooted at n prioritized by its max weight.
+func (pq *acqueue) pushSubtrie(n *acnode, prefix string) {
+	if n != nil {
+		heap.Push(pq, acitem{n: n, prefix: prefix, priority: n.max})
+	}
+}
+
+// pushTerm adds the term if the node has one.
+func (pq *acqueue) pushTerm(n *acnode, term string) {
+	if n.isTerm {
+		heap.Push(pq, acitem{prefix: term, isTerm: true, priority: n.weight})
+	}
+}
+
+// top takes up to k terms off the queue expanding the subtries on the way.
+// When a subtrie is expanded, its left and right subtries share the same prefix,
+// whereas the middle one extends it with the root character.
+func (pq *acqueue) top(k int) []Completion {
+	var cc []Completion
+	for pq.Len() > 0 && len(cc) < k {
+		it := heap.Pop(pq).(acitem)
+		if it.isTerm {
+			cc = append(cc, Completion{Term: it.prefix, Weight: it.priority})
+			continue
+		}
+
+		n := it.n
+		pq.pushSubtrie(n.left, it.prefix)
+		pq.pushSubtrie(n.right, it.prefix)
+		term := it.prefix + string(n.char)
+		pq.pushTerm(n, term)
+		pq.pushSubtrie(n.mid, term)
+	}
+	return cc
+}
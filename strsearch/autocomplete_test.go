@@ -0,0 +1,229 @@
+package strsearch
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// cities are weighted by population.
+var cities = []Completion{
+	{"Shanghai", 14608512},
+	{"Buenos Aires", 13076300},
+	{"Mumbai", 12691836},
+	{"Mexico City", 12294193},
+	{"Karachi", 11624219},
+	{"Istanbul", 11174257},
+	{"Delhi", 10927986},
+	{"Manila", 10444527},
+	{"Moscow", 10381222},
+	{"Dhaka", 10356500},
+	{"Seoul", 10349312},
+	{"Sao Paulo", 10021295},
+	{"Lagos", 9000000},
+	{"Madrid", 3255944},
+	{"Melbourne", 4246375},
+	{"Montreal", 3268513},
+	{"Moss", 30000},
+	{"Mos", 100},
+}
+
+func citiesAutocomplete() *Autocomplete {
+	a := Autocomplete{}
+	for _, c := range cities {
+		a.Put(c.Term, c.Weight)
+	}
+	return &a
+}
+
+// terms returns the terms of the completions.
+func terms(cc []Completion) []string {
+	tt := make([]string, len(cc))
+	for i := range cc {
+		tt[i] = cc[i].Term
+	}
+	return tt
+}
+
+func TestAutocompleteTopK(t *testing.T) {
+	a := citiesAutocomplete()
+	tests := []struct {
+		prefix string
+		k      int
+		want   []string
+	}{
+		{"M", 3, []string{"Mumbai", "Mexico City", "Manila"}},
+		{"M", 0, nil},
+		{"Mo", 5, []string{"Moscow", "Montreal", "Moss", "Mos"}},
+		{"Mos", 2, []string{"Moscow", "Moss"}},
+		{"Moss", 10, []string{"Moss"}},
+		{"Mosq", 10, nil},
+		{"", 2, []string{"Shanghai", "Buenos Aires"}},
+		{"X", 2, nil},
+	}
+	for _, tc := range tests {
+		if got := terms(a.TopK(tc.prefix, tc.k)); !slices.Equal(got, tc.want) {
+			t.Errorf("TopK(%q, %d) = %q, want %q", tc.prefix, tc.k, got, tc.want)
+		}
+	}
+
+	if got := a.Size(); got != len(cities) {
+		t.Errorf("Size() = %d, want %d", got, len(cities))
+	}
+}
+
+func TestAutocompleteUpdateWeight(t *testing.T) {
+	a := citiesAutocomplete()
+	a.Put("Mumbai", 1)
+	a.Put("Mos", 20000000)
+
+	want := []string{"Mos", "Mexico City", "Manila"}
+	if got := terms(a.TopK("M", 3)); !slices.Equal(got, want) {
+		t.Errorf("TopK(M, 3) = %q, want %q", got, want)
+	}
+	if w, ok := a.Weight("Mumbai"); !ok || w != 1 {
+		t.Errorf("Weight(Mumbai) = %d %v, want 1", w, ok)
+	}
+	if _, ok := a.Weight("Mu"); ok {
+		t.Errorf("Weight(Mu) expected to be missing")
+	}
+	if got := a.Size(); got != len(cities) {
+		t.Errorf("Size() = %d, want %d", got, len(cities))
+	}
+}
+
+func TestAutocompleteFuzzy(t *testing.T) {
+	a := citiesAutocomplete()
+	tests := []struct {
+		prefix   string
+		maxEdits int
+		want     []string
+	}{
+		{"Mosc", 0, []string{"Moscow"}},
+		{"Moxc", 1, []string{"Moscow"}},
+		{"Mscow", 1, []string{"Moscow"}},
+		{"Mosscow", 1, []string{"Moscow"}},
+		{"Moxc", 0, nil},
+		{"Dehli", 1, nil},
+		{"Dehli", 2, []string{"Delhi"}},
+		// Deleting "i" gives "Mumba" which is a prefix of "Mumbai".
+		{"Mumbia", 1, []string{"Mumbai"}},
+		{"Mos", 1, []string{"Moscow", "Montreal", "Moss", "Mos"}},
+		{"X", 1, []string{"Shanghai", "Buenos Aires", "Mumbai", "Mexico City", "Karachi", "Istanbul"}},
+	}
+	for _, tc := range tests {
+		if got := terms(a.Fuzzy(tc.prefix, 6, tc.maxEdits)); !slices.Equal(got, tc.want) {
+			t.Errorf("Fuzzy(%q, 6, %d) = %q, want %q", tc.prefix, tc.maxEdits, got, tc.want)
+		}
+	}
+}
+
+// levenshtein computes the edit distance between a and b.
+func levenshtein(a, b []rune) int {
+	row := make([]int, len(b)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		prev := row[0]
+		row[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			prev, row[j] = row[j], min(row[j]+1, row[j-1]+1, prev+cost)
+		}
+	}
+	return row[len(b)]
+}
+
+func TestAutocompleteRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	a := Autocomplete{}
+	weights := make(map[string]int)
+	for i := 0; i < 2000; i++ {
+		b := make([]byte, 1+r.Intn(6))
+		for j := range b {
+			b[j] = "abcd"[r.Intn(4)]
+		}
+		// Weights are unique to make the order deterministic.
+		weights[string(b)] = i
+		a.Put(string(b), i)
+	}
+
+	for _, q := range []string{"", "a", "ab", "cab", "dddd", "abcabc"} {
+		for maxEdits := 0; maxEdits <= 2; maxEdits++ {
+			var want []Completion
+			for term, w := range weights {
+				tt, qq := []rune(term), []rune(q)
+				// A term matches if any of its prefixes is within maxEdits of the query.
+				for l := 0; l <= len(tt); l++ {
+					if levenshtein(tt[:l], qq) <= maxEdits {
+						want = append(want, Completion{term, w})
+						break
+					}
+				}
+			}
+			sort.Slice(want, func(i, j int) bool { return want[i].Weight > want[j].Weight })
+			if len(want) > 10 {
+				want = want[:10]
+			}
+
+			got := a.Fuzzy(q, 10, maxEdits)
+			if !slices.Equal(got, want) {
+				t.Errorf("Fuzzy(%q, 10, %d) = %v, want %v", q, maxEdits, got, want)
+			}
+			if maxEdits > 0 {
+				continue
+			}
+			if got = a.TopK(q, 10); !slices.Equal(got, want) {
+				t.Errorf("TopK(%q, 10) = %v, want %v", q, got, want)
+			}
+		}
+	}
+}
+
+func BenchmarkAutocompleteTopK(b *testing.B) {
+	a := Autocomplete{}
+	words := wordCorpus(100000)
+	for i, w := range words {
+		a.Put(w, i)
+	}
+	prefixes := make([]string, 100)
+	for i := range prefixes {
+		prefixes[i] = words[i][:2]
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		a.TopK(prefixes[i%len(prefixes)], 10)
+	}
+}
+
+func ExampleAutocomplete() {
+	a := Autocomplete{}
+	a.Put("the", 100)
+	a.Put("they", 60)
+	a.Put("theory", 30)
+	a.Put("then", 80)
+	a.Put("tea", 90)
+
+	for _, c := range a.TopK("the", 3) {
+		fmt.Println(c.Term, c.Weight)
+	}
+	fmt.Println(strings.Repeat("-", 3))
+	for _, c := range a.Fuzzy("hte", 2, 2) {
+		fmt.Println(c.Term, c.Weight)
+	}
+	// Output:
+	// the 100
+	// then 80
+	// they 60
+	// ---
+	// the 100
+	// tea 90
+}
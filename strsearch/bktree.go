@@ -0,0 +1,114 @@
+package strsearch
+
+import "sort"
+
+// Suggestion is a key found within a distance of the query.
+type Suggestion struct {
+	Key      string
+	Distance int
+}
+
+/*
+BKTree (Burkhard-Keller tree) indexes keys in a metric space to find the keys close to a query,
+e.g., dictionary words within 2 typos. Each node has a key and its children are indexed
+by their distance to that key: all the keys in the subtree of the child at distance d
+are exactly d away from the node's key.
+
+When searching for the keys within maxEdits of the query, the triangle inequality lets us skip
+the children whose distance d to the node's key is outside [dist-maxEdits, dist+maxEdits],
+where dist is the distance between the query and the node's key.
+The smaller maxEdits, the fewer nodes are visited, e.g., 1 or 2 edits usually examine a small
+fraction of the tree, but a large distance degrades to comparing the query with every key.
+
+Zero value is a BK-tree with Levenshtein distance, use NewBKTree for another metric, e.g., Damerau.
+*/
+type BKTree struct {
+	distance func(a, b string) int
+	root     *bknode
+	// n is the number of keys in the tree.
+	n int
+}
+type bknode struct {
+	key string
+	// children are indexed by the distance to the key.
+	children map[int]*bknode
+}
+
+// NewBKTree creates a BK-tree that measures distance between keys with a metric,
+// i.e., the distance must be symmetric and satisfy the triangle inequality.
+func NewBKTree(distance func(a, b string) int) *BKTree {
+	return &BKTree{distance: distance}
+}
+
+func (t *BKTree) dist(a, b string) int {
+	if t.distance == nil {
+		return Levenshtein(a, b)
+	}
+	return t.distance(a, b)
+}
+
+// Add inserts the key into the tree. It walks down from the root following the child
+// at the key's distance to the current node until there is no such child.
+func (t *BKTree) Add(key string) {
+	if t.root == nil {
+		t.root = &bknode{key: key}
+		t.n++
+		return
+	}
+
+	n := t.root
+	for {
+		d := t.dist(key, n.key)
+		if d == 0 {
+			return
+		}
+		child, ok := n.children[d]
+		if !ok {
+			if n.children == nil {
+				n.children = make(map[int]*bknode)
+			}
+			n.children[d] = &bknode{key: key}
+			t.n++
+			return
+		}
+		n = child
+	}
+}
+
+// Size returns the number of keys in the tree.
+func (t *BKTree) Size() int {
+	return t.n
+}
+
+// Search returns the keys within maxEdits distance of the query
+// ordered by distance and then by key.
+func (t *BKTree) Search(query string, maxEdits int) []Suggestion {
+	var found []Suggestion
+	if t.root == nil {
+		return found
+	}
+
+	stack := []*bknode{t.root}
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := t.dist(query, n.key)
+		if d <= maxEdits {
+			found = append(found, Suggestion{Key: n.key, Distance: d})
+		}
+		for cd, child := range n.children {
+			if cd >= d-maxEdits && cd <= d+maxEdits {
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].Distance != found[j].Distance {
+			return found[i].Distance < found[j].Distance
+		}
+		return found[i].Key < found[j].Key
+	})
+	return found
+}
@@ -0,0 +1,82 @@
+package strsearch
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestBKTree(t *testing.T) {
+	var bk BKTree
+	for _, k := range []string{"book", "books", "cake", "boo", "cape", "cart", "boon", "cook", "book"} {
+		bk.Add(k)
+	}
+	if got := bk.Size(); got != 8 {
+		t.Errorf("Size() = %d, want 8", got)
+	}
+
+	tests := []struct {
+		query    string
+		maxEdits int
+		want     []Suggestion
+	}{
+		{"bo", 0, nil},
+		{"book", 0, []Suggestion{{"book", 0}}},
+		{"bok", 1, []Suggestion{{"boo", 1}, {"book", 1}}},
+		{"caqe", 1, []Suggestion{{"cake", 1}, {"cape", 1}}},
+		{"boko", 2, []Suggestion{{"boo", 1}, {"book", 2}, {"books", 2}, {"boon", 2}}},
+	}
+	for _, tc := range tests {
+		if got := bk.Search(tc.query, tc.maxEdits); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Search(%q, %d) = %v, want %v", tc.query, tc.maxEdits, got, tc.want)
+		}
+	}
+}
+
+func TestBKTreeDamerau(t *testing.T) {
+	bk := NewBKTree(Damerau)
+	for _, k := range []string{"the", "then", "they", "tea"} {
+		bk.Add(k)
+	}
+	want := []Suggestion{{"the", 1}, {"tea", 2}, {"then", 2}, {"they", 2}}
+	if got := bk.Search("hte", 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("Search(hte, 2) = %v, want %v", got, want)
+	}
+}
+
+func TestBKTreeRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, dist := range []func(a, b string) int{Levenshtein, Damerau} {
+		bk := NewBKTree(dist)
+		keys := make(map[string]bool)
+		for i := 0; i < 500; i++ {
+			k := randomWord(r, "abcd", 6)
+			bk.Add(k)
+			keys[k] = true
+		}
+		if bk.Size() != len(keys) {
+			t.Fatalf("Size() = %d, want %d", bk.Size(), len(keys))
+		}
+
+		for i := 0; i < 100; i++ {
+			q, maxEdits := randomWord(r, "abcde", 6), r.Intn(3)
+			var want []Suggestion
+			for k := range keys {
+				if d := dist(q, k); d <= maxEdits {
+					want = append(want, Suggestion{k, d})
+				}
+			}
+			sort.Slice(want, func(i, j int) bool {
+				if want[i].Distance != want[j].Distance {
+					return want[i].Distance < want[j].Distance
+				}
+				return want[i].Key < want[j].Key
+			})
+
+			if got := bk.Search(q, maxEdits); !reflect.DeepEqual(got, want) {
+				t.Fatalf("Search(%q, %d) = %v, want %v", q, maxEdits, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package strsearch
+
+/*
+Levenshtein returns the edit distance between a and b:
+the min number of insertions, deletions, and substitutions of characters (runes)
+required to transform a into b, e.g., the distance between "kitten" and "sitting" is 3.
+
+The distance table d[i][j] holds the distance between the first i characters of a
+and the first j characters of b:
+
+	d[i][0] = i and d[0][j] = j (transform to or from an empty string)
+	d[i][j] = d[i-1][j-1] if a[i-1] == b[j-1]
+	d[i][j] = 1 + min(d[i-1][j], d[i][j-1], d[i-1][j-1]) otherwise
+
+Only the previous row of the table is needed to compute the next one,
+so it takes m*n time and n space.
+*/
+func Levenshtein(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	row := make([]int, len(t)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		// diag is d[i-1][j-1].
+		diag := row[0]
+		row[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			diag, row[j] = row[j], min(row[j]+1, row[j-1]+1, diag+cost)
+		}
+	}
+	return row[len(t)]
+}
+
+/*
+Damerau returns the Damerau-Levenshtein distance between a and b which also counts
+a transposition of two adjacent characters as a single edit, e.g., "ca" and "ac" are 1 edit apart.
+It's the unrestricted distance where a substring can be edited after a transposition,
+e.g., "ca" -> "ac" -> "abc" takes 2 edits.
+
+The Lowrance-Wagner algorithm extends the Levenshtein table: a transposition of a[i-1]
+with an earlier a[k-1] == b[j-1] (the last such k) and b[j-1] with an earlier b[l-1] == a[i-1]
+(the last such l) costs
+
+	d[k-1][l-1] + (i-k-1) + 1 + (j-l-1)
+
+where the characters between them are deleted from a and inserted from b.
+It takes m*n time and space.
+*/
+func Damerau(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// d is the distance table shifted by one row and column,
+	// so d[i+1][j+1] is the distance between the first i characters of a and the first j characters of b.
+	// The extra row and column hold the max possible distance.
+	inf := len(s) + len(t)
+	d := make([][]int, len(s)+2)
+	for i := range d {
+		d[i] = make([]int, len(t)+2)
+		d[i][0] = inf
+		if i > 0 {
+			d[i][1] = i - 1
+		}
+	}
+	for j := 1; j < len(t)+2; j++ {
+		d[0][j] = inf
+		d[1][j] = j - 1
+	}
+
+	// lastRow is the last row (character position in a) where the character was seen.
+	lastRow := make(map[rune]int)
+	for i := 1; i <= len(s); i++ {
+		// lastCol is the last column (character position in b) in this row where the characters matched.
+		lastCol := 0
+		for j := 1; j <= len(t); j++ {
+			k, l := lastRow[t[j-1]], lastCol
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+				lastCol = j
+			}
+			d[i+1][j+1] = min(
+				d[i][j]+cost,              // Substitution.
+				d[i+1][j]+1,               // Insertion.
+				d[i][j+1]+1,               // Deletion.
+				d[k][l]+(i-k-1)+1+(j-l-1), // Transposition.
+			)
+		}
+		lastRow[s[i-1]] = i
+	}
+	return d[len(s)+1][len(t)+1]
+}
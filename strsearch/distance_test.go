@@ -0,0 +1,81 @@
+package strsearch
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"flaw", "lawn", 2},
+		{"ca", "ac", 2},
+		{"ca", "abc", 3},
+		{"hte", "the", 2},
+		{"привет", "превед", 2},
+	}
+	for _, tc := range tests {
+		if got := Levenshtein(tc.a, tc.b); got != tc.want {
+			t.Errorf("Levenshtein(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestDamerau(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"", "abc", 3},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"ca", "ac", 1},
+		{"ca", "abc", 2},
+		{"hte", "the", 1},
+		{"abcdef", "badcfe", 3},
+		{"привет", "пирвет", 1},
+	}
+	for _, tc := range tests {
+		if got := Damerau(tc.a, tc.b); got != tc.want {
+			t.Errorf("Damerau(%q, %q) = %d, want %d", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestDistanceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		a, b, c := randomWord(r, "abc", 8), randomWord(r, "abc", 8), randomWord(r, "abc", 8)
+		for _, dist := range []func(a, b string) int{Levenshtein, Damerau} {
+			ab, ba := dist(a, b), dist(b, a)
+			if ab != ba {
+				t.Fatalf("distance(%q, %q) = %d, distance(%q, %q) = %d", a, b, ab, b, a, ba)
+			}
+			if ac, bc := dist(a, c), dist(b, c); ac > ab+bc {
+				t.Fatalf("triangle inequality doesn't hold for %q, %q, %q", a, b, c)
+			}
+		}
+		if got, want := Levenshtein(a, b), levenshtein([]rune(a), []rune(b)); got != want {
+			t.Fatalf("Levenshtein(%q, %q) = %d, want %d", a, b, got, want)
+		}
+		if d, l := Damerau(a, b), Levenshtein(a, b); d > l {
+			t.Fatalf("Damerau(%q, %q) = %d is greater than Levenshtein distance %d", a, b, d, l)
+		}
+	}
+}
+
+// randomWord returns a word of up to n characters from the alphabet.
+func randomWord(r *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, r.Intn(n+1))
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
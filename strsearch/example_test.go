@@ -3,6 +3,7 @@ package strsearch_test
 import (
 	"fmt"
 
+	"github.com/marselester/alg/alphabet"
 	"github.com/marselester/alg/strsearch"
 )
 
@@ -33,3 +34,15 @@ func ExampleTernaryTrie() {
 	// sea 6
 	// shore 7
 }
+
+func ExampleNewTrieWithAlphabet() {
+	st := strsearch.NewTrieWithAlphabet(alphabet.DNA)
+	st.Put("GATTACA", "1")
+	st.Put("GAT", "2")
+	st.Put("ACGT", "3")
+	fmt.Println(st.Keys())
+	fmt.Println(st.LongestPrefixOf("GATTC"))
+	// Output:
+	// [ACGT GAT GATTACA]
+	// GAT
+}
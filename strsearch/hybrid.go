@@ -0,0 +1,307 @@
+package strsearch
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/marselester/alg/alphabet"
+)
+
+/*
+HybridTernaryTrie is a ternary search trie with R²-way branching at the root.
+The root is an array of R² TSTs: one for each possible pair of the first two characters of a key.
+Keys of a single character are kept in a separate array of R values.
+
+A search starts by indexing the array with the first two characters,
+so it skips the nodes that would be visited at the top of a TST where all the keys are concentrated.
+This works well for large numbers of keys from small alphabets, but the root array
+takes space proportional to R², e.g., 65536 entries for extended ASCII.
+*/
+type HybridTernaryTrie struct {
+	// tst keeps the alphabet and the number of keys, its root isn't used.
+	tst   TernaryTrie
+	radix int
+	// short holds values of single-character keys indexed by the character.
+	short []string
+	// roots are TSTs of keys starting with characters c1 and c2 indexed by c1*R + c2.
+	roots []hybridroot
+}
+type hybridroot struct {
+	// value associated with a two-character key.
+	value string
+	root  *tstnode
+}
+
+// maxHybridRadix is the max alphabet size of a hybrid TST, so the root has at most 65536 entries.
+const maxHybridRadix = 1 << 8
+
+// NewHybridTernaryTrie creates a TST with R²-way branching at the root for keys made of characters of the alphabet.
+// The root takes R² entries, hence it panics if the alphabet has more than 256 characters,
+// e.g., alphabet.Unicode (use TernaryTrie instead).
+func NewHybridTernaryTrie(a *alphabet.Alphabet) *HybridTernaryTrie {
+	r := a.Radix()
+	if r > maxHybridRadix {
+		panic(fmt.Sprintf("strsearch: alphabet of %d characters is too large for hybrid TST", r))
+	}
+	return &HybridTernaryTrie{
+		tst:   TernaryTrie{alphabet: a},
+		radix: r,
+		short: make([]string, r),
+		roots: make([]hybridroot, r*r),
+	}
+}
+
+// head returns alphabet indices of the first one or two characters of the key
+// and the byte offset after them. The second index is -1 if the key has a single character.
+// It returns false if a character is not in the alphabet.
+func (h *HybridTernaryTrie) head(key string) (c1, c2 rune, i int, ok bool) {
+	c1, w1 := h.tst.index(key, 0)
+	if c1 == -1 {
+		return 0, 0, 0, false
+	}
+	if w1 == len(key) {
+		return c1, -1, w1, true
+	}
+	c2, w2 := h.tst.index(key, w1)
+	if c2 == -1 {
+		return 0, 0, 0, false
+	}
+	return c1, c2, w1 + w2, true
+}
+
+// Get searches the value associated with a given key.
+// The first two characters of the key choose the TST where the rest of the key is searched.
+func (h *HybridTernaryTrie) Get(key string) string {
+	if key == "" {
+		return ""
+	}
+	c1, c2, i, ok := h.head(key)
+	switch {
+	case !ok:
+		return ""
+	case c2 == -1:
+		return h.short[c1]
+	}
+
+	r := &h.roots[int(c1)*h.radix+int(c2)]
+	if i == len(key) {
+		return r.value
+	}
+	if n := h.tst.get(r.root, key, i); n != nil {
+		return n.value
+	}
+	return ""
+}
+
+// Put inserts a key with value into a TST chosen by the first two characters of the key.
+// Putting an empty value deletes the key.
+// It panics if a key character is not in the trie's alphabet.
+func (h *HybridTernaryTrie) Put(key, value string) {
+	if key == "" {
+		return
+	}
+	if value == "" {
+		h.Delete(key)
+		return
+	}
+
+	c1, c2, i, ok := h.head(key)
+	switch {
+	case !ok:
+		panic(fmt.Sprintf("strsearch: key %q has characters that are not in alphabet", key))
+	case c2 == -1:
+		if h.short[c1] == "" {
+			h.tst.n++
+		}
+		h.short[c1] = value
+		return
+	}
+
+	r := &h.roots[int(c1)*h.radix+int(c2)]
+	if i < len(key) {
+		r.root = h.tst.put(r.root, key, value, i)
+		return
+	}
+	if r.value == "" {
+		h.tst.n++
+	}
+	r.value = value
+}
+
+// Size returns the number of keys in the trie.
+func (h *HybridTernaryTrie) Size() int {
+	return h.tst.n
+}
+
+// Contains reports whether the trie has a value for the key.
+func (h *HybridTernaryTrie) Contains(key string) bool {
+	return h.Get(key) != ""
+}
+
+// Delete removes the key from the trie, see TernaryTrie.Delete.
+func (h *HybridTernaryTrie) Delete(key string) {
+	if key == "" {
+		return
+	}
+	c1, c2, i, ok := h.head(key)
+	switch {
+	case !ok:
+		return
+	case c2 == -1:
+		if h.short[c1] != "" {
+			h.tst.n--
+			h.short[c1] = ""
+		}
+		return
+	}
+
+	r := &h.roots[int(c1)*h.radix+int(c2)]
+	if i < len(key) {
+		r.root = h.tst.delete(r.root, key, i)
+		return
+	}
+	if r.value != "" {
+		h.tst.n--
+		r.value = ""
+	}
+}
+
+// Keys returns all the keys in the trie in sorted order.
+func (h *HybridTernaryTrie) Keys() []string {
+	return h.KeysWithPrefix("")
+}
+
+// KeysWithPrefix returns the keys that start with the prefix in sorted order.
+// The TSTs of the root array are visited in order of the first two characters.
+func (h *HybridTernaryTrie) KeysWithPrefix(prefix string) []string {
+	var keys []string
+	if prefix == "" {
+		for c1 := 0; c1 < h.radix; c1++ {
+			h.collectFirst(c1, &keys)
+		}
+		return keys
+	}
+
+	c1, c2, i, ok := h.head(prefix)
+	switch {
+	case !ok:
+		return nil
+	case c2 == -1:
+		h.collectFirst(int(c1), &keys)
+		return keys
+	case i == len(prefix):
+		h.collectRoot(int(c1), int(c2), []byte(prefix), &keys)
+		return keys
+	}
+
+	r := &h.roots[int(c1)*h.radix+int(c2)]
+	n := h.tst.get(r.root, prefix, i)
+	if n == nil {
+		return nil
+	}
+	if n.value != "" {
+		keys = append(keys, prefix)
+	}
+	h.tst.collect(n.mid, []byte(prefix), &keys)
+	return keys
+}
+
+// collectFirst appends to keys all the keys that start with the character c1.
+func (h *HybridTernaryTrie) collectFirst(c1 int, keys *[]string) {
+	prefix := utf8.AppendRune(nil, h.tst.toChar(rune(c1)))
+	if h.short[c1] != "" {
+		*keys = append(*keys, string(prefix))
+	}
+	for c2 := 0; c2 < h.radix; c2++ {
+		h.collectRoot(c1, c2, utf8.AppendRune(prefix, h.tst.toChar(rune(c2))), keys)
+	}
+}
+
+// collectRoot appends to keys all the keys that start with the characters c1 and c2 (the prefix).
+func (h *HybridTernaryTrie) collectRoot(c1, c2 int, prefix []byte, keys *[]string) {
+	r := &h.roots[c1*h.radix+c2]
+	if r.value != "" {
+		*keys = append(*keys, string(prefix))
+	}
+	h.tst.collect(r.root, prefix, keys)
+}
+
+/*
+KeysThatMatch returns the keys that match the pattern in sorted order,
+where a period matches any character, e.g., ".he" matches "she" and "the".
+Only the keys of the same length (in runes) as the pattern are considered.
+*/
+func (h *HybridTernaryTrie) KeysThatMatch(pattern string) []string {
+	var keys []string
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return nil
+	}
+
+	for _, c1 := range h.candidates(p[0]) {
+		if len(p) == 1 {
+			if h.short[c1] != "" {
+				keys = append(keys, string(h.tst.toChar(rune(c1))))
+			}
+			continue
+		}
+
+		for _, c2 := range h.candidates(p[1]) {
+			r := &h.roots[c1*h.radix+c2]
+			prefix := utf8.AppendRune(utf8.AppendRune(nil, h.tst.toChar(rune(c1))), h.tst.toChar(rune(c2)))
+			if len(p) == 2 {
+				if r.value != "" {
+					keys = append(keys, string(prefix))
+				}
+				continue
+			}
+			h.tst.collectMatch(r.root, prefix, p, 2, &keys)
+		}
+	}
+	return keys
+}
+
+// candidates returns alphabet indices matching the pattern character:
+// all of them for a period, otherwise the index of the character if it's in the alphabet.
+func (h *HybridTernaryTrie) candidates(c rune) []int {
+	if c == '.' {
+		cc := make([]int, h.radix)
+		for i := range cc {
+			cc[i] = i
+		}
+		return cc
+	}
+	if i, _ := h.tst.index(string(c), 0); i != -1 {
+		return []int{int(i)}
+	}
+	return nil
+}
+
+// LongestPrefixOf returns the longest key that is a prefix of the query, see TernaryTrie.LongestPrefixOf.
+func (h *HybridTernaryTrie) LongestPrefixOf(query string) string {
+	if query == "" {
+		return ""
+	}
+
+	c1, w1 := h.tst.index(query, 0)
+	if c1 == -1 {
+		return ""
+	}
+	length := 0
+	if h.short[c1] != "" {
+		length = w1
+	}
+	if w1 == len(query) {
+		return query[:length]
+	}
+
+	c2, w2 := h.tst.index(query, w1)
+	if c2 == -1 {
+		return query[:length]
+	}
+	r := &h.roots[int(c1)*h.radix+int(c2)]
+	if r.value != "" {
+		length = w1 + w2
+	}
+	return query[:h.tst.longestPrefixOf(r.root, query, w1+w2, length)]
+}
@@ -0,0 +1,79 @@
+package strsearch
+
+/*
+LevenshteinAutomaton is a deterministic automaton that accepts the strings
+within maxEdits Levenshtein distance of the query.
+
+A state is the last row of the Levenshtein distance table computed for the characters read so far:
+row[i] is the distance between the first i characters of the query and the input.
+Reading a character computes the next row from the previous one, see Levenshtein.
+The distances greater than maxEdits are all replaced with maxEdits+1 since they can only grow,
+hence there is a finite number of states.
+
+The state is accepting if the distance to the whole query is within maxEdits.
+When all the distances in a row exceed maxEdits, the state is dead:
+no continuation of the input can be accepted. That's what makes the automaton useful
+to intersect with a trie (see Trie.KeysWithinDistance), the subtries that lead to a dead state are skipped.
+*/
+type LevenshteinAutomaton struct {
+	query    []rune
+	maxEdits int
+}
+
+// NewLevenshteinAutomaton creates an automaton that accepts the strings within maxEdits of the query.
+func NewLevenshteinAutomaton(query string, maxEdits int) *LevenshteinAutomaton {
+	return &LevenshteinAutomaton{
+		query:    []rune(query),
+		maxEdits: maxEdits,
+	}
+}
+
+// Start returns the initial state: the distances between the query prefixes and an empty input.
+func (a *LevenshteinAutomaton) Start() []int {
+	state := make([]int, len(a.query)+1)
+	for i := range state {
+		state[i] = min(i, a.maxEdits+1)
+	}
+	return state
+}
+
+// Step returns the state after reading the character c in the given state.
+func (a *LevenshteinAutomaton) Step(state []int, c rune) []int {
+	next := make([]int, len(state))
+	next[0] = min(state[0]+1, a.maxEdits+1)
+	for i := 1; i < len(state); i++ {
+		cost := 1
+		if a.query[i-1] == c {
+			cost = 0
+		}
+		next[i] = min(state[i]+1, next[i-1]+1, state[i-1]+cost, a.maxEdits+1)
+	}
+	return next
+}
+
+// IsMatch reports whether the state is accepting.
+func (a *LevenshteinAutomaton) IsMatch(state []int) bool {
+	return state[len(state)-1] <= a.maxEdits
+}
+
+// CanMatch reports whether an accepting state can be reached from the state.
+func (a *LevenshteinAutomaton) CanMatch(state []int) bool {
+	for _, d := range state {
+		if d <= a.maxEdits {
+			return true
+		}
+	}
+	return false
+}
+
+// Match reports whether s is within maxEdits of the query.
+func (a *LevenshteinAutomaton) Match(s string) bool {
+	state := a.Start()
+	for _, c := range s {
+		if !a.CanMatch(state) {
+			return false
+		}
+		state = a.Step(state, c)
+	}
+	return a.IsMatch(state)
+}
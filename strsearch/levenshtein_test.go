@@ -0,0 +1,107 @@
+package strsearch
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
+
+func TestLevenshteinAutomatonRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		q, s, maxEdits := randomWord(r, "abc", 6), randomWord(r, "abc", 8), r.Intn(4)
+		a := NewLevenshteinAutomaton(q, maxEdits)
+		want := Levenshtein(q, s) <= maxEdits
+		if got := a.Match(s); got != want {
+			t.Fatalf("NewLevenshteinAutomaton(%q, %d).Match(%q) = %t, want %t", q, maxEdits, s, got, want)
+		}
+	}
+}
+
+func TestTrie_KeysWithinDistance(t *testing.T) {
+	st := NewTrie(ASCIIRadix)
+	for i, k := range shellsKeys {
+		st.Put(k, fmt.Sprint(i))
+	}
+
+	tests := []struct {
+		query    string
+		maxEdits int
+		want     []string
+	}{
+		{"she", 0, []string{"she"}},
+		{"shes", 0, nil},
+		{"shes", 1, []string{"she"}},
+		{"shes", 2, []string{"sea", "she", "shells", "the"}},
+		{"", 2, []string{"by"}},
+		{"xyz", 2, []string{"by"}},
+		{"xyz", 1, nil},
+	}
+	for _, tc := range tests {
+		if got := st.KeysWithinDistance(tc.query, tc.maxEdits); !slices.Equal(got, tc.want) {
+			t.Errorf("KeysWithinDistance(%q, %d) = %q, want %q", tc.query, tc.maxEdits, got, tc.want)
+		}
+	}
+}
+
+func TestTrie_KeysWithinDistanceRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	st := NewTrieWithAlphabet(alphabet.DNA)
+	keys := make(map[string]bool)
+	for i := 0; i < 300; i++ {
+		k := randomWord(r, "ACGT", 7)
+		if k == "" {
+			continue
+		}
+		st.Put(k, "1")
+		keys[k] = true
+	}
+
+	for i := 0; i < 100; i++ {
+		q, maxEdits := randomWord(r, "ACGTN", 7), r.Intn(3)
+		var want []string
+		for k := range keys {
+			if Levenshtein(q, k) <= maxEdits {
+				want = append(want, k)
+			}
+		}
+		sort.Strings(want)
+
+		if got := st.KeysWithinDistance(q, maxEdits); !slices.Equal(got, want) {
+			t.Fatalf("KeysWithinDistance(%q, %d) = %q, want %q", q, maxEdits, got, want)
+		}
+	}
+}
+
+func BenchmarkKeysWithinDistance(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	st := NewTrie(ASCIIRadix)
+	var bk BKTree
+	for i := 0; i < 10000; i++ {
+		k := randomWord(r, "abcdefghijklmnopqrstuvwxyz", 10)
+		if k == "" {
+			continue
+		}
+		st.Put(k, "1")
+		bk.Add(k)
+	}
+	queries := make([]string, 100)
+	for i := range queries {
+		queries[i] = randomWord(r, "abcdefghijklmnopqrstuvwxyz", 10)
+	}
+
+	b.Run("Trie", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			st.KeysWithinDistance(queries[i%len(queries)], 2)
+		}
+	})
+	b.Run("BKTree", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			bk.Search(queries[i%len(queries)], 2)
+		}
+	})
+}
@@ -0,0 +1,143 @@
+package strsearch
+
+import (
+	"fmt"
+	"math/rand"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
+
+const (
+	// corpusSize is large enough for the compressed tries to show their advantage on long keys.
+	corpusSize = 100000
+	// trieCorpusSize is a subset of the corpus for R-way trie, because it needs 2KB per node.
+	trieCorpusSize = 2000
+)
+
+// syllables are used to generate pronounceable words.
+var syllables = []string{"al", "be", "con", "de", "er", "fi", "gor", "hu", "in", "ka", "lo", "me", "nu", "or", "pra", "qui", "ro", "sa", "ti", "ux"}
+
+// wordCorpus returns n random words made of syllables, e.g., "conerfi".
+func wordCorpus(n int) []string {
+	r := rand.New(rand.NewSource(1))
+	words := make([]string, n)
+	for i := range words {
+		var b strings.Builder
+		for j := 1 + r.Intn(4); j >= 0; j-- {
+			b.WriteString(syllables[r.Intn(len(syllables))])
+		}
+		words[i] = b.String()
+	}
+	return words
+}
+
+// urlCorpus returns n random URLs with long common prefixes, e.g.,
+// "https://www.conerfi.com/alpra/saux?page=12".
+func urlCorpus(n int) []string {
+	r := rand.New(rand.NewSource(1))
+	words := wordCorpus(100)
+	urls := make([]string, n)
+	for i := range urls {
+		var b strings.Builder
+		fmt.Fprintf(&b, "https://www.%s.com", words[r.Intn(10)])
+		for j := r.Intn(4); j >= 0; j-- {
+			b.WriteString("/")
+			b.WriteString(words[r.Intn(len(words))])
+		}
+		fmt.Fprintf(&b, "?page=%d", r.Intn(100))
+		urls[i] = b.String()
+	}
+	return urls
+}
+
+// heapInUse returns the number of bytes in use after garbage collection.
+func heapInUse() uint64 {
+	runtime.GC()
+	var m runtime.MemStats
+	runtime.ReadMemStats(&m)
+	return m.HeapAlloc
+}
+
+// benchmarkMemory reports how many bytes per key a symbol table takes.
+func benchmarkMemory(b *testing.B, keys []string, newST func() stringST) {
+	b.ReportAllocs()
+	var bytes int64
+	for i := 0; i < b.N; i++ {
+		before := heapInUse()
+		st := newST()
+		for _, k := range keys {
+			st.Put(k, k)
+		}
+		// GC might free more memory than the symbol table keeps.
+		bytes += max(int64(heapInUse())-int64(before), 0)
+		runtime.KeepAlive(st)
+	}
+	b.ReportMetric(float64(bytes)/float64(b.N)/float64(len(keys)), "B/key")
+}
+
+// benchmarkGet measures search hits.
+func benchmarkGet(b *testing.B, keys []string, newST func() stringST) {
+	st := newST()
+	for _, k := range keys {
+		st.Put(k, k)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		k := keys[i%len(keys)]
+		if st.Get(k) != k {
+			b.Fatalf("Get(%q) miss", k)
+		}
+	}
+}
+
+var symbolTables = []struct {
+	name  string
+	newST func() stringST
+	// maxKeys limits the number of corpus keys if it's not zero.
+	maxKeys int
+}{
+	{"Trie", func() stringST { return NewTrie(ASCIIRadix) }, trieCorpusSize},
+	{"TernaryTrie", func() stringST { return &TernaryTrie{} }, 0},
+	{"HybridTernaryTrie", func() stringST { return NewHybridTernaryTrie(alphabet.ExtendedASCII) }, 0},
+	{"RadixTrie", func() stringST { return &RadixTrie{} }, 0},
+	{"AdaptiveRadixTree", func() stringST { return &AdaptiveRadixTree{} }, 0},
+}
+
+// corpusKeys returns the keys of the corpus that the symbol table is benchmarked on.
+func corpusKeys(keys []string, maxKeys int) []string {
+	if maxKeys != 0 && maxKeys < len(keys) {
+		return keys[:maxKeys]
+	}
+	return keys
+}
+
+func BenchmarkMemory(b *testing.B) {
+	corpora := []struct {
+		name string
+		keys []string
+	}{
+		{"words", wordCorpus(corpusSize)},
+		{"urls", urlCorpus(corpusSize)},
+	}
+	for _, c := range corpora {
+		for _, st := range symbolTables {
+			keys := corpusKeys(c.keys, st.maxKeys)
+			b.Run(fmt.Sprintf("%s/%s/%d", c.name, st.name, len(keys)), func(b *testing.B) {
+				benchmarkMemory(b, keys, st.newST)
+			})
+		}
+	}
+}
+
+func BenchmarkGet(b *testing.B) {
+	keys := urlCorpus(corpusSize)
+	for _, st := range symbolTables {
+		keys := corpusKeys(keys, st.maxKeys)
+		b.Run(fmt.Sprintf("%s/%d", st.name, len(keys)), func(b *testing.B) {
+			benchmarkGet(b, keys, st.newST)
+		})
+	}
+}
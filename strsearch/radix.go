@@ -0,0 +1,296 @@
+package strsearch
+
+import (
+	"slices"
+	"sort"
+	"strings"
+)
+
+/*
+RadixTrie is a compressed trie also known as Patricia trie
+(practical algorithm to retrieve information coded in alphanumeric).
+It addresses external one-way branching of an R-way trie: a chain of nodes where each node
+has a single link is collapsed into one node, and the edge leading to it is labeled
+with a substring of the key rather than a single character.
+Hence every node is either a key or a branching point.
+
+The number of nodes in a radix trie built from n keys is at most 2*n regardless of the keys length.
+Children of each node are kept in a slice sorted by the first byte of their labels,
+so the space doesn't depend on the alphabet size either.
+
+A search follows the labeled edges comparing a substring of the key at a time.
+Zero value is an empty trie ready to use.
+*/
+type RadixTrie struct {
+	root radixnode
+	// n is the number of keys in the trie.
+	n int
+}
+type radixnode struct {
+	// label is a substring of the key on the edge leading to the node.
+	label string
+	// value associated with a key.
+	value string
+	// children are sorted by the first byte of their labels which are all different.
+	children []*radixnode
+}
+
+// child finds a child whose label starts with the byte c.
+// If there is no such child, it returns the index where it should be inserted.
+func (n *radixnode) child(c byte) (int, bool) {
+	i := sort.Search(len(n.children), func(i int) bool {
+		return n.children[i].label[0] >= c
+	})
+	return i, i < len(n.children) && n.children[i].label[0] == c
+}
+
+// Get searches the value associated with a given key.
+// Starting at the root, it follows the edge whose label is a prefix of the rest of the key.
+// Search miss: there is no such edge or the node where the search ends has a blank value.
+func (t *RadixTrie) Get(key string) string {
+	if key == "" {
+		return ""
+	}
+
+	n := &t.root
+	for key != "" {
+		i, ok := n.child(key[0])
+		if !ok || !strings.HasPrefix(key, n.children[i].label) {
+			return ""
+		}
+		key = key[len(n.children[i].label):]
+		n = n.children[i]
+	}
+	return n.value
+}
+
+/*
+Put inserts a key with value into a trie. It follows the labeled edges until one of the following holds:
+
+	the key is exhausted, so the node's value is updated
+	there is no edge to follow, so a new leaf labeled with the rest of the key is added
+	the key diverges in the middle of an edge label, so the edge is split in two at the point of divergence
+
+Putting an empty value deletes the key.
+*/
+func (t *RadixTrie) Put(key, value string) {
+	if key == "" {
+		return
+	}
+	if value == "" {
+		t.Delete(key)
+		return
+	}
+
+	n := &t.root
+	for key != "" {
+		i, ok := n.child(key[0])
+		if !ok {
+			n.children = slices.Insert(n.children, i, &radixnode{label: key, value: value})
+			t.n++
+			return
+		}
+
+		ch := n.children[i]
+		l := commonPrefixLen(key, ch.label)
+		if l < len(ch.label) {
+			// Split the edge, so the common part of the label leads to a new branching node.
+			mid := &radixnode{
+				label:    ch.label[:l],
+				children: []*radixnode{ch},
+			}
+			ch.label = ch.label[l:]
+			n.children[i] = mid
+			ch = mid
+		}
+		key = key[l:]
+		n = ch
+	}
+
+	if n.value == "" {
+		t.n++
+	}
+	n.value = value
+}
+
+// commonPrefixLen returns the length of the longest common prefix of a and b.
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// Size returns the number of keys in the trie.
+func (t *RadixTrie) Size() int {
+	return t.n
+}
+
+// Contains reports whether the trie has a value for the key.
+func (t *RadixTrie) Contains(key string) bool {
+	return t.Get(key) != ""
+}
+
+/*
+Delete removes the key from the trie.
+It finds the node corresponding to the key and sets its value to empty string.
+To keep the trie compressed, a node without children is removed,
+and a node with a single child is merged with that child.
+*/
+func (t *RadixTrie) Delete(key string) {
+	if key == "" {
+		return
+	}
+	t.delete(&t.root, key)
+}
+
+// delete removes the key from the subtrie rooted at n and reports whether the key was found.
+func (t *RadixTrie) delete(n *radixnode, key string) bool {
+	if key == "" {
+		if n.value == "" {
+			return false
+		}
+		n.value = ""
+		t.n--
+		return true
+	}
+
+	i, ok := n.child(key[0])
+	if !ok {
+		return false
+	}
+	ch := n.children[i]
+	if !strings.HasPrefix(key, ch.label) || !t.delete(ch, key[len(ch.label):]) {
+		return false
+	}
+
+	if ch.value == "" {
+		switch len(ch.children) {
+		case 0:
+			n.children = slices.Delete(n.children, i, i+1)
+		case 1:
+			grandchild := ch.children[0]
+			grandchild.label = ch.label + grandchild.label
+			n.children[i] = grandchild
+		}
+	}
+	return true
+}
+
+// Keys returns all the keys in the trie in sorted order.
+func (t *RadixTrie) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+/*
+KeysWithPrefix returns the keys that start with the prefix in sorted order.
+It follows the labeled edges until the prefix is exhausted, possibly in the middle of an edge,
+and collects the keys from the subtrie rooted at the node the edge leads to.
+*/
+func (t *RadixTrie) KeysWithPrefix(prefix string) []string {
+	var (
+		keys []string
+		path []byte
+		n    = &t.root
+	)
+	for prefix != "" {
+		i, ok := n.child(prefix[0])
+		if !ok {
+			return nil
+		}
+		ch := n.children[i]
+		switch {
+		case strings.HasPrefix(prefix, ch.label):
+			prefix = prefix[len(ch.label):]
+		case strings.HasPrefix(ch.label, prefix):
+			prefix = ""
+		default:
+			return nil
+		}
+		path = append(path, ch.label...)
+		n = ch
+	}
+
+	t.collect(n, path, &keys)
+	return keys
+}
+
+// collect appends to keys all the keys of the subtrie rooted at n.
+// The prefix is a string corresponding to the path from the root to n including n's label.
+func (t *RadixTrie) collect(n *radixnode, prefix []byte, keys *[]string) {
+	if n.value != "" {
+		*keys = append(*keys, string(prefix))
+	}
+	for _, ch := range n.children {
+		t.collect(ch, append(prefix, ch.label...), keys)
+	}
+}
+
+/*
+KeysThatMatch returns the keys that match the pattern in sorted order,
+where a period matches any character (byte), e.g., ".he" matches "she" and "the".
+Only the keys of the same length as the pattern are considered.
+*/
+func (t *RadixTrie) KeysThatMatch(pattern string) []string {
+	var keys []string
+	if pattern != "" {
+		t.collectMatch(&t.root, nil, pattern, &keys)
+	}
+	return keys
+}
+
+// collectMatch appends to keys all the keys of the subtrie rooted at n that match the pattern.
+// The prefix is a string corresponding to the path from the root to n including n's label.
+func (t *RadixTrie) collectMatch(n *radixnode, prefix []byte, pattern string, keys *[]string) {
+	d := len(prefix)
+	if d == len(pattern) {
+		if n.value != "" {
+			*keys = append(*keys, string(prefix))
+		}
+		return
+	}
+
+	for _, ch := range n.children {
+		if d+len(ch.label) > len(pattern) || !matchLabel(ch.label, pattern[d:]) {
+			continue
+		}
+		t.collectMatch(ch, append(prefix, ch.label...), pattern, keys)
+	}
+}
+
+// matchLabel reports whether the label matches the beginning of the pattern
+// where a period matches any byte.
+func matchLabel(label, pattern string) bool {
+	for i := 0; i < len(label); i++ {
+		if pattern[i] != '.' && pattern[i] != label[i] {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+LongestPrefixOf returns the longest key that is a prefix of the query, e.g.,
+"shell" is the longest prefix of "shellsort" given keys "she" and "shell".
+It follows the labeled edges keeping track of the last node with a value.
+*/
+func (t *RadixTrie) LongestPrefixOf(query string) string {
+	length := 0
+	n := &t.root
+	for i := 0; ; {
+		if n.value != "" {
+			length = i
+		}
+		if i == len(query) {
+			break
+		}
+		j, ok := n.child(query[i])
+		if !ok || !strings.HasPrefix(query[i:], n.children[j].label) {
+			break
+		}
+		i += len(n.children[j].label)
+		n = n.children[j]
+	}
+	return query[:length]
+}
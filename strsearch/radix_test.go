@@ -0,0 +1,29 @@
+package strsearch
+
+import "testing"
+
+func TestRadixTrie_compression(t *testing.T) {
+	rt := RadixTrie{}
+	rt.Put("romane", "1")
+	rt.Put("romanus", "2")
+	rt.Put("romulus", "3")
+
+	// r-om-(an-(e, us), ulus)
+	if len(rt.root.children) != 1 || rt.root.children[0].label != "rom" {
+		t.Fatalf("RadixTrie root edge is not compressed")
+	}
+	rom := rt.root.children[0]
+	if len(rom.children) != 2 || rom.children[0].label != "an" || rom.children[1].label != "ulus" {
+		t.Fatalf("RadixTrie rom node edges got %d children, want an and ulus", len(rom.children))
+	}
+
+	// Removing romanus leaves "an" node with a single child, so it must be merged with "e".
+	rt.Delete("romanus")
+	if rom.children[0].label != "ane" || rom.children[0].value != "1" {
+		t.Errorf("RadixTrie.Delete(romanus) got edge %q, want ane", rom.children[0].label)
+	}
+	rt.Delete("romulus")
+	if len(rt.root.children) != 1 || rt.root.children[0].label != "romane" {
+		t.Errorf("RadixTrie.Delete(romulus) got root edge %q, want romane", rt.root.children[0].label)
+	}
+}
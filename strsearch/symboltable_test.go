@@ -0,0 +1,365 @@
+package strsearch
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
+
+// stringST is a string symbol table API shared by the tries.
+type stringST interface {
+	Get(key string) string
+	Put(key, value string)
+	Delete(key string)
+	Contains(key string) bool
+	Size() int
+	Keys() []string
+	KeysWithPrefix(prefix string) []string
+	KeysThatMatch(pattern string) []string
+	LongestPrefixOf(query string) string
+}
+
+var (
+	_ stringST = (*Trie)(nil)
+	_ stringST = (*TernaryTrie)(nil)
+	_ stringST = (*RadixTrie)(nil)
+	_ stringST = (*AdaptiveRadixTree)(nil)
+	_ stringST = (*HybridTernaryTrie)(nil)
+)
+
+// shellsKeys are the keys from the book's examples.
+var shellsKeys = []string{"she", "sells", "sea", "shells", "by", "the", "sea", "shore"}
+
+// testStringST runs the test suite against a string symbol table created by newST.
+func testStringST(t *testing.T, newST func() stringST) {
+	t.Run("Size", func(t *testing.T) {
+		st := newST()
+		if got := st.Size(); got != 0 {
+			t.Errorf("Size() = %d, want 0", got)
+		}
+		for i, k := range shellsKeys {
+			st.Put(k, fmt.Sprint(i))
+		}
+		if got := st.Size(); got != 7 {
+			t.Errorf("Size() = %d, want 7", got)
+		}
+		st.Put("", "empty")
+		if got := st.Size(); got != 7 {
+			t.Errorf("Size() after Put of empty key = %d, want 7", got)
+		}
+	})
+
+	t.Run("Contains", func(t *testing.T) {
+		st := newST()
+		for i, k := range shellsKeys {
+			st.Put(k, fmt.Sprint(i))
+		}
+		tests := []struct {
+			key  string
+			want bool
+		}{
+			{"", false},
+			{"s", false},
+			{"sh", false},
+			{"she", true},
+			{"shel", false},
+			{"shells", true},
+			{"shellsort", false},
+			{"sea", true},
+			{"zzz", false},
+		}
+		for _, tc := range tests {
+			if got := st.Contains(tc.key); got != tc.want {
+				t.Errorf("Contains(%q) = %v, want %v", tc.key, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		st := newST()
+		if got := st.Keys(); len(got) != 0 {
+			t.Errorf("Keys() = %q, want none", got)
+		}
+		for i, k := range shellsKeys {
+			st.Put(k, fmt.Sprint(i))
+		}
+		want := []string{"by", "sea", "sells", "she", "shells", "shore", "the"}
+		if got := st.Keys(); !slices.Equal(got, want) {
+			t.Errorf("Keys() = %q, want %q", got, want)
+		}
+	})
+
+	t.Run("KeysWithPrefix", func(t *testing.T) {
+		st := newST()
+		for i, k := range shellsKeys {
+			st.Put(k, fmt.Sprint(i))
+		}
+		tests := []struct {
+			prefix string
+			want   []string
+		}{
+			{"", []string{"by", "sea", "sells", "she", "shells", "shore", "the"}},
+			{"s", []string{"sea", "sells", "she", "shells", "shore"}},
+			{"sh", []string{"she", "shells", "shore"}},
+			{"she", []string{"she", "shells"}},
+			{"shells", []string{"shells"}},
+			{"shellsort", nil},
+			{"x", nil},
+		}
+		for _, tc := range tests {
+			if got := st.KeysWithPrefix(tc.prefix); !slices.Equal(got, tc.want) {
+				t.Errorf("KeysWithPrefix(%q) = %q, want %q", tc.prefix, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("KeysThatMatch", func(t *testing.T) {
+		st := newST()
+		for i, k := range shellsKeys {
+			st.Put(k, fmt.Sprint(i))
+		}
+		tests := []struct {
+			pattern string
+			want    []string
+		}{
+			{"", nil},
+			{".", nil},
+			{"..", []string{"by"}},
+			{".he", []string{"she", "the"}},
+			{"s..", []string{"sea", "she"}},
+			{"sh...", []string{"shore"}},
+			{"she..s", []string{"shells"}},
+			{"......", []string{"shells"}},
+			{"....", nil},
+			{"sea", []string{"sea"}},
+		}
+		for _, tc := range tests {
+			if got := st.KeysThatMatch(tc.pattern); !slices.Equal(got, tc.want) {
+				t.Errorf("KeysThatMatch(%q) = %q, want %q", tc.pattern, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("LongestPrefixOf", func(t *testing.T) {
+		st := newST()
+		for i, k := range shellsKeys {
+			st.Put(k, fmt.Sprint(i))
+		}
+		tests := []struct {
+			query string
+			want  string
+		}{
+			{"", ""},
+			{"sh", ""},
+			{"she", "she"},
+			{"shell", "she"},
+			{"shellsort", "shells"},
+			{"seashore", "sea"},
+			{"bye", "by"},
+			{"quicksort", ""},
+		}
+		for _, tc := range tests {
+			if got := st.LongestPrefixOf(tc.query); got != tc.want {
+				t.Errorf("LongestPrefixOf(%q) = %q, want %q", tc.query, got, tc.want)
+			}
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		st := newST()
+		for i, k := range shellsKeys {
+			st.Put(k, fmt.Sprint(i))
+		}
+
+		want := []string{"by", "sea", "sells", "she", "shells", "shore", "the"}
+		for _, k := range []string{"shellsort", "sh", "shells", "by", "she", "shore", "the", "sells", "sea"} {
+			st.Delete(k)
+			if i := slices.Index(want, k); i != -1 {
+				want = slices.Delete(want, i, i+1)
+			}
+			if got := st.Keys(); !slices.Equal(got, want) {
+				t.Fatalf("Delete(%q) keys = %q, want %q", k, got, want)
+			}
+			if st.Contains(k) {
+				t.Errorf("Delete(%q) key is still found", k)
+			}
+			if got := st.Size(); got != len(want) {
+				t.Errorf("Delete(%q) size = %d, want %d", k, got, len(want))
+			}
+		}
+
+		// Trie must be usable after all the keys were removed.
+		st.Put("she", "1")
+		if got := st.Get("she"); got != "1" {
+			t.Errorf("Get(she) = %q, want 1", got)
+		}
+	})
+
+	t.Run("PutEmptyValue", func(t *testing.T) {
+		st := newST()
+		st.Put("she", "1")
+		st.Put("she", "")
+		if st.Contains("she") || st.Size() != 0 {
+			t.Errorf("Put(she, \"\") didn't delete the key")
+		}
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		st := newST()
+		want := make(map[string]string)
+		words := []string{"a", "ab", "abc", "abd", "b", "ba", "bab", "c", "ca", "cab", "cb"}
+		for i := 0; i < 1000; i++ {
+			k := words[(i*7+i/3)%len(words)]
+			if i%3 == 0 {
+				st.Delete(k)
+				delete(want, k)
+			} else {
+				st.Put(k, fmt.Sprint(i))
+				want[k] = fmt.Sprint(i)
+			}
+		}
+
+		wantKeys := make([]string, 0, len(want))
+		for k, v := range want {
+			wantKeys = append(wantKeys, k)
+			if got := st.Get(k); got != v {
+				t.Errorf("Get(%q) = %q, want %q", k, got, v)
+			}
+		}
+		sort.Strings(wantKeys)
+		if got := st.Keys(); !slices.Equal(got, wantKeys) {
+			t.Errorf("Keys() = %q, want %q", got, wantKeys)
+		}
+	})
+}
+
+func TestTrie(t *testing.T) {
+	testStringST(t, func() stringST {
+		return NewTrie(ASCIIRadix)
+	})
+}
+
+func TestTernaryTrie(t *testing.T) {
+	testStringST(t, func() stringST {
+		return &TernaryTrie{}
+	})
+}
+
+func TestTernaryTrieUnicode(t *testing.T) {
+	tst := TernaryTrie{}
+	for _, k := range []string{"жук", "жаба", "ёж", "жуки"} {
+		tst.Put(k, k)
+	}
+	if got, want := tst.KeysThatMatch("ж.к"), []string{"жук"}; !slices.Equal(got, want) {
+		t.Errorf("KeysThatMatch(ж.к) = %q, want %q", got, want)
+	}
+	if got, want := tst.KeysWithPrefix("жу"), []string{"жук", "жуки"}; !slices.Equal(got, want) {
+		t.Errorf("KeysWithPrefix(жу) = %q, want %q", got, want)
+	}
+	if got, want := tst.LongestPrefixOf("жуками"), "жук"; got != want {
+		t.Errorf("LongestPrefixOf(жуками) = %q, want %q", got, want)
+	}
+}
+
+func TestRadixTrie(t *testing.T) {
+	testStringST(t, func() stringST {
+		return &RadixTrie{}
+	})
+}
+
+func TestAdaptiveRadixTree(t *testing.T) {
+	testStringST(t, func() stringST {
+		return &AdaptiveRadixTree{}
+	})
+}
+
+func TestHybridTernaryTrie(t *testing.T) {
+	testStringST(t, func() stringST {
+		return NewHybridTernaryTrie(alphabet.ExtendedASCII)
+	})
+}
+
+func TestNewHybridTernaryTrieTooLarge(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewHybridTernaryTrie(Unicode) expected panic")
+		}
+	}()
+	NewHybridTernaryTrie(alphabet.Unicode)
+}
+
+func TestAlphabetTries(t *testing.T) {
+	tries := []struct {
+		name string
+		st   stringST
+	}{
+		{"Trie", NewTrieWithAlphabet(alphabet.DNA)},
+		{"TernaryTrie", NewTernaryTrie(alphabet.DNA)},
+		{"HybridTernaryTrie", NewHybridTernaryTrie(alphabet.DNA)},
+	}
+	keys := []string{"GATTACA", "GAT", "T", "TA", "ACGT", "CCC", "GATA"}
+	for _, tc := range tries {
+		for i, k := range keys {
+			tc.st.Put(k, fmt.Sprint(i))
+		}
+
+		// Keys are ordered by the alphabet: A < C < G < T.
+		want := []string{"ACGT", "CCC", "GAT", "GATA", "GATTACA", "T", "TA"}
+		if got := tc.st.Keys(); !slices.Equal(got, want) {
+			t.Errorf("%s.Keys() = %q, want %q", tc.name, got, want)
+		}
+		if got, want := tc.st.KeysThatMatch("GAT."), []string{"GATA"}; !slices.Equal(got, want) {
+			t.Errorf("%s.KeysThatMatch(GAT.) = %q, want %q", tc.name, got, want)
+		}
+		if got, want := tc.st.LongestPrefixOf("GATTAXA"), "GAT"; got != want {
+			t.Errorf("%s.LongestPrefixOf(GATTAXA) = %q, want %q", tc.name, got, want)
+		}
+		if got := tc.st.Get("GAXA"); got != "" {
+			t.Errorf("%s.Get(GAXA) = %q, want blank", tc.name, got)
+		}
+		tc.st.Delete("GAXA")
+		if got := tc.st.Size(); got != len(keys) {
+			t.Errorf("%s.Size() = %d, want %d", tc.name, got, len(keys))
+		}
+	}
+}
+
+func TestAlphabetTriesPanic(t *testing.T) {
+	tries := []struct {
+		name string
+		st   stringST
+	}{
+		{"Trie", NewTrieWithAlphabet(alphabet.DNA)},
+		{"TernaryTrie", NewTernaryTrie(alphabet.DNA)},
+		{"HybridTernaryTrie", NewHybridTernaryTrie(alphabet.DNA)},
+	}
+	for _, tc := range tries {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s.Put(GAXA) expected panic", tc.name)
+				}
+			}()
+			tc.st.Put("GAXA", "1")
+		}()
+	}
+}
+
+func TestTrieUnicode(t *testing.T) {
+	st := NewTrieWithAlphabet(alphabet.New("абвгдеёжзийклмнопрстуфхцчшщъыьэюя"))
+	for _, k := range []string{"жук", "жаба", "ёж", "жуки"} {
+		st.Put(k, k)
+	}
+	if got, want := st.Keys(), []string{"ёж", "жаба", "жук", "жуки"}; !slices.Equal(got, want) {
+		t.Errorf("Keys() = %q, want %q", got, want)
+	}
+	if got, want := st.KeysThatMatch("ж.к"), []string{"жук"}; !slices.Equal(got, want) {
+		t.Errorf("KeysThatMatch(ж.к) = %q, want %q", got, want)
+	}
+	if got, want := st.LongestPrefixOf("жуками"), "жук"; got != want {
+		t.Errorf("LongestPrefixOf(жуками) = %q, want %q", got, want)
+	}
+}
@@ -1,7 +1,10 @@
 package strsearch
 
 import (
+	"fmt"
 	"unicode/utf8"
+
+	"github.com/marselester/alg/alphabet"
 )
 
 /*
@@ -17,11 +20,19 @@ of average length w is between 3*n and 3*w*n.
 
 A search miss in a TST build from n random string keys on average requires ln n character compares.
 A search hit or an insertion in a TST uses ln n + L character compares, where L is the length of the search key.
+
+Zero value is a trie of Unicode keys ordered by code points,
+use NewTernaryTrie to restrict keys to an alphabet and order them accordingly.
 */
 type TernaryTrie struct {
-	root *tstnode
+	// alphabet is nil when the trie accepts any Unicode characters.
+	alphabet *alphabet.Alphabet
+	root     *tstnode
+	// n is the number of keys in the trie.
+	n int
 }
 type tstnode struct {
+	// char is the index of the character in the trie's alphabet.
 	char rune
 	// value associated with a key.
 	value string
@@ -30,6 +41,29 @@ type tstnode struct {
 	right *tstnode
 }
 
+// NewTernaryTrie creates a ternary search trie for keys made of characters of the alphabet.
+func NewTernaryTrie(a *alphabet.Alphabet) *TernaryTrie {
+	return &TernaryTrie{alphabet: a}
+}
+
+// index returns the alphabet index of the character at i-th byte of the key and the character's width.
+// The index is -1 if the character is not in the alphabet.
+func (t *TernaryTrie) index(key string, i int) (rune, int) {
+	c, width := utf8.DecodeRuneInString(key[i:])
+	if t.alphabet == nil {
+		return c, width
+	}
+	return rune(t.alphabet.ToIndex(c)), width
+}
+
+// toChar converts the alphabet index to a character.
+func (t *TernaryTrie) toChar(index rune) rune {
+	if t.alphabet == nil {
+		return index
+	}
+	return t.alphabet.ToChar(int(index))
+}
+
 /*
 Get searches the value associated with a given key.
 To search, the first character in the key is compared with the character at the root:
@@ -58,7 +92,10 @@ func (t *TernaryTrie) get(n *tstnode, key string, i int) *tstnode {
 		return nil
 	}
 
-	char, width := utf8.DecodeRuneInString(key[i:])
+	char, width := t.index(key, i)
+	if char == -1 {
+		return nil
+	}
 	switch {
 	case char < n.char:
 		return t.get(n.left, key, i)
@@ -79,13 +116,26 @@ until reaching the last character or a null link. At this point, one of the foll
 	create a node (null link found before reaching the last character of the key)
 	update node's value (found the last character of the key before reaching a null link)
 
+Putting an empty value deletes the key.
+It panics if a key character is not in the trie's alphabet.
 */
 func (t *TernaryTrie) Put(key, value string) {
+	if key == "" {
+		return
+	}
+	if value == "" {
+		t.Delete(key)
+		return
+	}
 	t.root = t.put(t.root, key, value, 0)
 }
 
 func (t *TernaryTrie) put(n *tstnode, key, value string, i int) *tstnode {
-	char, width := utf8.DecodeRuneInString(key[i:])
+	char, width := t.index(key, i)
+	if char == -1 {
+		r, _ := utf8.DecodeRuneInString(key[i:])
+		panic(fmt.Sprintf("strsearch: character %q of key %q is not in alphabet", r, key))
+	}
 
 	if n == nil {
 		n = &tstnode{char: char}
@@ -98,6 +148,9 @@ func (t *TernaryTrie) put(n *tstnode, key, value string, i int) *tstnode {
 		n.right = t.put(n.right, key, value, i)
 	case char == n.char:
 		if isLastChar := i+width == len(key); isLastChar {
+			if n.value == "" {
+				t.n++
+			}
 			n.value = value
 		} else {
 			n.mid = t.put(n.mid, key, value, i+width)
@@ -106,3 +159,193 @@ func (t *TernaryTrie) put(n *tstnode, key, value string, i int) *tstnode {
 
 	return n
 }
+
+// Size returns the number of keys in the trie.
+func (t *TernaryTrie) Size() int {
+	return t.n
+}
+
+// Contains reports whether the trie has a value for the key.
+func (t *TernaryTrie) Contains(key string) bool {
+	return t.Get(key) != ""
+}
+
+/*
+Delete removes the key from the trie.
+It finds the node corresponding to the key and sets its value to empty string.
+A node that has neither a value nor a middle link doesn't lead to any key,
+so it's replaced with its left and right subtrees the same way as in a binary search tree.
+This way the parents which became useless are removed as well.
+*/
+func (t *TernaryTrie) Delete(key string) {
+	if key == "" {
+		return
+	}
+	t.root = t.delete(t.root, key, 0)
+}
+
+// delete removes the key from the subtrie rooted at n and returns the new root of the subtrie.
+func (t *TernaryTrie) delete(n *tstnode, key string, i int) *tstnode {
+	if n == nil {
+		return nil
+	}
+
+	char, width := t.index(key, i)
+	switch {
+	case char == -1:
+		return n
+	case char < n.char:
+		n.left = t.delete(n.left, key, i)
+	case char > n.char:
+		n.right = t.delete(n.right, key, i)
+	default:
+		if isLastChar := i+width == len(key); isLastChar {
+			if n.value != "" {
+				t.n--
+				n.value = ""
+			}
+		} else {
+			n.mid = t.delete(n.mid, key, i+width)
+		}
+	}
+
+	if n.value != "" || n.mid != nil {
+		return n
+	}
+	return joinTST(n.left, n.right)
+}
+
+// joinTST merges two subtries where all characters of the left one are less than in the right one.
+// The right subtrie becomes the right link of the max node of the left subtrie.
+func joinTST(left, right *tstnode) *tstnode {
+	if left == nil {
+		return right
+	}
+	max := left
+	for max.right != nil {
+		max = max.right
+	}
+	max.right = right
+	return left
+}
+
+// Keys returns all the keys in the trie in sorted order.
+func (t *TernaryTrie) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+/*
+KeysWithPrefix returns the keys that start with the prefix in sorted order.
+It finds the node corresponding to the last character of the prefix
+and collects the keys from its middle subtrie.
+An in-order traversal (left, middle, right) visits the keys in sorted order.
+*/
+func (t *TernaryTrie) KeysWithPrefix(prefix string) []string {
+	var keys []string
+	if prefix == "" {
+		t.collect(t.root, nil, &keys)
+		return keys
+	}
+
+	n := t.get(t.root, prefix, 0)
+	if n == nil {
+		return nil
+	}
+	if n.value != "" {
+		keys = append(keys, prefix)
+	}
+	t.collect(n.mid, []byte(prefix), &keys)
+	return keys
+}
+
+// collect appends to keys all the keys of the subtrie rooted at n.
+// The prefix is a string corresponding to the path from the root to n.
+func (t *TernaryTrie) collect(n *tstnode, prefix []byte, keys *[]string) {
+	if n == nil {
+		return
+	}
+
+	t.collect(n.left, prefix, keys)
+	p := utf8.AppendRune(prefix, t.toChar(n.char))
+	if n.value != "" {
+		*keys = append(*keys, string(p))
+	}
+	t.collect(n.mid, p, keys)
+	t.collect(n.right, prefix, keys)
+}
+
+/*
+KeysThatMatch returns the keys that match the pattern in sorted order,
+where a period matches any character, e.g., ".he" matches "she" and "the".
+Only the keys of the same length (in runes) as the pattern are considered.
+*/
+func (t *TernaryTrie) KeysThatMatch(pattern string) []string {
+	var keys []string
+	if pattern != "" {
+		t.collectMatch(t.root, nil, []rune(pattern), 0, &keys)
+	}
+	return keys
+}
+
+// collectMatch appends to keys all the keys of the subtrie rooted at n that match the pattern
+// starting from its d-th character.
+func (t *TernaryTrie) collectMatch(n *tstnode, prefix []byte, pattern []rune, d int, keys *[]string) {
+	if n == nil {
+		return
+	}
+
+	c, wildcard := pattern[d], pattern[d] == '.'
+	if !wildcard && t.alphabet != nil {
+		if c = rune(t.alphabet.ToIndex(c)); c == -1 {
+			return
+		}
+	}
+	if wildcard || c < n.char {
+		t.collectMatch(n.left, prefix, pattern, d, keys)
+	}
+	if wildcard || c == n.char {
+		p := utf8.AppendRune(prefix, t.toChar(n.char))
+		if isLastChar := d == len(pattern)-1; isLastChar {
+			if n.value != "" {
+				*keys = append(*keys, string(p))
+			}
+		} else {
+			t.collectMatch(n.mid, p, pattern, d+1, keys)
+		}
+	}
+	if wildcard || c > n.char {
+		t.collectMatch(n.right, prefix, pattern, d, keys)
+	}
+}
+
+/*
+LongestPrefixOf returns the longest key that is a prefix of the query, e.g.,
+"shell" is the longest prefix of "shellsort" given keys "she" and "shell".
+It searches for the query keeping track of the longest key found on the way.
+*/
+func (t *TernaryTrie) LongestPrefixOf(query string) string {
+	return query[:t.longestPrefixOf(t.root, query, 0, 0)]
+}
+
+// longestPrefixOf searches the query starting at i-th byte in the subtrie rooted at n
+// and returns the length of the longest key found, or the given length if there is none.
+func (t *TernaryTrie) longestPrefixOf(n *tstnode, query string, i, length int) int {
+	for n != nil && i < len(query) {
+		char, width := t.index(query, i)
+		switch {
+		case char == -1:
+			n = nil
+		case char < n.char:
+			n = n.left
+		case char > n.char:
+			n = n.right
+		default:
+			i += width
+			if n.value != "" {
+				length = i
+			}
+			n = n.mid
+		}
+	}
+	return length
+}
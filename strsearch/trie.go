@@ -1,5 +1,12 @@
 package strsearch
 
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/marselester/alg/alphabet"
+)
+
 // ASCIIRadix is extended ASCII alphabet size (number of characters).
 const ASCIIRadix = 256
 
@@ -31,17 +38,30 @@ because it will require space proportional to R times the total number of key ch
 
 The primary reason trie space is excessive for long keys because they tend to have long tails in the trie,
 with each node having a single link to the next node (situation known as external one-way branching).
+
+Keys of a trie created with NewTrie are byte strings where each byte is a character,
+so they don't have to be valid UTF-8. Keys of a trie created with NewTrieWithAlphabet
+are sequences of characters (runes) of the trie's alphabet.
 */
 type Trie struct {
 	radix int
-	root  *node
+	// alphabet is nil when a character is a byte value.
+	alphabet *alphabet.Alphabet
+	root     *node
+	// n is the number of keys in the trie.
+	n int
 }
 type node struct {
 	value string
 	next  []*node
 }
 
-// NewTrie creates an R-way trie (trie for an R-character alphabet).
+// maxTrieRadix is the max alphabet size of an R-way trie.
+// Each node of a larger alphabet would take over half a megabyte.
+const maxTrieRadix = 1 << 16
+
+// NewTrie creates an R-way trie (trie for an R-character alphabet)
+// whose keys consist of bytes less than R, e.g., ASCIIRadix.
 func NewTrie(radix int) *Trie {
 	return &Trie{
 		radix: radix,
@@ -51,6 +71,86 @@ func NewTrie(radix int) *Trie {
 	}
 }
 
+// NewTrieWithAlphabet creates a trie for keys made of characters of the alphabet,
+// e.g., alphabet.DNA trie nodes have only 4 links.
+// Each node has R links, hence it panics if the alphabet has more than 65536 characters,
+// e.g., alphabet.Unicode (use TernaryTrie instead).
+func NewTrieWithAlphabet(a *alphabet.Alphabet) *Trie {
+	if a.Radix() > maxTrieRadix {
+		panic(fmt.Sprintf("strsearch: alphabet of %d characters is too large for R-way trie", a.Radix()))
+	}
+	return &Trie{
+		radix:    a.Radix(),
+		alphabet: a,
+		root: &node{
+			next: make([]*node, a.Radix()),
+		},
+	}
+}
+
+// index returns the alphabet index of the character at i-th byte of the key and the character's width.
+// The index is -1 if the character is not in the alphabet.
+// The character is the byte itself if the trie has no alphabet.
+func (t *Trie) index(key string, i int) (int, int) {
+	if t.alphabet == nil {
+		return t.toIndex(rune(key[i])), 1
+	}
+	c, width := utf8.DecodeRuneInString(key[i:])
+	return t.alphabet.ToIndex(c), width
+}
+
+// toIndex converts the character to its index, or -1 if the character is not in the alphabet.
+func (t *Trie) toIndex(c rune) int {
+	if t.alphabet == nil {
+		if c < 0 || int(c) >= t.radix {
+			return -1
+		}
+		return int(c)
+	}
+	return t.alphabet.ToIndex(c)
+}
+
+// chars splits s into characters: bytes if the trie has no alphabet, runes otherwise.
+func (t *Trie) chars(s string) []rune {
+	if t.alphabet != nil {
+		return []rune(s)
+	}
+	chars := make([]rune, len(s))
+	for i := 0; i < len(s); i++ {
+		chars[i] = rune(s[i])
+	}
+	return chars
+}
+
+// toChar converts the index to a character: a byte value if the trie has no alphabet.
+func (t *Trie) toChar(index int) rune {
+	if t.alphabet == nil {
+		return rune(index)
+	}
+	return t.alphabet.ToChar(index)
+}
+
+// appendChar appends the character with the given index to the key.
+func (t *Trie) appendChar(key []byte, index int) []byte {
+	if t.alphabet == nil {
+		return append(key, byte(index))
+	}
+	return utf8.AppendRune(key, t.alphabet.ToChar(index))
+}
+
+// mustIndex is like index, but it panics if the character is not in the alphabet.
+func (t *Trie) mustIndex(key string, i int) (int, int) {
+	c, width := t.index(key, i)
+	if c == -1 {
+		if t.alphabet == nil {
+			panic(fmt.Sprintf("strsearch: byte %#x of key %q is out of radix %d", key[i], key, t.radix))
+		}
+		r, _ := utf8.DecodeRuneInString(key[i:])
+		panic(fmt.Sprintf("strsearch: character %q of key %q is not in alphabet", r, key))
+	}
+	return c, width
+}
+
 /*
 Get searches the value associated with a given key.
 Each node in the trie has a link corresponding to each possible string character.
@@ -85,8 +185,11 @@ func (t *Trie) get(n *node, key string, i int) *node {
 		return n
 	}
 
-	c := key[i]
-	return t.get(n.next[c], key, i+1)
+	c, width := t.index(key, i)
+	if c == -1 {
+		return nil
+	}
+	return t.get(n.next[c], key, i+width)
 }
 
 /*
@@ -96,15 +199,22 @@ until reaching the last character or a null link. At this point, one of the foll
 	create a node (null link found before reaching the last character of the key)
 	update node's value (found the last character of the key before reaching a null link)
 
+Putting an empty value deletes the key.
+It panics if a key character is not in the trie's alphabet.
 */
 func (t *Trie) Put(key, value string) {
 	if key == "" {
 		return
 	}
+	if value == "" {
+		t.Delete(key)
+		return
+	}
 
 	n := t.root
-	for i := 0; i < len(key); i++ {
-		c := key[i]
+	for i := 0; i < len(key); {
+		c, width := t.mustIndex(key, i)
+		i += width
 		if n.next[c] == nil {
 			n.next[c] = &node{
 				next: make([]*node, t.radix),
@@ -112,5 +222,190 @@ func (t *Trie) Put(key, value string) {
 		}
 		n = n.next[c]
 	}
+	if n.value == "" {
+		t.n++
+	}
 	n.value = value
 }
+
+// Size returns the number of keys in the trie.
+func (t *Trie) Size() int {
+	return t.n
+}
+
+// Contains reports whether the trie has a value for the key.
+func (t *Trie) Contains(key string) bool {
+	return t.Get(key) != ""
+}
+
+/*
+Delete removes the key from the trie.
+It finds the node corresponding to the key and sets its value to empty string.
+If that node has all null links, it's removed from the trie, and so are its parents
+which became useless: they have neither a value nor non-null links.
+*/
+func (t *Trie) Delete(key string) {
+	if key == "" {
+		return
+	}
+	t.delete(t.root, key, 0)
+}
+
+// delete removes the key from the subtrie rooted at n
+// and returns nil if n is no longer needed.
+func (t *Trie) delete(n *node, key string, i int) *node {
+	if n == nil {
+		return nil
+	}
+
+	if isLastChar := i == len(key); isLastChar {
+		if n.value != "" {
+			t.n--
+			n.value = ""
+		}
+	} else {
+		c, width := t.index(key, i)
+		if c == -1 {
+			return n
+		}
+		n.next[c] = t.delete(n.next[c], key, i+width)
+	}
+
+	if n.value != "" || n == t.root {
+		return n
+	}
+	for _, next := range n.next {
+		if next != nil {
+			return n
+		}
+	}
+	return nil
+}
+
+// Keys returns all the keys in the trie in sorted order.
+func (t *Trie) Keys() []string {
+	return t.KeysWithPrefix("")
+}
+
+/*
+KeysWithPrefix returns the keys that start with the prefix in sorted order.
+It finds the node corresponding to the prefix and collects the keys
+from the subtrie rooted at that node. Links are visited in order of characters,
+so the keys are naturally sorted.
+*/
+func (t *Trie) KeysWithPrefix(prefix string) []string {
+	var keys []string
+	t.collect(t.get(t.root, prefix, 0), []byte(prefix), &keys)
+	return keys
+}
+
+// collect appends to keys all the keys of the subtrie rooted at n.
+// The prefix is a string corresponding to the path from the root to n.
+func (t *Trie) collect(n *node, prefix []byte, keys *[]string) {
+	if n == nil {
+		return
+	}
+	if n.value != "" {
+		*keys = append(*keys, string(prefix))
+	}
+	for c, next := range n.next {
+		if next != nil {
+			t.collect(next, t.appendChar(prefix, c), keys)
+		}
+	}
+}
+
+/*
+KeysThatMatch returns the keys that match the pattern in sorted order,
+where a period matches any character, e.g., ".he" matches "she" and "the".
+Only the keys of the same length (in characters) as the pattern are considered.
+*/
+func (t *Trie) KeysThatMatch(pattern string) []string {
+	var keys []string
+	if pattern != "" {
+		t.collectMatch(t.root, nil, t.chars(pattern), 0, &keys)
+	}
+	return keys
+}
+
+// collectMatch appends to keys all the keys of the subtrie rooted at n that match the pattern
+// starting from its d-th character.
+func (t *Trie) collectMatch(n *node, prefix []byte, pattern []rune, d int, keys *[]string) {
+	if n == nil {
+		return
+	}
+
+	if d == len(pattern) {
+		if n.value != "" {
+			*keys = append(*keys, string(prefix))
+		}
+		return
+	}
+
+	if r := pattern[d]; r != '.' {
+		if c := t.toIndex(r); c != -1 {
+			t.collectMatch(n.next[c], t.appendChar(prefix, c), pattern, d+1, keys)
+		}
+		return
+	}
+	for c, next := range n.next {
+		if next != nil {
+			t.collectMatch(next, t.appendChar(prefix, c), pattern, d+1, keys)
+		}
+	}
+}
+
+/*
+KeysWithinDistance returns the keys within maxEdits Levenshtein distance of the query in sorted order,
+e.g., "sea", "she", "shells", and "the" are within 2 edits of "shes".
+The trie is intersected with LevenshteinAutomaton: the automaton runs along each path from the root,
+and the subtries where it reaches a dead state are skipped.
+Like the keys, the edits are counted in bytes if the trie was created with NewTrie.
+*/
+func (t *Trie) KeysWithinDistance(query string, maxEdits int) []string {
+	var keys []string
+	a := &LevenshteinAutomaton{query: t.chars(query), maxEdits: maxEdits}
+	t.collectWithin(t.root, nil, a, a.Start(), &keys)
+	return keys
+}
+
+// collectWithin appends to keys all the keys of the subtrie rooted at n accepted by the automaton.
+// The state is the automaton's state after reading the prefix (the path from the root to n).
+func (t *Trie) collectWithin(n *node, prefix []byte, a *LevenshteinAutomaton, state []int, keys *[]string) {
+	if n.value != "" && a.IsMatch(state) {
+		*keys = append(*keys, string(prefix))
+	}
+	for c, next := range n.next {
+		if next == nil {
+			continue
+		}
+		if s := a.Step(state, t.toChar(c)); a.CanMatch(s) {
+			t.collectWithin(next, t.appendChar(prefix, c), a, s, keys)
+		}
+	}
+}
+
+/*
+LongestPrefixOf returns the longest key that is a prefix of the query, e.g.,
+"shell" is the longest prefix of "shellsort" given keys "she" and "shell".
+It follows the query characters down the trie keeping track of the last node with a value.
+*/
+func (t *Trie) LongestPrefixOf(query string) string {
+	length := 0
+	n := t.root
+	for i := 0; n != nil; {
+		if n.value != "" {
+			length = i
+		}
+		if i == len(query) {
+			break
+		}
+		c, width := t.index(query, i)
+		if c == -1 {
+			break
+		}
+		n = n.next[c]
+		i += width
+	}
+	return query[:length]
+}
@@ -1,6 +1,12 @@
 package strsearch
 
-import "testing"
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
 
 func TestTrie_Get(t *testing.T) {
 	st := NewTrie(ASCIIRadix)
@@ -65,3 +71,62 @@ func TestTrie_Put(t *testing.T) {
 		t.Errorf("Trie.Put(ab, bazz) 'ab' value is %q, want %q", st.root.next['a'].next['b'].value, want)
 	}
 }
+
+func TestTrie_ByteKeys(t *testing.T) {
+	st := NewTrie(ASCIIRadix)
+	// Keys are byte strings: invalid UTF-8 and multi-byte runes are accepted byte by byte.
+	keys := []string{"caf\xff", "café", "日本", "日本語", "\x00\x80"}
+	for i, k := range keys {
+		st.Put(k, fmt.Sprint(i))
+	}
+	for i, k := range keys {
+		if got, want := st.Get(k), fmt.Sprint(i); got != want {
+			t.Errorf("Get(%q) = %q, want %q", k, got, want)
+		}
+	}
+
+	want := []string{"\x00\x80", "caf\xc3\xa9", "caf\xff", "日本", "日本語"}
+	if got := st.Keys(); !slices.Equal(got, want) {
+		t.Errorf("Keys() = %q, want %q", got, want)
+	}
+	if got := st.KeysWithPrefix("日"); !slices.Equal(got, []string{"日本", "日本語"}) {
+		t.Errorf("KeysWithPrefix(日) = %q, want [日本 日本語]", got)
+	}
+	// A period matches any byte, so "caf." matches only the 4-byte keys.
+	if got := st.KeysThatMatch("caf."); !slices.Equal(got, []string{"caf\xff"}) {
+		t.Errorf("KeysThatMatch(caf.) = %q, want [caf\\xff]", got)
+	}
+	if got := st.LongestPrefixOf("日本語です"); got != "日本語" {
+		t.Errorf("LongestPrefixOf(日本語です) = %q, want 日本語", got)
+	}
+	if got := st.KeysWithinDistance("caf", 1); !slices.Equal(got, []string{"caf\xff"}) {
+		t.Errorf("KeysWithinDistance(caf, 1) = %q, want [caf\\xff]", got)
+	}
+
+	st.Delete("caf\xff")
+	if st.Contains("caf\xff") || st.Size() != 4 {
+		t.Errorf("Delete(caf\\xff) didn't remove the key")
+	}
+}
+
+func TestTrie_ByteOutOfRadix(t *testing.T) {
+	st := NewTrie(128)
+	if got := st.Get("caf\xff"); got != "" {
+		t.Errorf("Get(caf\\xff) = %q, want empty", got)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Errorf("Put(caf\\xff) expected panic")
+		}
+	}()
+	st.Put("caf\xff", "v")
+}
+
+func TestNewTrieWithAlphabetTooLarge(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("NewTrieWithAlphabet(Unicode) expected panic")
+		}
+	}()
+	NewTrieWithAlphabet(alphabet.Unicode)
+}
@@ -0,0 +1,79 @@
+package strsort
+
+// flagTask is a subarray from a[lo] to a[hi] to sort on the column by American flag sort.
+type flagTask struct {
+	lo, hi, column int
+}
+
+/*
+AmericanFlag sorts a slice of strings using American flag sort: in-place MSD string sort.
+It takes the same options as MSD, but it's not stable.
+
+Like MSD, it counts the frequencies of the first characters to find where each subarray (bucket) starts.
+Instead of distributing the strings to the auxiliary array, it permutes them in place:
+a string is swapped into the next free position of its bucket, then the string that was there
+goes to its bucket, and so forth until a string belonging to the current position is found.
+Each string is moved at most once per pass.
+
+The recursion is replaced with an explicit stack of the subarrays,
+so the count arrays are allocated once, not at every recursive call.
+The extra space is proportional to R plus the stack size which is up to R times the length of the longest string
+(the subarrays are pushed before they're sorted).
+*/
+func AmericanFlag(a []string, options ...msdOption) {
+	s := msdSorter[string, string]{
+		msd: newMSD(options),
+		key: identity,
+	}
+	s.americanFlag(a)
+}
+
+func (s *msdSorter[T, K]) americanFlag(a []T) {
+	// count[c+1] is the number of keys whose column character is c, where c is -1 at the end of a key.
+	// Then it's transformed into the end of the bucket.
+	count := make([]int, s.radix+1)
+	// next[c+1] is the next position to fill in the bucket of c.
+	next := make([]int, s.radix+1)
+
+	stack := []flagTask{{0, len(a) - 1, 0}}
+	for len(stack) > 0 {
+		t := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		lo, hi, column := t.lo, t.hi, t.column
+		if hi <= lo+s.cutoff {
+			s.insertionSort(a, lo, hi, column)
+			continue
+		}
+
+		clear(count)
+		for i := lo; i <= hi; i++ {
+			count[s.charAt(s.key(a[i]), column)+1]++
+		}
+
+		// Compute the bucket boundaries and push the buckets except the one of the keys that ended.
+		start := lo
+		for c := range count {
+			next[c] = start
+			start += count[c]
+			if c > 0 && count[c] > 1 {
+				stack = append(stack, flagTask{next[c], start - 1, column + 1})
+			}
+			count[c] = start
+		}
+
+		// Permute the keys into their buckets.
+		for c := range count {
+			for next[c] < count[c] {
+				v := a[next[c]]
+				k := s.charAt(s.key(v), column) + 1
+				for k != c {
+					a[next[k]], v = v, a[next[k]]
+					next[k]++
+					k = s.charAt(s.key(v), column) + 1
+				}
+				a[next[c]] = v
+				next[c]++
+			}
+		}
+	}
+}
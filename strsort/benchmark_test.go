@@ -0,0 +1,198 @@
+package strsort
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// randomStrings returns n random lowercase strings of length up to w.
+func randomStrings(r *rand.Rand, n, w int) []string {
+	a := make([]string, n)
+	var b strings.Builder
+	for i := range a {
+		b.Reset()
+		for j := r.Intn(w + 1); j > 0; j-- {
+			b.WriteByte(byte('a' + r.Intn(26)))
+		}
+		a[i] = b.String()
+	}
+	return a
+}
+
+func benchmarkStrings(b *testing.B, sortFn func(a []string)) {
+	src := randomStrings(rand.New(rand.NewSource(1)), 100000, 20)
+	a := make([]string, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(a, src)
+		sortFn(a)
+	}
+}
+
+func BenchmarkSortStrings(b *testing.B) {
+	benchmarkStrings(b, sort.Strings)
+}
+
+func BenchmarkMSD(b *testing.B) {
+	benchmarkStrings(b, func(a []string) { MSD(a) })
+}
+
+// BenchmarkMSDMemory compares memory allocated by MSD with different cutoffs,
+// American flag sort, and parallel MSD.
+func BenchmarkMSDMemory(b *testing.B) {
+	for _, cutoff := range []int{0, DefaultCutoff, 50} {
+		b.Run(fmt.Sprintf("MSD cutoff %d", cutoff), func(b *testing.B) {
+			b.ReportAllocs()
+			benchmarkStrings(b, func(a []string) { MSD(a, WithCutoff(cutoff)) })
+		})
+		b.Run(fmt.Sprintf("AmericanFlag cutoff %d", cutoff), func(b *testing.B) {
+			b.ReportAllocs()
+			benchmarkStrings(b, func(a []string) { AmericanFlag(a, WithCutoff(cutoff)) })
+		})
+	}
+	b.Run("ParallelMSD", func(b *testing.B) {
+		b.ReportAllocs()
+		benchmarkStrings(b, func(a []string) { ParallelMSD(a) })
+	})
+}
+
+func BenchmarkQuick(b *testing.B) {
+	benchmarkStrings(b, Quick)
+}
+
+// user is a record sorted by name in benchmarks.
+type user struct {
+	name string
+	age  int
+}
+
+func benchmarkUsers(b *testing.B, sortFn func(a []user)) {
+	r := rand.New(rand.NewSource(1))
+	src := make([]user, 100000)
+	for i, name := range randomStrings(r, len(src), 20) {
+		src[i] = user{name: name, age: r.Intn(100)}
+	}
+	a := make([]user, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(a, src)
+		sortFn(a)
+	}
+}
+
+func BenchmarkUsersSortSlice(b *testing.B) {
+	benchmarkUsers(b, func(a []user) {
+		sort.Slice(a, func(i, j int) bool { return a[i].name < a[j].name })
+	})
+}
+
+func BenchmarkUsersMSDFunc(b *testing.B) {
+	benchmarkUsers(b, func(a []user) {
+		MSDFunc(a, func(u user) string { return u.name })
+	})
+}
+
+func BenchmarkUsersQuickFunc(b *testing.B) {
+	benchmarkUsers(b, func(a []user) {
+		QuickFunc(a, func(u user) string { return u.name })
+	})
+}
+
+func BenchmarkUsersByAgeSortSlice(b *testing.B) {
+	benchmarkUsers(b, func(a []user) {
+		sort.SliceStable(a, func(i, j int) bool { return a[i].age < a[j].age })
+	})
+}
+
+func BenchmarkUsersByAgeLSDIntsFunc(b *testing.B) {
+	benchmarkUsers(b, func(a []user) {
+		LSDIntsFunc(a, func(u user) int { return u.age })
+	})
+}
+
+func BenchmarkUsersByAgeKeyIndexedCountingFunc(b *testing.B) {
+	benchmarkUsers(b, func(a []user) {
+		KeyIndexedCountingFunc(a, func(u user) int { return u.age }, 100)
+	})
+}
+
+func benchmarkNumbers[T any](b *testing.B, gen func(r *rand.Rand) T, sortFn func(a []T)) {
+	r := rand.New(rand.NewSource(1))
+	src := make([]T, 1000000)
+	for i := range src {
+		src[i] = gen(r)
+	}
+	a := make([]T, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(a, src)
+		sortFn(a)
+	}
+}
+
+func randomInt64(r *rand.Rand) int64 { return int64(r.Uint64()) }
+
+func randomFloat64(r *rand.Rand) float64 { return r.NormFloat64() }
+
+func BenchmarkInt64SortSlice(b *testing.B) {
+	benchmarkNumbers(b, randomInt64, func(a []int64) {
+		sort.Slice(a, func(i, j int) bool { return a[i] < a[j] })
+	})
+}
+
+func BenchmarkInt64LSDInts(b *testing.B) {
+	benchmarkNumbers(b, randomInt64, LSDInts[int64])
+}
+
+func BenchmarkFloat64SortSlice(b *testing.B) {
+	benchmarkNumbers(b, randomFloat64, func(a []float64) {
+		sort.Slice(a, func(i, j int) bool { return a[i] < a[j] })
+	})
+}
+
+func BenchmarkFloat64LSDFloats(b *testing.B) {
+	benchmarkNumbers(b, randomFloat64, LSDFloats[float64])
+}
+
+// randomProductNames returns n random names made of Latin, Cyrillic, and accented letters.
+func randomProductNames(r *rand.Rand, n int) []string {
+	chars := []rune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZäöüéèßабвгдеёжзий ")
+	a := make([]string, n)
+	for i := range a {
+		name := make([]rune, 1+r.Intn(20))
+		for j := range name {
+			name[j] = chars[r.Intn(len(chars))]
+		}
+		a[i] = string(name)
+	}
+	return a
+}
+
+func benchmarkProductNames(b *testing.B, sortFn func(a []string)) {
+	src := randomProductNames(rand.New(rand.NewSource(1)), 100000)
+	a := make([]string, len(src))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(a, src)
+		sortFn(a)
+	}
+}
+
+func BenchmarkProductNamesSortStrings(b *testing.B) {
+	benchmarkProductNames(b, sort.Strings)
+}
+
+func BenchmarkProductNamesMSDRunes(b *testing.B) {
+	benchmarkProductNames(b, func(a []string) { MSDRunes(a, WithCaseFolding()) })
+}
+
+func BenchmarkProductNamesQuickRunes(b *testing.B) {
+	benchmarkProductNames(b, func(a []string) { QuickRunes(a, WithCaseFolding()) })
+}
+
+func BenchmarkProductNamesCollate(b *testing.B) {
+	benchmarkProductNames(b, Collate)
+}
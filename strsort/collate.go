@@ -0,0 +1,109 @@
+package strsort
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// baseLetters maps the lowercase Latin letters with diacritics to their base letters.
+var baseLetters = make(map[rune]rune)
+
+// expansions maps the lowercase ligatures to the letters they're sorted as.
+var expansions = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ĳ': "ij",
+	'þ': "th",
+}
+
+func init() {
+	for base, letters := range map[rune]string{
+		'a': "àáâãäåāăą",
+		'c': "çćĉċč",
+		'd': "ďđð",
+		'e': "èéêëēĕėęě",
+		'g': "ĝğġģ",
+		'h': "ĥħ",
+		'i': "ìíîïĩīĭįı",
+		'j': "ĵ",
+		'k': "ķ",
+		'l': "ĺļľŀł",
+		'n': "ñńņňŉ",
+		'o': "òóôõöøōŏő",
+		'r': "ŕŗř",
+		's': "śŝşšș",
+		't': "ţťŧț",
+		'u': "ùúûüũūŭůűų",
+		'w': "ŵ",
+		'y': "ýÿŷ",
+		'z': "źżž",
+	} {
+		for _, c := range letters {
+			baseLetters[c] = base
+		}
+	}
+}
+
+/*
+CollationKey returns a sort key of the string, so that comparing keys byte by byte
+orders the strings closer to a dictionary than comparing their code points, e.g.,
+"Apple" < "apple" < "Äpfel" by code points becomes "Äpfel" < "apple" < "Apple".
+It's a simplified version of Unicode Collation Algorithm that compares strings on three levels:
+
+	primary: the base letters, i.e., the case is folded and Latin diacritics are removed, e.g., "Ä" is "a", "ß" is "ss"
+	secondary: the diacritics, i.e., the case is folded, e.g., "eclair" < "éclair"
+	tertiary: the case where lowercase goes first, e.g., "eclair" < "Eclair"
+
+The key is the primary weights (UTF-8 encoded base letters) followed by zero byte,
+then the secondary weights (UTF-8 encoded folded characters) followed by zero byte,
+and the tertiary weights (one byte per character: 0 lowercase or uncased, 1 uppercase).
+Note, the letters of other scripts are ordered by code points.
+*/
+func CollationKey(s string) string {
+	primary := make([]byte, 0, len(s)+1)
+	secondary := make([]byte, 0, len(s)+1)
+	var tertiary []byte
+	for _, c := range s {
+		folded := foldRune(c)
+		if exp, ok := expansions[folded]; ok {
+			primary = append(primary, exp...)
+		} else if base, ok := baseLetters[folded]; ok {
+			primary = utf8.AppendRune(primary, base)
+		} else {
+			primary = utf8.AppendRune(primary, folded)
+		}
+
+		secondary = utf8.AppendRune(secondary, folded)
+
+		if unicode.IsUpper(c) {
+			tertiary = append(tertiary, 1)
+		} else {
+			tertiary = append(tertiary, 0)
+		}
+	}
+
+	primary = append(primary, 0)
+	primary = append(primary, secondary...)
+	primary = append(primary, 0)
+	return string(append(primary, tertiary...))
+}
+
+// Collate sorts the strings by their collation keys (see CollationKey) using MSD sort,
+// equal strings keep their input order.
+// The keys are computed once which takes extra space proportional to the total length of the strings.
+func Collate(a []string) {
+	type collated struct {
+		key string
+		s   string
+	}
+	c := make([]collated, len(a))
+	for i, s := range a {
+		c[i] = collated{key: CollationKey(s), s: s}
+	}
+
+	MSDFunc(c, func(v collated) string { return v.key })
+	for i := range c {
+		a[i] = c[i].s
+	}
+}
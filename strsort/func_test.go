@@ -0,0 +1,106 @@
+package strsort
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
+
+// record is a record to sort by a string key and check the sort's stability.
+type record struct {
+	key []byte
+	seq int
+}
+
+// funcSorts are the sorts of records by a byte slice key.
+var funcSorts = []struct {
+	name   string
+	sort   func(a []record)
+	stable bool
+}{
+	{"MSDFunc", func(a []record) { MSDFunc(a, func(r record) []byte { return r.key }) }, true},
+	{"MSDFunc no cutoff", func(a []record) { MSDFunc(a, func(r record) []byte { return r.key }, WithCutoff(0)) }, true},
+	{"QuickFunc", func(a []record) { QuickFunc(a, func(r record) []byte { return r.key }) }, false},
+}
+
+func TestFuncSorts(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, s := range funcSorts {
+		for _, n := range []int{0, 1, 10, 1000} {
+			a := make([]record, n)
+			for i, k := range randomStrings(r, n, 4) {
+				a[i] = record{key: []byte(strings.ToUpper(k)), seq: i}
+			}
+			want := slices.Clone(a)
+			sort.SliceStable(want, func(i, j int) bool { return string(want[i].key) < string(want[j].key) })
+
+			s.sort(a)
+			for i := range a {
+				if string(a[i].key) != string(want[i].key) || (s.stable && a[i].seq != want[i].seq) {
+					t.Fatalf("%s(n=%d) got %v at %d, want %v", s.name, n, a[i], i, want[i])
+				}
+			}
+		}
+	}
+}
+
+func TestLSDFunc(t *testing.T) {
+	students := []student{
+		{"Harris", 1},
+		{"Brown", 3},
+		{"Davis", 3},
+		{"Moore", 1},
+		{"Miller", 2},
+	}
+	want := []student{
+		{"Brown", 3},
+		{"Davis", 3},
+		{"Harris", 1},
+		{"Miller", 2},
+		{"Moore", 1},
+	}
+	// The names are sorted by the first two letters which keeps the order of Miller and Moore.
+	LSDFunc(students, func(s student) string { return s.name[:2] }, 256)
+	if !slices.Equal(students, want) {
+		t.Errorf("LSDFunc() = %v, want %v", students, want)
+	}
+}
+
+func TestKeyIndexedCountingFunc(t *testing.T) {
+	students := []student{
+		{"Anderson", 2},
+		{"Brown", 3},
+		{"Davis", 3},
+		{"Harris", 1},
+		{"Martin", 1},
+		{"Martinez", 2},
+	}
+	want := []student{
+		{"Harris", 1},
+		{"Martin", 1},
+		{"Anderson", 2},
+		{"Martinez", 2},
+		{"Brown", 3},
+		{"Davis", 3},
+	}
+	KeyIndexedCountingFunc(students, func(s student) int { return s.group }, 4)
+	if !slices.Equal(students, want) {
+		t.Errorf("KeyIndexedCountingFunc() = %v, want %v", students, want)
+	}
+}
+
+func TestMSDFuncAlphabet(t *testing.T) {
+	type gene struct {
+		seq string
+	}
+	a := []gene{{"GATTACA"}, {"T"}, {"ACGT"}, {"GAT"}, {"CCC"}}
+	want := []gene{{"ACGT"}, {"CCC"}, {"GAT"}, {"GATTACA"}, {"T"}}
+	MSDFunc(a, func(g gene) string { return g.seq }, WithAlphabet(alphabet.DNA), WithCutoff(0))
+	if !slices.Equal(a, want) {
+		t.Errorf("MSDFunc(DNA) = %v, want %v", a, want)
+	}
+}
@@ -56,6 +56,29 @@ func KeyIndexedCounting(slice interface{}, key func(i int) int, radix int) {
 	}
 }
 
+// KeyIndexedCountingFunc is like KeyIndexedCounting, but it doesn't rely on reflection
+// and the key function takes a record instead of its index, e.g.,
+// KeyIndexedCountingFunc(students, func(s student) int { return s.group }, 5).
+func KeyIndexedCountingFunc[T any](a []T, key func(T) int, radix int) {
+	count := make([]int, radix+1)
+	for _, v := range a {
+		count[key(v)+1]++
+	}
+
+	for r := 0; r < radix; r++ {
+		count[r+1] += count[r]
+	}
+
+	aux := make([]T, len(a))
+	for _, v := range a {
+		k := key(v)
+		aux[count[k]] = v
+		count[k]++
+	}
+
+	copy(a, aux)
+}
+
 type student struct {
 	name  string
 	group int
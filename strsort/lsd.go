@@ -1,5 +1,11 @@
 package strsort
 
+import (
+	"fmt"
+
+	"github.com/marselester/alg/alphabet"
+)
+
 /*
 LSD performs least-significant-digit first (LSD) string sort given that the strings are fixed-length,
 for example, license plates, telephone numbers, bank account numbers, IP addresses.
@@ -16,17 +22,41 @@ No matter how large n, it makes w passes through the data.
 
 */
 func LSD(a []string, radix int) {
+	LSDFunc(a, identity, radix)
+}
+
+// LSDFunc is like LSD, but it sorts records by a fixed-length string or byte slice key, e.g.,
+// LSDFunc(cars, func(c car) string { return c.plate }, 256).
+func LSDFunc[T any, K Key](a []T, key func(T) K, radix int) {
+	lsd(a, key, radix, func(c byte) int { return int(c) })
+}
+
+// LSDAlphabet is like LSD, but the strings are sorted in the alphabet order, e.g., alphabet.DNA.
+// Each byte of a string is a character, hence the alphabet must consist of single-byte characters.
+// It panics if a character is not in the alphabet.
+func LSDAlphabet(a []string, alpha *alphabet.Alphabet) {
+	lsd(a, identity, alpha.Radix(), func(c byte) int {
+		i := alpha.ToIndex(rune(c))
+		if i == -1 {
+			panic(fmt.Sprintf("strsort: character %q is not in alphabet", c))
+		}
+		return i
+	})
+}
+
+// lsd sorts the records by their keys where index converts a byte into a character index in [0; radix-1] range.
+func lsd[T any, K Key](a []T, key func(T) K, radix int, index func(c byte) int) {
 	if len(a) == 0 {
 		return
 	}
-	// For simplicity's sake assume all the strings are the same length and each byte represents one character.
-	w := len(a[0])
-	aux := make([]string, len(a))
+	// For simplicity's sake assume all the keys are the same length and each byte represents one character.
+	w := len(key(a[0]))
+	aux := make([]T, len(a))
 
 	for column := w - 1; column >= 0; column-- {
 		count := make([]int, radix+1)
 		for i := range a {
-			count[a[i][column]+1]++
+			count[index(key(a[i])[column])+1]++
 		}
 
 		for r := 0; r < radix; r++ {
@@ -34,9 +64,9 @@ func LSD(a []string, radix int) {
 		}
 
 		for i := range a {
-			pos := count[a[i][column]]
-			aux[pos] = a[i]
-			count[a[i][column]]++
+			c := index(key(a[i])[column])
+			aux[count[c]] = a[i]
+			count[c]++
 		}
 
 		for i := range a {
@@ -1,6 +1,12 @@
 package strsort
 
-import "fmt"
+import (
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
 
 func ExampleLSD() {
 	plates := []string{
@@ -38,3 +44,19 @@ func ExampleLSD() {
 	// 4JZY524
 	// 4PGC938
 }
+
+func TestLSDAlphabet(t *testing.T) {
+	a := []string{"GATT", "ACGT", "TTAG", "CCCA", "GAAT", "ACGA"}
+	want := []string{"ACGA", "ACGT", "CCCA", "GAAT", "GATT", "TTAG"}
+	LSDAlphabet(a, alphabet.DNA)
+	if !slices.Equal(a, want) {
+		t.Errorf("LSDAlphabet() = %q, want %q", a, want)
+	}
+
+	// Reversed alphabet sorts the strings in descending order.
+	LSDAlphabet(a, alphabet.New("TGCA"))
+	slices.Reverse(want)
+	if !slices.Equal(a, want) {
+		t.Errorf("LSDAlphabet(TGCA) = %q, want %q", a, want)
+	}
+}
@@ -1,5 +1,12 @@
 package strsort
 
+import (
+	"fmt"
+	"sync"
+
+	"github.com/marselester/alg/alphabet"
+)
+
 const (
 	// DefaultCutoff is a cutoff for small subarrays threshold (insertion sort).
 	DefaultCutoff = 15
@@ -7,13 +14,46 @@ const (
 	DefaultRadix = 256
 )
 
+// Key is a sort key of a record: a string or a byte slice.
+type Key interface {
+	~string | ~[]byte
+}
+
+// msd is a configuration of MSD sort.
 type msd struct {
 	cutoff int // cutoff for small subarrays.
 	radix  int
-	aux    []string
+	// alphabet is nil when a character is a byte value.
+	alphabet *alphabet.Alphabet
+	// fold indicates whether the rune sorts fold the case of characters.
+	fold bool
+	// parallelism is the max number of goroutines of ParallelMSD.
+	parallelism int
 }
 type msdOption func(*msd)
 
+// newMSD creates the default MSD configuration with options applied.
+func newMSD(options []msdOption) msd {
+	s := msd{
+		cutoff: DefaultCutoff,
+		radix:  DefaultRadix,
+	}
+	for _, opt := range options {
+		opt(&s)
+	}
+	return s
+}
+
+// msdSorter sorts records by their keys according to the MSD configuration.
+type msdSorter[T any, K Key] struct {
+	msd
+	key func(T) K
+	aux []T
+	// workers is a semaphore that limits the number of goroutines of ParallelMSD, it's nil when the sort is sequential.
+	workers chan struct{}
+	wg      sync.WaitGroup
+}
+
 // WithCutoff defines a size of a subarray when to use insertion sort algorithm.
 func WithCutoff(cutoff int) msdOption {
 	return func(s *msd) {
@@ -28,6 +68,18 @@ func WithRadix(radix int) msdOption {
 	}
 }
 
+// WithAlphabet defines the alphabet of the keys, so the radix is the alphabet size
+// and the keys are ordered by the characters' indices, e.g., alphabet.DNA.
+// Each byte of a key is a character, hence the alphabet must consist of single-byte characters
+// unless it's a rune sort such as MSDRunes.
+// The sort panics if a character is not in the alphabet.
+func WithAlphabet(a *alphabet.Alphabet) msdOption {
+	return func(s *msd) {
+		s.alphabet = a
+		s.radix = a.Radix()
+	}
+}
+
 /*
 MSD performs most-significant-digit first (MSD) string sort where strings are not necessarily all the same length.
 It uses key-indexed counting to sort the strings according to their first character,
@@ -46,30 +98,38 @@ in the worst case.
 The main challenge in getting max efficiency from MSD sort on long strings is to deal with lack of randomness.
 */
 func MSD(a []string, options ...msdOption) {
-	s := msd{
-		cutoff: DefaultCutoff,
-		radix:  DefaultRadix,
-		aux:    make([]string, len(a)),
-	}
-	for _, opt := range options {
-		opt(&s)
-	}
+	MSDFunc(a, identity, options...)
+}
 
+// MSDFunc is like MSD, but it sorts records by a string or a byte slice key, e.g.,
+// MSDFunc(users, func(u user) string { return u.name }).
+// The sort is stable.
+func MSDFunc[T any, K Key](a []T, key func(T) K, options ...msdOption) {
+	s := msdSorter[T, K]{
+		msd: newMSD(options),
+		key: key,
+		aux: make([]T, len(a)),
+	}
 	s.sort(a, 0, len(a)-1, 0)
 }
 
-// sort sorts a slice of strings on their first character using key-indexed counting,
+// identity is a key of a string.
+func identity(s string) string {
+	return s
+}
+
+// sort sorts the records on the first character of their keys using key-indexed counting,
 // then recursively sorts the subarrays corresponding to each first-character value.
-func (s *msd) sort(a []string, lo, hi, column int) {
+func (s *msdSorter[T, K]) sort(a []T, lo, hi, column int) {
 	if hi <= lo+s.cutoff {
-		insertionSort(a, lo, hi)
+		s.insertionSort(a, lo, hi, column)
 		return
 	}
 
 	// Compute frequency counts.
 	count := make([]int, s.radix+2)
 	for i := lo; i <= hi; i++ {
-		count[charAt(a[i], column)+2]++
+		count[s.charAt(s.key(a[i]), column)+2]++
 	}
 
 	// Transform counts to indices.
@@ -77,24 +137,99 @@ func (s *msd) sort(a []string, lo, hi, column int) {
 		count[r+1] += count[r]
 	}
 
-	// Distribute.
+	// Distribute into the same positions of the auxiliary array,
+	// so the subarrays sorted concurrently by ParallelMSD don't overlap.
 	for i := lo; i <= hi; i++ {
-		c := charAt(a[i], column) + 1
-		s.aux[count[c]] = a[i]
+		c := s.charAt(s.key(a[i]), column) + 1
+		s.aux[lo+count[c]] = a[i]
 		count[c]++
 	}
 
 	// Copy back.
-	for i := lo; i <= hi; i++ {
-		a[i] = s.aux[i-lo]
-	}
+	copy(a[lo:hi+1], s.aux[lo:hi+1])
 
 	// Recursively sort for each character value excluding -1.
 	for r := 0; r < s.radix; r++ {
-		s.sort(a, lo+count[r], lo+count[r+1]-1, column+1)
+		s.sortBucket(a, lo+count[r], lo+count[r+1]-1, column+1)
 	}
 }
 
+// sortBucket sorts the subarray from a[lo] to a[hi] in a new goroutine
+// if the sort is parallel, the subarray is large enough, and there is an idle worker.
+// Otherwise the subarray is sorted in the current goroutine.
+func (s *msdSorter[T, K]) sortBucket(a []T, lo, hi, column int) {
+	if s.workers == nil || hi-lo < parallelCutoff {
+		s.sort(a, lo, hi, column)
+		return
+	}
+
+	select {
+	case s.workers <- struct{}{}:
+		s.wg.Add(1)
+		go func() {
+			s.sort(a, lo, hi, column)
+			<-s.workers
+			s.wg.Done()
+		}()
+	default:
+		s.sort(a, lo, hi, column)
+	}
+}
+
+// charAt is like charAt function, but it returns the index of the character in the alphabet if it's set.
+func (s *msdSorter[T, K]) charAt(str K, column int) int {
+	return charAtIn(s.alphabet, str, column)
+}
+
+// charAtIn is like charAt function, but it returns the index of the character in the alphabet if it's not nil.
+// It panics if the character is not in the alphabet.
+func charAtIn[K Key](a *alphabet.Alphabet, str K, column int) int {
+	if a == nil {
+		return charAt(str, column)
+	}
+	if column >= len(str) {
+		return -1
+	}
+	c := a.ToIndex(rune(str[column]))
+	if c == -1 {
+		panic(fmt.Sprintf("strsort: character %q of %q is not in alphabet", str[column], str))
+	}
+	return c
+}
+
+// insertionSort sorts from a[lo] to a[hi] whose first column characters are equal.
+// Keys are compared in the alphabet order if it's set.
+func (s *msdSorter[T, K]) insertionSort(a []T, lo, hi, column int) {
+	for i := lo; i <= hi; i++ {
+		for j := i; j > lo && s.less(s.key(a[j]), s.key(a[j-1]), column); j-- {
+			a[j], a[j-1] = a[j-1], a[j]
+		}
+	}
+}
+
+// less reports whether v is less than w (in the alphabet order if it's set) starting from the column.
+func (s *msdSorter[T, K]) less(v, w K, column int) bool {
+	if s.alphabet == nil {
+		return lessFrom(v, w, column)
+	}
+	for i := column; i < len(v) && i < len(w); i++ {
+		if cv, cw := s.charAt(v, i), s.charAt(w, i); cv != cw {
+			return cv < cw
+		}
+	}
+	return len(v) < len(w)
+}
+
+// lessFrom reports whether v is less than w comparing bytes starting from the column.
+func lessFrom[K Key](v, w K, column int) bool {
+	for i := column; i < len(v) && i < len(w); i++ {
+		if v[i] != w[i] {
+			return v[i] < w[i]
+		}
+	}
+	return len(v) < len(w)
+}
+
 /*
 charAt converts from an indexed string character to an array index that returns -1
 if the specified character position is past the end of the string.
@@ -106,20 +241,9 @@ This convention means that we have radix+1 different possible character values a
 
 When using charAt return value, add 1 to get a nonnegative int that can be used to index arrays.
 */
-func charAt(str string, column int) int {
+func charAt[K Key](str K, column int) int {
 	if column < len(str) {
 		return int(str[column])
 	}
 	return -1
 }
-
-// insertionSort sorts from a[lo] to a[hi] in increasing order using insertion sort algorithm.
-func insertionSort(a []string, lo, hi int) {
-	for i := lo; i <= hi; i++ {
-		for j := i; j > lo; j-- {
-			if a[j] < a[j-1] {
-				a[j], a[j-1] = a[j-1], a[j]
-			}
-		}
-	}
-}
@@ -1,6 +1,14 @@
 package strsort
 
-import "fmt"
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
 
 func ExampleMSD() {
 	a := []string{
@@ -38,3 +46,83 @@ func ExampleMSD() {
 	// the
 	// the
 }
+
+func TestMSDAlphabet(t *testing.T) {
+	tests := []struct {
+		name   string
+		cutoff int
+	}{
+		{"no cutoff", 0},
+		{"default cutoff", DefaultCutoff},
+	}
+	for _, tc := range tests {
+		a := []string{"GATTACA", "T", "ACGT", "TA", "GAT", "CCC", "GATA", "AAA", "GAT"}
+		want := []string{"AAA", "ACGT", "CCC", "GAT", "GAT", "GATA", "GATTACA", "T", "TA"}
+		MSD(a, WithAlphabet(alphabet.DNA), WithCutoff(tc.cutoff))
+		if !slices.Equal(a, want) {
+			t.Errorf("MSD(DNA) %s = %q, want %q", tc.name, a, want)
+		}
+
+		// Reversed alphabet changes the order of characters, but shorter strings still go first.
+		MSD(a, WithAlphabet(alphabet.New("TGCA")), WithCutoff(tc.cutoff))
+		want = []string{"T", "TA", "GAT", "GAT", "GATTACA", "GATA", "CCC", "ACGT", "AAA"}
+		if !slices.Equal(a, want) {
+			t.Errorf("MSD(TGCA) %s = %q, want %q", tc.name, a, want)
+		}
+	}
+}
+
+func TestMSDAlphabetPanic(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("MSD(DNA) expected panic")
+		}
+	}()
+	MSD([]string{"GATTACA", "GAXA"}, WithAlphabet(alphabet.DNA), WithCutoff(0))
+}
+
+// msdSorts are the sorts that take MSD options.
+var msdSorts = []struct {
+	name string
+	sort func(a []string, options ...msdOption)
+}{
+	{"MSD", MSD},
+	{"AmericanFlag", AmericanFlag},
+	{"ParallelMSD", ParallelMSD},
+	{"ParallelMSD one worker", func(a []string, options ...msdOption) {
+		ParallelMSD(a, append(options, WithParallelism(1))...)
+	}},
+}
+
+func TestMSDSortsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, s := range msdSorts {
+		for _, n := range []int{0, 1, 2, 100, 20000} {
+			for _, cutoff := range []int{0, DefaultCutoff} {
+				a := randomStrings(r, n, 8)
+				// Skewed strings with a common prefix.
+				for i := 0; i < n; i += 3 {
+					a[i] = "prefix" + a[i]
+				}
+				want := slices.Clone(a)
+				sort.Strings(want)
+
+				s.sort(a, WithCutoff(cutoff))
+				if !slices.Equal(a, want) {
+					t.Fatalf("%s(n=%d, cutoff=%d) isn't sorted", s.name, n, cutoff)
+				}
+			}
+		}
+	}
+}
+
+func TestMSDSortsAlphabet(t *testing.T) {
+	for _, s := range msdSorts {
+		a := []string{"GATTACA", "T", "ACGT", "TA", "GAT", "CCC", "GATA", "AAA", "GAT"}
+		want := []string{"AAA", "ACGT", "CCC", "GAT", "GAT", "GATA", "GATTACA", "T", "TA"}
+		s.sort(a, WithAlphabet(alphabet.DNA), WithCutoff(0))
+		if !slices.Equal(a, want) {
+			t.Errorf("%s(DNA) = %q, want %q", s.name, a, want)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package strsort
+
+import (
+	"math"
+	"unsafe"
+)
+
+// Integer is a signed or unsigned integer type.
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 | ~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
+// Float is a floating-point type.
+type Float interface {
+	~float32 | ~float64
+}
+
+/*
+LSDInts sorts integers in increasing order using LSD radix sort on bytes,
+i.e., an integer is treated as a fixed-length string of bytes from the most significant one.
+The sort is stable and takes w passes through the data where w is the size of the integer type in bytes.
+
+The negative numbers of two's complement representation have the sign bit set,
+so they would go after the positive ones. Flipping the sign bit fixes the order:
+the smallest int8 -128 (10000000) becomes 0, and -1 (11111111) becomes 127 (01111111).
+*/
+func LSDInts[I Integer](a []I) {
+	LSDIntsFunc(a, func(v I) I { return v })
+}
+
+// LSDIntsFunc is like LSDInts, but it sorts records by an integer key, e.g.,
+// LSDIntsFunc(users, func(u user) int { return u.age }).
+func LSDIntsFunc[T any, I Integer](a []T, key func(T) I) {
+	var zero I
+	width := int(unsafe.Sizeof(zero))
+	var signBit uint64
+	if isSigned := ^zero < 0; isSigned {
+		signBit = 1 << (8*width - 1)
+	}
+
+	lsdUint64(a, width, func(v T) uint64 {
+		// Sign extension of a negative number sets the bits above the width, they're masked out.
+		u := uint64(key(v)) ^ signBit
+		if width < 8 {
+			u &= 1<<(8*width) - 1
+		}
+		return u
+	})
+}
+
+/*
+LSDFloats sorts floating-point numbers in increasing order using LSD radix sort on bytes of IEEE 754 representation.
+The bits of a non-negative number are ordered like the unsigned integers, so only the sign bit is flipped.
+The bits of a negative number are all flipped, because the greater its magnitude, the smaller the number.
+
+The order is -NaN, -Inf, negative numbers, -0, +0, positive numbers, +Inf, NaN
+where -NaN is a NaN with the sign bit set.
+*/
+func LSDFloats[F Float](a []F) {
+	LSDFloatsFunc(a, func(v F) F { return v })
+}
+
+// LSDFloatsFunc is like LSDFloats, but it sorts records by a floating-point key, e.g.,
+// LSDFloatsFunc(products, func(p product) float64 { return p.price }).
+func LSDFloatsFunc[T any, F Float](a []T, key func(T) F) {
+	var zero F
+	if unsafe.Sizeof(zero) == 4 {
+		lsdUint64(a, 4, func(v T) uint64 {
+			u := math.Float32bits(float32(key(v)))
+			if u>>31 == 1 {
+				return uint64(^u)
+			}
+			return uint64(u | 1<<31)
+		})
+		return
+	}
+
+	lsdUint64(a, 8, func(v T) uint64 {
+		u := math.Float64bits(float64(key(v)))
+		if u>>63 == 1 {
+			return ^u
+		}
+		return u | 1<<63
+	})
+}
+
+// lsdUint64 sorts the records by the width least significant bytes of their unsigned integer keys.
+// The keys are computed once, and a pass is skipped when all the keys have the same byte.
+func lsdUint64[T any](a []T, width int, key func(T) uint64) {
+	if len(a) == 0 {
+		return
+	}
+	src := a
+	keys := make([]uint64, len(a))
+	for i := range a {
+		keys[i] = key(a[i])
+	}
+	aux := make([]T, len(a))
+	auxKeys := make([]uint64, len(a))
+
+	for shift := 0; shift < 8*width; shift += 8 {
+		var count [257]int
+		for _, k := range keys {
+			count[int(byte(k>>shift))+1]++
+		}
+		if count[int(byte(keys[0]>>shift))+1] == len(a) {
+			continue
+		}
+
+		for r := 0; r < 256; r++ {
+			count[r+1] += count[r]
+		}
+
+		for i, k := range keys {
+			c := byte(k >> shift)
+			aux[count[c]] = a[i]
+			auxKeys[count[c]] = k
+			count[c]++
+		}
+
+		a, aux = aux, a
+		keys, auxKeys = auxKeys, keys
+	}
+	// The records end up in the auxiliary array after odd number of passes.
+	if &a[0] != &src[0] {
+		copy(src, a)
+	}
+}
@@ -0,0 +1,113 @@
+package strsort
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func ExampleLSDInts() {
+	a := []int{170, -45, 75, -90, 802, 24, 2, 66, math.MinInt, math.MaxInt}
+	LSDInts(a)
+	fmt.Println(a)
+	// Output:
+	// [-9223372036854775808 -90 -45 2 24 66 75 170 802 9223372036854775807]
+}
+
+func TestLSDInts(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for _, n := range []int{0, 1, 2, 100, 1000} {
+		a8 := make([]int8, n)
+		a32 := make([]int32, n)
+		a64 := make([]int64, n)
+		u16 := make([]uint16, n)
+		u64 := make([]uint64, n)
+		for i := 0; i < n; i++ {
+			a8[i] = int8(r.Uint32())
+			a32[i] = int32(r.Uint32())
+			a64[i] = int64(r.Uint64())
+			u16[i] = uint16(r.Uint32())
+			u64[i] = r.Uint64()
+		}
+
+		testLSDInts(t, a8)
+		testLSDInts(t, a32)
+		testLSDInts(t, a64)
+		testLSDInts(t, u16)
+		testLSDInts(t, u64)
+	}
+
+	// Only the least significant bytes differ, so the other passes are skipped.
+	testLSDInts(t, []int{3, -1, 2, -3, 0, 1, -2})
+}
+
+func testLSDInts[I Integer](t *testing.T, a []I) {
+	t.Helper()
+	want := slices.Clone(a)
+	slices.Sort(want)
+	LSDInts(a)
+	if !slices.Equal(a, want) {
+		t.Errorf("LSDInts(%T) = %v, want %v", a, a, want)
+	}
+}
+
+func TestLSDFloats(t *testing.T) {
+	negZero := math.Copysign(0, -1)
+	a := []float64{3.5, math.Inf(-1), negZero, 1e-300, -2.25, math.NaN(), math.Inf(1), 0, -1e300, 42}
+	LSDFloats(a)
+	want := []float64{math.Inf(-1), -1e300, -2.25, negZero, 0, 1e-300, 3.5, 42, math.Inf(1), math.NaN()}
+	if fmt.Sprint(a) != fmt.Sprint(want) {
+		t.Errorf("LSDFloats() = %v, want %v", a, want)
+	}
+	if !math.Signbit(a[3]) || math.Signbit(a[4]) {
+		t.Errorf("LSDFloats() = %v, want -0 before +0", a)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	f32 := make([]float32, 1000)
+	f64 := make([]float64, 1000)
+	for i := range f64 {
+		f32[i] = float32(r.NormFloat64() * 1000)
+		f64[i] = r.NormFloat64() * math.Pow(10, float64(r.Intn(40)-20))
+	}
+	testLSDFloats(t, f32)
+	testLSDFloats(t, f64)
+}
+
+func testLSDFloats[F Float](t *testing.T, a []F) {
+	t.Helper()
+	want := slices.Clone(a)
+	slices.Sort(want)
+	LSDFloats(a)
+	if !slices.Equal(a, want) {
+		t.Errorf("LSDFloats(%T) = %v, want %v", a, a, want)
+	}
+}
+
+func TestLSDIntsFuncStable(t *testing.T) {
+	students := []student{
+		{"Anderson", 2},
+		{"Brown", 3},
+		{"Davis", 3},
+		{"Harris", 1},
+		{"Martin", 1},
+		{"Martinez", 2},
+	}
+	want := slices.Clone(students)
+	sort.SliceStable(want, func(i, j int) bool { return want[i].group < want[j].group })
+
+	LSDIntsFunc(students, func(s student) int { return s.group })
+	if !slices.Equal(students, want) {
+		t.Errorf("LSDIntsFunc() = %v, want %v", students, want)
+	}
+
+	LSDFloatsFunc(students, func(s student) float64 { return -float64(s.group) })
+	// Reversing the order of the groups keeps the students' order within a group.
+	sort.SliceStable(want, func(i, j int) bool { return want[i].group > want[j].group })
+	if !slices.Equal(students, want) {
+		t.Errorf("LSDFloatsFunc() = %v, want %v", students, want)
+	}
+}
@@ -0,0 +1,43 @@
+package strsort
+
+import "runtime"
+
+// parallelCutoff is the min size of a subarray to sort in a separate goroutine,
+// smaller subarrays aren't worth the overhead.
+const parallelCutoff = 1 << 12
+
+// WithParallelism limits the number of goroutines that ParallelMSD runs concurrently.
+// By default it's GOMAXPROCS.
+func WithParallelism(n int) msdOption {
+	return func(s *msd) {
+		s.parallelism = n
+	}
+}
+
+/*
+ParallelMSD is like MSD, but the subarrays corresponding to each character value (buckets)
+are sorted concurrently. Once the first pass distributed the strings into buckets,
+the buckets are independent: they occupy disjoint ranges of the slice
+and of the auxiliary array, so they can be sorted by different goroutines without locking.
+
+A bucket is handed over to a new goroutine if it's large enough (thousands of strings)
+and there are less than WithParallelism goroutines running,
+otherwise the bucket is sorted by the current goroutine.
+Skewed data such as strings with a long common prefix put most of the strings in one bucket,
+so the parallelism kicks in deeper in the recursion.
+*/
+func ParallelMSD(a []string, options ...msdOption) {
+	s := msdSorter[string, string]{
+		msd: newMSD(options),
+		key: identity,
+		aux: make([]string, len(a)),
+	}
+	if s.parallelism == 0 {
+		s.parallelism = runtime.GOMAXPROCS(0)
+	}
+	// The current goroutine is one of the workers.
+	s.workers = make(chan struct{}, s.parallelism-1)
+
+	s.sort(a, 0, len(a)-1, 0)
+	s.wg.Wait()
+}
@@ -21,19 +21,26 @@ The algorithm outperforms other sorting methods when strings have long common pr
 it doesn't have to reexamine (web logs analysis).
 */
 func Quick(a []string) {
-	quick3way(a, 0, len(a)-1, 0)
+	QuickFunc(a, identity)
 }
 
-func quick3way(a []string, lo, hi, column int) {
+// QuickFunc is like Quick, but it sorts records by a string or a byte slice key, e.g.,
+// QuickFunc(users, func(u user) string { return u.name }).
+// Like the standard quicksort, the sort is not stable.
+func QuickFunc[T any, K Key](a []T, key func(T) K) {
+	quick3way(a, key, 0, len(a)-1, 0)
+}
+
+func quick3way[T any, K Key](a []T, key func(T) K, lo, hi, column int) {
 	if hi <= lo {
 		return
 	}
 
 	lt, gt := lo, hi
-	v := charAt(a[lo], column)
+	v := charAt(key(a[lo]), column)
 
 	for i := lo + 1; i <= gt; {
-		switch t := charAt(a[i], column); {
+		switch t := charAt(key(a[i]), column); {
 		case t < v:
 			a[lt], a[i] = a[i], a[lt]
 			lt++
@@ -46,9 +53,9 @@ func quick3way(a []string, lo, hi, column int) {
 		}
 	}
 
-	quick3way(a, lo, lt-1, column)
+	quick3way(a, key, lo, lt-1, column)
 	if v >= 0 {
-		quick3way(a, lt, gt, column+1)
+		quick3way(a, key, lt, gt, column+1)
 	}
-	quick3way(a, gt+1, hi, column)
+	quick3way(a, key, gt+1, hi, column)
 }
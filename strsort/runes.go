@@ -0,0 +1,202 @@
+package strsort
+
+import (
+	"fmt"
+	"unicode"
+)
+
+// WithCaseFolding makes the rune sorts (MSDRunes, QuickRunes) compare characters case-insensitively,
+// e.g., "Zürich" and "ZÜRICH" are equal, so MSDRunes keeps them in the input order.
+func WithCaseFolding() msdOption {
+	return func(s *msd) {
+		s.fold = true
+	}
+}
+
+// ranked is a string with the ranks of its characters (runes) used to sort the string.
+type ranked struct {
+	s     string
+	ranks []int32
+}
+
+// rankAll computes the ranks of the strings' characters: the indices in the alphabet if it's set,
+// otherwise the code points which must be less than radix if it's set.
+// The characters are case-folded first if needed.
+// The radix is set to the max rank + 1 if neither the alphabet nor the radix is set.
+func (s *msd) rankAll(a []string) []ranked {
+	rs := make([]ranked, len(a))
+	var maxRank int32
+	for i, str := range a {
+		ranks := make([]int32, 0, len(str))
+		for _, c := range str {
+			r := s.rank(c, str)
+			ranks = append(ranks, r)
+			maxRank = max(maxRank, r)
+		}
+		rs[i] = ranked{s: str, ranks: ranks}
+	}
+
+	if s.radix == 0 {
+		s.radix = int(maxRank) + 1
+	}
+	return rs
+}
+
+// rank returns the rank of the character c of the string str.
+// It panics if the character is not in the alphabet or out of radix.
+func (s *msd) rank(c rune, str string) int32 {
+	if s.fold {
+		c = foldRune(c)
+	}
+	r := c
+	if s.alphabet != nil {
+		r = rune(s.alphabet.ToIndex(c))
+	}
+	if r == -1 || (s.radix > 0 && int(r) >= s.radix) {
+		panic(fmt.Sprintf("strsort: character %q of %q is not in alphabet", c, str))
+	}
+	return r
+}
+
+// foldRune maps the character to its lowercase form so that all the case variants are mapped to the same character,
+// e.g., 'K', 'k', and Kelvin sign 'K' become 'k'; 'S', 's', and long s 'ſ' become 's'.
+func foldRune(c rune) rune {
+	return unicode.ToLower(unicode.ToUpper(c))
+}
+
+// rankAt is like charAt, but it returns the rank of the character at the column.
+func rankAt(ranks []int32, column int) int {
+	if column < len(ranks) {
+		return int(ranks[column])
+	}
+	return -1
+}
+
+/*
+MSDRunes is like MSD, but the characters are runes rather than bytes,
+so UTF-8 strings are ordered by code points, e.g., Cyrillic "ёж" goes after "яд"
+because 'ё' (U+0451) is greater than 'я' (U+044F).
+Use WithAlphabet to order the characters differently, e.g., alphabet.New("абвгдеёжзийклмнопрстуфхцчшщъыьэюя").
+With WithCaseFolding the sort ignores the case of characters.
+
+The characters are ranked before sorting which takes extra space proportional to the total length of the strings.
+Unless an alphabet or a radix is set, the radix is the max code point of the strings plus one,
+e.g., 1104 for Cyrillic text, but it's 40k for Chinese text which makes the count arrays too large;
+QuickRunes doesn't depend on the radix.
+*/
+func MSDRunes(a []string, options ...msdOption) {
+	s := msd{cutoff: DefaultCutoff}
+	for _, opt := range options {
+		opt(&s)
+	}
+
+	rs := s.rankAll(a)
+	sorter := runeSorter{msd: s, aux: make([]ranked, len(a))}
+	sorter.sort(rs, 0, len(rs)-1, 0)
+	for i := range rs {
+		a[i] = rs[i].s
+	}
+}
+
+// runeSorter sorts the strings by ranks of their characters according to the MSD configuration.
+type runeSorter struct {
+	msd
+	aux []ranked
+}
+
+// sort sorts the strings on the rank of their first character using key-indexed counting,
+// then recursively sorts the subarrays corresponding to each first-character rank.
+func (s *runeSorter) sort(a []ranked, lo, hi, column int) {
+	if hi <= lo+s.cutoff {
+		s.insertionSort(a, lo, hi, column)
+		return
+	}
+
+	count := make([]int, s.radix+2)
+	for i := lo; i <= hi; i++ {
+		count[rankAt(a[i].ranks, column)+2]++
+	}
+
+	for r := 0; r < s.radix+1; r++ {
+		count[r+1] += count[r]
+	}
+
+	for i := lo; i <= hi; i++ {
+		c := rankAt(a[i].ranks, column) + 1
+		s.aux[count[c]] = a[i]
+		count[c]++
+	}
+
+	copy(a[lo:hi+1], s.aux[:hi-lo+1])
+
+	for r := 0; r < s.radix; r++ {
+		s.sort(a, lo+count[r], lo+count[r+1]-1, column+1)
+	}
+}
+
+// insertionSort sorts from a[lo] to a[hi] whose first column characters are equal.
+func (s *runeSorter) insertionSort(a []ranked, lo, hi, column int) {
+	for i := lo; i <= hi; i++ {
+		for j := i; j > lo && lessRanks(a[j].ranks, a[j-1].ranks, column); j-- {
+			a[j], a[j-1] = a[j-1], a[j]
+		}
+	}
+}
+
+// lessRanks reports whether v is less than w comparing ranks starting from the column.
+func lessRanks(v, w []int32, column int) bool {
+	for i := column; i < len(v) && i < len(w); i++ {
+		if v[i] != w[i] {
+			return v[i] < w[i]
+		}
+	}
+	return len(v) < len(w)
+}
+
+/*
+QuickRunes is like Quick, but the characters are runes rather than bytes.
+It takes the same options as MSDRunes except for the cutoff,
+though the radix doesn't affect its running time, so it's a better fit for large alphabets.
+*/
+func QuickRunes(a []string, options ...msdOption) {
+	var s msd
+	for _, opt := range options {
+		opt(&s)
+	}
+
+	rs := s.rankAll(a)
+	quickRanks(rs, 0, len(rs)-1, 0)
+	for i := range rs {
+		a[i] = rs[i].s
+	}
+}
+
+// quickRanks is 3-way string quicksort of the strings by ranks of their characters.
+func quickRanks(a []ranked, lo, hi, column int) {
+	if hi <= lo {
+		return
+	}
+
+	lt, gt := lo, hi
+	v := rankAt(a[lo].ranks, column)
+
+	for i := lo + 1; i <= gt; {
+		switch t := rankAt(a[i].ranks, column); {
+		case t < v:
+			a[lt], a[i] = a[i], a[lt]
+			lt++
+			i++
+		case t > v:
+			a[i], a[gt] = a[gt], a[i]
+			gt--
+		default:
+			i++
+		}
+	}
+
+	quickRanks(a, lo, lt-1, column)
+	if v >= 0 {
+		quickRanks(a, lt, gt, column+1)
+	}
+	quickRanks(a, gt+1, hi, column)
+}
@@ -0,0 +1,151 @@
+package strsort
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/marselester/alg/alphabet"
+)
+
+// runeSorts are the sorts that treat runes as characters.
+var runeSorts = []struct {
+	name string
+	sort func(a []string, options ...msdOption)
+}{
+	{"MSDRunes", MSDRunes},
+	{"MSDRunes no cutoff", func(a []string, options ...msdOption) {
+		MSDRunes(a, append(options, WithCutoff(0))...)
+	}},
+	{"QuickRunes", QuickRunes},
+}
+
+func ExampleCollate() {
+	a := []string{"zebra", "Zürich", "éclair", "Eclair", "eclair", "Äpfel", "apple", "Apple", "zucchini", "Straße", "strasse"}
+	Collate(a)
+	fmt.Println(strings.Join(a, "\n"))
+	// Output:
+	// Äpfel
+	// apple
+	// Apple
+	// eclair
+	// Eclair
+	// éclair
+	// strasse
+	// Straße
+	// zebra
+	// zucchini
+	// Zürich
+}
+
+func TestRuneSorts(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   []string
+		options []msdOption
+		want    []string
+	}{
+		{
+			name:  "code points",
+			input: []string{"ёж", "яд", "жук", "ёлка", "", "арбуз", "Ель"},
+			want:  []string{"", "Ель", "арбуз", "жук", "яд", "ёж", "ёлка"},
+		},
+		{
+			name:    "Russian alphabet",
+			input:   []string{"ёж", "яд", "жук", "ёлка", "", "арбуз", "ель"},
+			options: []msdOption{WithAlphabet(alphabet.New("абвгдеёжзийклмнопрстуфхцчшщъыьэюя"))},
+			want:    []string{"", "арбуз", "ель", "ёж", "ёлка", "жук", "яд"},
+		},
+		{
+			name:    "case folding",
+			input:   []string{"Banana", "apple", "ZÜRICH", "cherry", "Apple", "Zürich", "Zurich"},
+			options: []msdOption{WithCaseFolding()},
+			want:    []string{"apple", "Apple", "Banana", "cherry", "Zurich", "ZÜRICH", "Zürich"},
+		},
+		{
+			name:    "case folding with alphabet",
+			input:   []string{"GATTACA", "cat", "ACGT", "Gat"},
+			options: []msdOption{WithCaseFolding(), WithAlphabet(alphabet.New("acgt"))},
+			want:    []string{"ACGT", "cat", "Gat", "GATTACA"},
+		},
+	}
+
+	for _, s := range runeSorts {
+		for _, tc := range tests {
+			a, want := slices.Clone(tc.input), slices.Clone(tc.want)
+			s.sort(a, tc.options...)
+			if s.name == "QuickRunes" && tc.name == "case folding" {
+				// Quicksort isn't stable, so only the folded strings are compared.
+				for i := range a {
+					a[i], want[i] = strings.ToLower(a[i]), strings.ToLower(want[i])
+				}
+			}
+			if !slices.Equal(a, want) {
+				t.Errorf("%s(%s) = %q, want %q", s.name, tc.name, a, want)
+			}
+		}
+	}
+}
+
+func TestRuneSortsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	chars := []rune("aAbяЯё日本語😀")
+	for _, s := range runeSorts {
+		for _, n := range []int{0, 1, 10, 1000} {
+			a := make([]string, n)
+			for i := range a {
+				word := make([]rune, r.Intn(6))
+				for j := range word {
+					word[j] = chars[r.Intn(len(chars))]
+				}
+				a[i] = string(word)
+			}
+			// UTF-8 preserves the order of code points, so the byte-wise order is the same.
+			want := slices.Clone(a)
+			sort.Strings(want)
+
+			s.sort(a)
+			if !slices.Equal(a, want) {
+				t.Fatalf("%s() = %q, want %q", s.name, a, want)
+			}
+		}
+	}
+}
+
+func TestRuneSortsPanic(t *testing.T) {
+	for _, s := range runeSorts {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("%s(DNA) expected panic", s.name)
+				}
+			}()
+			s.sort([]string{"GATTACA", "GAXA"}, WithAlphabet(alphabet.DNA))
+		}()
+	}
+}
+
+func TestCollationKey(t *testing.T) {
+	tests := []struct {
+		a, b string
+	}{
+		{"Äpfel", "apple"},
+		{"apple", "Apple"},
+		{"Apple", "äpple"},
+		{"eclair", "éclair"},
+		{"Eclair", "éclair"},
+		{"resume", "résumé"},
+		{"Ölkanne", "orange"},
+		{"ab", "abc"},
+		{"strasse", "Straße"},
+		{"Straße", "strasser"},
+	}
+	for _, tc := range tests {
+		if ka, kb := CollationKey(tc.a), CollationKey(tc.b); ka >= kb {
+			t.Errorf("CollationKey(%q) >= CollationKey(%q), want less", tc.a, tc.b)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package substr
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+
+	"github.com/marselester/alg/queue"
+)
+
+// MatchKind defines which matches are reported when the patterns overlap in the text.
+type MatchKind int
+
+const (
+	// Overlapping reports all the occurrences of all the patterns, e.g.,
+	// patterns "he", "she", "hers" are found at offsets 1, 2, 2 in "ushers".
+	Overlapping MatchKind = iota
+	// LeftmostLongest reports non-overlapping matches scanning the text from left to right:
+	// the match that starts first wins, and when several patterns start at the same offset,
+	// the longest one wins, e.g., only "she" is found in "ushers".
+	LeftmostLongest
+)
+
+// Match is an occurrence of a pattern in the text.
+type Match struct {
+	Pattern string
+	// Offset is the byte offset of the occurrence in the text.
+	Offset int64
+}
+
+/*
+AhoCorasick searches for a set of patterns simultaneously in a single pass over the text.
+It generalizes the KMP algorithm to multiple patterns: the patterns are put into a trie,
+and each trie node (state) has a failure link to the node corresponding to the longest proper suffix
+of the state's string that is also in the trie.
+When the next text character has no transition from the current state,
+the automaton follows the failure links until the transition is found or it reaches the root.
+
+Each state also has an output link to the nearest state along the failure links that ends a pattern,
+so all the patterns which end at a text position are reported without scanning the whole failure chain.
+
+The search never backs up in the text (except for a few bytes in leftmost-longest mode),
+so it takes time proportional to n + m + z where n is the text length,
+m is the total length of the patterns, and z is the number of matches.
+
+Zero value is unusable, please use NewAhoCorasick.
+*/
+type AhoCorasick struct {
+	kind     MatchKind
+	patterns []string
+	// states of the automaton where the root is states[0].
+	states []acstate
+}
+type acstate struct {
+	// keys are the characters of the transitions to the children states.
+	keys     []byte
+	children []int32
+	// fail is the state of the longest proper suffix that is in the trie.
+	fail int32
+	// output is the nearest state along the failure links that ends a pattern, or -1 if there is none.
+	output int32
+	// pattern is the index of the pattern which ends at the state, or -1 if there is none.
+	pattern int32
+	// depth is the length of the state's string.
+	depth int32
+}
+type ahoCorasickOption func(*AhoCorasick)
+
+// WithMatchKind defines which matches are reported, Overlapping by default.
+func WithMatchKind(kind MatchKind) ahoCorasickOption {
+	return func(ac *AhoCorasick) {
+		ac.kind = kind
+	}
+}
+
+// NewAhoCorasick builds the automaton from the patterns.
+// Empty patterns are ignored, and so are the duplicates.
+func NewAhoCorasick(patterns []string, options ...ahoCorasickOption) *AhoCorasick {
+	ac := AhoCorasick{
+		states: []acstate{{output: -1, pattern: -1}},
+	}
+	for _, opt := range options {
+		opt(&ac)
+	}
+
+	for _, pat := range patterns {
+		ac.put(pat)
+	}
+	ac.link()
+	return &ac
+}
+
+// put inserts the pattern into the trie.
+func (ac *AhoCorasick) put(pat string) {
+	if pat == "" {
+		return
+	}
+
+	var s int32
+	for i := 0; i < len(pat); i++ {
+		next := ac.child(s, pat[i])
+		if next == -1 {
+			next = int32(len(ac.states))
+			ac.states = append(ac.states, acstate{
+				output:  -1,
+				pattern: -1,
+				depth:   int32(i + 1),
+			})
+			ac.states[s].keys = append(ac.states[s].keys, pat[i])
+			ac.states[s].children = append(ac.states[s].children, next)
+		}
+		s = next
+	}
+	if ac.states[s].pattern == -1 {
+		ac.states[s].pattern = int32(len(ac.patterns))
+		ac.patterns = append(ac.patterns, pat)
+	}
+}
+
+/*
+link computes the failure and output links visiting the states in breadth-first order,
+so the links of the shallower states are ready when they're needed.
+The failure link of the child v of the state u by the character c is found
+by following the failure links from u until there is a state with a transition on c.
+*/
+func (ac *AhoCorasick) link() {
+	q := queue.ArrayOf[int32]{}
+	for _, v := range ac.states[0].children {
+		q.Enqueue(v)
+	}
+	for q.Size() > 0 {
+		u := q.Dequeue()
+		for i, v := range ac.states[u].children {
+			q.Enqueue(v)
+
+			f := ac.next(ac.states[u].fail, ac.states[u].keys[i])
+			ac.states[v].fail = f
+			if ac.states[f].pattern != -1 {
+				ac.states[v].output = f
+			} else {
+				ac.states[v].output = ac.states[f].output
+			}
+		}
+	}
+}
+
+// child returns the state reached from the state s by the character c, or -1 if there is no transition.
+func (ac *AhoCorasick) child(s int32, c byte) int32 {
+	if i := bytes.IndexByte(ac.states[s].keys, c); i != -1 {
+		return ac.states[s].children[i]
+	}
+	return -1
+}
+
+// next returns the state after reading the character c in the state s following the failure links.
+func (ac *AhoCorasick) next(s int32, c byte) int32 {
+	for {
+		if next := ac.child(s, c); next != -1 {
+			return next
+		}
+		if s == 0 {
+			return 0
+		}
+		s = ac.states[s].fail
+	}
+}
+
+// Size returns the number of distinct patterns.
+func (ac *AhoCorasick) Size() int {
+	return len(ac.patterns)
+}
+
+// FindAll returns the matches of the patterns in s ordered by the offset where they end.
+func (ac *AhoCorasick) FindAll(s string) []Match {
+	var matches []Match
+	// Reading from a string never fails.
+	_ = ac.Scan(strings.NewReader(s), func(m Match) bool {
+		matches = append(matches, m)
+		return true
+	})
+	return matches
+}
+
+// Scan reads the stream byte by byte calling fn with each match until fn returns false.
+// The matches are reported in order of the offsets where they end.
+func (ac *AhoCorasick) Scan(r io.Reader, fn func(m Match) bool) error {
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	if ac.kind == LeftmostLongest {
+		return ac.scanLeftmostLongest(br, fn)
+	}
+
+	var s int32
+	for i := int64(0); ; i++ {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		s = ac.next(s, c)
+		for out := s; out != -1; out = ac.states[out].output {
+			if ac.states[out].pattern == -1 {
+				continue
+			}
+			m := Match{
+				Pattern: ac.patterns[ac.states[out].pattern],
+				Offset:  i - int64(ac.states[out].depth) + 1,
+			}
+			if !fn(m) {
+				return nil
+			}
+		}
+	}
+}
+
+/*
+scanLeftmostLongest keeps the leftmost-longest match found so far as a candidate.
+The candidate is final once the current state can't lead to a match starting at or before it:
+the state's string is the longest suffix of the text that can be extended to a pattern,
+so all the matches in progress start at offset i-depth+1 or later.
+After the candidate is reported, the automaton restarts from the root right after the candidate,
+so the bytes scanned past the candidate are replayed.
+There are fewer of them than the longest pattern.
+*/
+func (ac *AhoCorasick) scanLeftmostLongest(br io.ByteReader, fn func(m Match) bool) error {
+	var (
+		s int32
+		// cand is the candidate match, and candEnd is the offset of its last byte.
+		cand    *Match
+		candEnd int64
+		// pending are the bytes scanned after the candidate.
+		pending []byte
+		// replay are the bytes to scan before reading the stream.
+		replay []byte
+	)
+	for i := int64(0); ; i++ {
+		var c byte
+		if len(replay) > 0 {
+			c, replay = replay[0], replay[1:]
+		} else {
+			var err error
+			if c, err = br.ReadByte(); err != nil && err != io.EOF {
+				return err
+			}
+			if err == io.EOF {
+				if cand == nil {
+					return nil
+				}
+				if !fn(*cand) {
+					return nil
+				}
+				s, i, cand = 0, candEnd, nil
+				replay, pending = pending, nil
+				continue
+			}
+		}
+
+		s = ac.next(s, c)
+		if cand != nil {
+			pending = append(pending, c)
+		}
+
+		// The longest pattern that ends here starts first.
+		out := s
+		if ac.states[out].pattern == -1 {
+			out = ac.states[out].output
+		}
+		if out != -1 {
+			offset := i - int64(ac.states[out].depth) + 1
+			if cand == nil || offset <= cand.Offset {
+				cand = &Match{
+					Pattern: ac.patterns[ac.states[out].pattern],
+					Offset:  offset,
+				}
+				candEnd = i
+				pending = pending[:0]
+			}
+		}
+
+		if cand != nil && i-int64(ac.states[s].depth)+1 > cand.Offset {
+			if !fn(*cand) {
+				return nil
+			}
+			s, i, cand = 0, candEnd, nil
+			replay = append(append([]byte(nil), pending...), replay...)
+			pending = pending[:0]
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package substr
+
+import (
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+func TestAhoCorasick(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		text     string
+		kind     MatchKind
+		want     []Match
+	}{
+		{
+			patterns: []string{"he", "she", "his", "hers"},
+			text:     "ushers",
+			kind:     Overlapping,
+			want:     []Match{{"she", 1}, {"he", 2}, {"hers", 2}},
+		},
+		{
+			patterns: []string{"he", "she", "his", "hers"},
+			text:     "ushers",
+			kind:     LeftmostLongest,
+			want:     []Match{{"she", 1}},
+		},
+		{
+			patterns: []string{"abcd", "bc", "hi"},
+			text:     "abcdefghi abchi",
+			kind:     LeftmostLongest,
+			want:     []Match{{"abcd", 0}, {"hi", 7}, {"bc", 11}, {"hi", 13}},
+		},
+		{
+			patterns: []string{"abcdefghij", "bc", "hi"},
+			text:     "abcdefghiX",
+			kind:     LeftmostLongest,
+			want:     []Match{{"bc", 1}, {"hi", 7}},
+		},
+		{
+			patterns: []string{"a", "ab", "abc"},
+			text:     "abcab",
+			kind:     LeftmostLongest,
+			want:     []Match{{"abc", 0}, {"ab", 3}},
+		},
+		{
+			patterns: []string{"aa", "aa", ""},
+			text:     "aaaa",
+			kind:     Overlapping,
+			want:     []Match{{"aa", 0}, {"aa", 1}, {"aa", 2}},
+		},
+		{
+			patterns: []string{"aa"},
+			text:     "aaaaa",
+			kind:     LeftmostLongest,
+			want:     []Match{{"aa", 0}, {"aa", 2}},
+		},
+		{
+			patterns: []string{"日本", "本語"},
+			text:     "日本語",
+			kind:     Overlapping,
+			want:     []Match{{"日本", 0}, {"本語", 3}},
+		},
+		{
+			patterns: []string{"needle"},
+			text:     "haystack",
+			want:     nil,
+		},
+		{
+			patterns: nil,
+			text:     "haystack",
+			want:     nil,
+		},
+	}
+
+	for _, tc := range tests {
+		ac := NewAhoCorasick(tc.patterns, WithMatchKind(tc.kind))
+		got := ac.FindAll(tc.text)
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("AhoCorasick(%q, %d).FindAll(%q) = %v, want %v", tc.patterns, tc.kind, tc.text, got, tc.want)
+		}
+	}
+}
+
+func TestAhoCorasickRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		patterns := make([]string, 1+r.Intn(5))
+		for j := range patterns {
+			patterns[j] = randomString(r, "ab", 1+r.Intn(4))
+		}
+		text := randomString(r, "abc", r.Intn(30))
+
+		for kind, want := range [][]Match{
+			Overlapping:     findAllOverlapping(text, patterns),
+			LeftmostLongest: findAllLeftmostLongest(text, patterns),
+		} {
+			ac := NewAhoCorasick(patterns, WithMatchKind(MatchKind(kind)))
+			if got := ac.FindAll(text); !reflect.DeepEqual(got, want) {
+				t.Fatalf("AhoCorasick(%q, %d).FindAll(%q) = %v, want %v", patterns, kind, text, got, want)
+			}
+		}
+	}
+}
+
+func TestAhoCorasickScan(t *testing.T) {
+	for _, kind := range []MatchKind{Overlapping, LeftmostLongest} {
+		ac := NewAhoCorasick([]string{"error", "warn"}, WithMatchKind(kind))
+		text := "info: ok\nwarn: disk\nerror: boom\nerror: again\n"
+
+		// The scan stops after the second match.
+		var got []Match
+		err := ac.Scan(iotest.OneByteReader(strings.NewReader(text)), func(m Match) bool {
+			got = append(got, m)
+			return len(got) < 2
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []Match{{"warn", 9}, {"error", 20}}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("Scan(%d) = %v, want %v", kind, got, want)
+		}
+	}
+}
+
+func TestAhoCorasickScanError(t *testing.T) {
+	errRead := errors.New("read failed")
+	for _, kind := range []MatchKind{Overlapping, LeftmostLongest} {
+		ac := NewAhoCorasick([]string{"ab"}, WithMatchKind(kind))
+		r := io.MultiReader(strings.NewReader("abab"), iotest.ErrReader(errRead))
+		err := ac.Scan(r, func(Match) bool { return true })
+		if !errors.Is(err, errRead) {
+			t.Errorf("Scan(%d) error = %v, want %v", kind, err, errRead)
+		}
+	}
+}
+
+func BenchmarkAhoCorasick(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	text := randomString(r, "abcdefghijklmnopqrstuvwxyz ", 1<<16)
+	patterns := make([]string, 1000)
+	for i := range patterns {
+		patterns[i] = randomString(r, "abcdefghijklmnopqrstuvwxyz", 4+r.Intn(8))
+	}
+
+	b.Run("AhoCorasick", func(b *testing.B) {
+		ac := NewAhoCorasick(patterns)
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			ac.FindAll(text)
+		}
+	})
+	b.Run("KMP", func(b *testing.B) {
+		matchers := make([]*KMPMatcher, len(patterns))
+		for i, pat := range patterns {
+			matchers[i] = NewKMP(pat)
+		}
+		b.SetBytes(int64(len(text)))
+		for i := 0; i < b.N; i++ {
+			for _, k := range matchers {
+				k.FindAll(text)
+			}
+		}
+	})
+}
+
+// findAllOverlapping is a naive implementation of Overlapping search used to check the automaton.
+// At each offset the longer patterns are reported first.
+func findAllOverlapping(s string, patterns []string) []Match {
+	patterns = uniqueByLength(patterns)
+	var matches []Match
+	for end := 1; end <= len(s); end++ {
+		for _, pat := range patterns {
+			if strings.HasSuffix(s[:end], pat) {
+				matches = append(matches, Match{pat, int64(end - len(pat))})
+			}
+		}
+	}
+	return matches
+}
+
+// findAllLeftmostLongest is a naive implementation of LeftmostLongest search used to check the automaton.
+func findAllLeftmostLongest(s string, patterns []string) []Match {
+	patterns = uniqueByLength(patterns)
+	var matches []Match
+	for i := 0; i < len(s); {
+		found := false
+		for _, pat := range patterns {
+			if strings.HasPrefix(s[i:], pat) {
+				matches = append(matches, Match{pat, int64(i)})
+				i += len(pat)
+				found = true
+				break
+			}
+		}
+		if !found {
+			i++
+		}
+	}
+	return matches
+}
+
+// uniqueByLength returns distinct patterns ordered from the longest to the shortest.
+func uniqueByLength(patterns []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, pat := range patterns {
+		if !seen[pat] {
+			seen[pat] = true
+			unique = append(unique, pat)
+		}
+	}
+	sort.Slice(unique, func(i, j int) bool {
+		return len(unique[i]) > len(unique[j])
+	})
+	return unique
+}
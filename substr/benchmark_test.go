@@ -0,0 +1,91 @@
+package substr
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+var benchmarks = []struct {
+	name    string
+	text    string
+	pattern string
+}{
+	// Random text over a large alphabet is a typical case: most compares fail on the first character.
+	{"random", randomString(rand.New(rand.NewSource(1)), "ABCDEFGHIJKLMNOPQRSTUVWXYZ", 1<<20), "NEEDLEINAHAYSTACK"},
+	// Repetitive text is the worst case for brute-force search.
+	{"repetitive", strings.Repeat("A", 1<<20), strings.Repeat("A", 100) + "B"},
+}
+
+func benchmarkSearch(b *testing.B, search func(s, pat string) int) {
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			b.SetBytes(int64(len(bm.text)))
+			for i := 0; i < b.N; i++ {
+				search(bm.text, bm.pattern)
+			}
+		})
+	}
+}
+
+func BenchmarkBruteforce(b *testing.B) {
+	benchmarkSearch(b, Bruteforce)
+}
+
+func BenchmarkStringsIndex(b *testing.B) {
+	benchmarkSearch(b, strings.Index)
+}
+
+func BenchmarkKMP(b *testing.B) {
+	benchmarkSearch(b, KMP)
+}
+
+func BenchmarkBoyerMoore(b *testing.B) {
+	benchmarkSearch(b, BoyerMoore)
+}
+
+func BenchmarkRabinKarp(b *testing.B) {
+	benchmarkSearch(b, RabinKarp)
+}
+
+func BenchmarkRabinKarpLasVegas(b *testing.B) {
+	benchmarkSearch(b, RabinKarpLasVegas)
+}
+
+func BenchmarkBitap(b *testing.B) {
+	benchmarkSearch(b, Bitap)
+}
+
+func BenchmarkBitapApprox(b *testing.B) {
+	benchmarkSearch(b, func(s, pat string) int { return BitapApprox(s, pat, 2) })
+}
+
+func BenchmarkKMPReader(b *testing.B) {
+	for _, bm := range benchmarks {
+		b.Run(bm.name, func(b *testing.B) {
+			k := NewKMP(bm.pattern)
+			b.SetBytes(int64(len(bm.text)))
+			for i := 0; i < b.N; i++ {
+				if _, err := k.IndexReader(strings.NewReader(bm.text)); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkBoyerMooreFindAllPeriodic is the worst case for FindAll without Galil rule:
+// the pattern occurs at every offset, so each occurrence would cost m compares.
+func BenchmarkBoyerMooreFindAllPeriodic(b *testing.B) {
+	bm := NewBoyerMoore(strings.Repeat("a", 2000))
+	for _, n := range []int{1 << 14, 1 << 15} {
+		s := strings.Repeat("a", n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.SetBytes(int64(n))
+			for i := 0; i < b.N; i++ {
+				bm.FindAll(s)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package substr
+
+// bitapMaxLen is the max pattern length for Bitap algorithm, so the pattern fits in a machine word.
+const bitapMaxLen = 64
+
+/*
+Bitap (shift-or) finds the first occurrence of a pattern string pat in a text string s.
+It simulates NFA recognizing the pattern with bit-parallelism:
+j-th bit of the state is 0 if the first j+1 pattern characters match the text ending at i-th character.
+Reading a text character shifts the state to the left (advances all partial matches)
+and turns on (ORs) the bits of the positions where the pattern doesn't have that character.
+A match is found when the bit of the last pattern character is 0.
+
+It takes n word operations regardless of the text and the pattern,
+but the pattern must fit in a word (64 bytes), a longer pattern is searched with KMP.
+*/
+func Bitap(s, pat string) int {
+	m := len(pat)
+	if m == 0 {
+		return 0
+	}
+	if m > bitapMaxLen {
+		return KMP(s, pat)
+	}
+
+	mask := bitapMask(pat)
+	state := ^uint64(0)
+	for i := 0; i < len(s); i++ {
+		state = state<<1 | mask[s[i]]
+		if state&(1<<(m-1)) == 0 {
+			return i - m + 1
+		}
+	}
+	return -1
+}
+
+// bitapMask returns the bitmasks for each byte value where j-th bit is 0 if pat[j] is that byte.
+func bitapMask(pat string) *[radix]uint64 {
+	var mask [radix]uint64
+	for c := range mask {
+		mask[c] = ^uint64(0)
+	}
+	for j := 0; j < len(pat); j++ {
+		mask[pat[j]] &^= 1 << j
+	}
+	return &mask
+}
+
+/*
+BitapApprox finds the first substring of s within k edits (Levenshtein distance)
+of a pattern pat and returns the offset where it ends, or -1 if there is none (or k is negative).
+The end is reported because substrings starting at different offsets might be within k edits,
+e.g., "bc" and "abc" both end at 3 in "abcd" and they're within 1 edit of "bc".
+
+Wu-Manber algorithm keeps k+1 shift-or states: the state r[d] tracks partial matches with up to d edits.
+On each text character, r[d] is the union of
+
+	r[d] advanced on a matching character
+	r[d-1] before the character (the character is inserted into the text)
+	r[d-1] before the character advanced on any character (the pattern character is substituted)
+	r[d-1] after the character advanced on any character (the pattern character is deleted)
+
+It takes k*n word operations. Patterns longer than 64 bytes are searched with Sellers algorithm
+in m*n time which fills a column of the edit distance table per text character.
+*/
+func BitapApprox(s, pat string, k int) int {
+	m := len(pat)
+	if k < 0 {
+		return -1
+	}
+	if m <= k {
+		return 0
+	}
+	if m > bitapMaxLen {
+		return sellers(s, pat, k)
+	}
+
+	mask := bitapMask(pat)
+	// Initially the first d pattern characters can be deleted.
+	r := make([]uint64, k+1)
+	for d := range r {
+		r[d] = ^uint64(0) << d
+	}
+	found := uint64(1) << (m - 1)
+	for i := 0; i < len(s); i++ {
+		prev := r[0]
+		r[0] = r[0]<<1 | mask[s[i]]
+		for d := 1; d <= k; d++ {
+			old := r[d]
+			r[d] = (old<<1 | mask[s[i]]) & prev & (prev << 1) & (r[d-1] << 1)
+			prev = old
+		}
+		if r[k]&found == 0 {
+			return i + 1
+		}
+	}
+	return -1
+}
+
+// sellers is like BitapApprox, but it computes the edit distance table column by column.
+// col[j] is the min edit distance between the first j pattern characters
+// and a substring of the text ending at the current character.
+func sellers(s, pat string, k int) int {
+	m := len(pat)
+	col := make([]int, m+1)
+	for j := range col {
+		col[j] = j
+	}
+	if col[m] <= k {
+		return 0
+	}
+	for i := 0; i < len(s); i++ {
+		// A match can start anywhere, so diag is 0 for the empty pattern prefix.
+		diag := col[0]
+		for j := 1; j <= m; j++ {
+			cost := 1
+			if pat[j-1] == s[i] {
+				cost = 0
+			}
+			diag, col[j] = col[j], min(col[j]+1, col[j-1]+1, diag+cost)
+		}
+		if col[m] <= k {
+			return i + 1
+		}
+	}
+	return -1
+}
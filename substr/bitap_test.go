@@ -0,0 +1,63 @@
+package substr
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestBitapApprox(t *testing.T) {
+	tests := []struct {
+		text    string
+		pattern string
+		k       int
+		want    int
+	}{
+		{"ABACADABRA", "ABRA", 0, 10},
+		{"ABACADABRA", "ABRA", 1, 3},
+		{"ABACADABRA", "ADRA", 1, 7},
+		{"ABACADABRA", "CADDAB", 1, 8},
+		{"ABACADABRA", "XYZ", 2, -1},
+		{"ABACADABRA", "XYZ", 3, 0},
+		{"ABACADABRA", "", 0, 0},
+		{"", "A", 0, -1},
+		{"", "A", 1, 0},
+		{"the quick brown fox", "quikc", 0, -1},
+		{"the quick brown fox", "quikc", 1, 8},
+		{"the quick brown fox", "quikc", 2, 7},
+		{"the quick brown fox", "brwn", 1, 15},
+		{"ABACADABRA", "ABRA", -1, -1},
+		{"ABACADABRA", "", -1, -1},
+	}
+	for _, tc := range tests {
+		if got := BitapApprox(tc.text, tc.pattern, tc.k); got != tc.want {
+			t.Errorf("BitapApprox(%q, %q, %d) = %d, want %d", tc.text, tc.pattern, tc.k, got, tc.want)
+		}
+		if got := sellers(tc.text, tc.pattern, tc.k); got != tc.want {
+			t.Errorf("sellers(%q, %q, %d) = %d, want %d", tc.text, tc.pattern, tc.k, got, tc.want)
+		}
+	}
+}
+
+func TestBitapApproxRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 2000; i++ {
+		s := randomString(r, "ab", r.Intn(30))
+		pat := randomString(r, "abc", 1+r.Intn(8))
+		k := r.Intn(4)
+		if got, want := BitapApprox(s, pat, k), sellers(s, pat, k); got != want {
+			t.Fatalf("BitapApprox(%q, %q, %d) = %d, want %d", s, pat, k, got, want)
+		}
+	}
+}
+
+func TestBitapLongPattern(t *testing.T) {
+	pat := strings.Repeat("ab", 40)
+	s := "xx" + pat[:30] + "c" + pat[31:] + "xx"
+	if got := Bitap(s, pat); got != -1 {
+		t.Errorf("Bitap() = %d, want -1", got)
+	}
+	if got, want := BitapApprox(s, pat, 1), len(s)-2; got != want {
+		t.Errorf("BitapApprox() = %d, want %d", got, want)
+	}
+}
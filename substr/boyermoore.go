@@ -0,0 +1,131 @@
+package substr
+
+// BoyerMoore finds the first occurrence of a pattern string pat in a text string s
+// using Boyer-Moore algorithm, see BoyerMooreMatcher.
+func BoyerMoore(s, pat string) int {
+	return NewBoyerMoore(pat).Index(s)
+}
+
+/*
+BoyerMooreMatcher searches for the pattern with Boyer-Moore algorithm.
+It scans the pattern from right to left when trying to match it against the text,
+and on a mismatch it skips ahead as far as two heuristics allow:
+
+	bad-character rule aligns the mismatched text character with its rightmost occurrence in the pattern
+	good-suffix rule aligns the already matched suffix with its next occurrence in the pattern
+
+In typical texts (when the pattern is long and the alphabet is large),
+it uses ~n/m character compares to search for a pattern of length m in a text of length n.
+With the good-suffix rule Index takes linear time even in the worst case.
+FindAll also needs Galil rule to stay linear when the pattern is periodic (e.g., "aaa" in "aaaaaa"),
+otherwise each of ~n occurrences would be compared against all m pattern characters.
+*/
+type BoyerMooreMatcher struct {
+	pat string
+	// right[c] is the index of the rightmost occurrence of byte c in the pattern, or -1 if there is none.
+	right [radix]int
+	// shift[j] is how far the pattern can be moved when pat[j:] suffix matched the text
+	// (the mismatch occurred at j-1). The shift[0] is the period of the pattern.
+	shift []int
+}
+
+// NewBoyerMoore precomputes the bad-character and good-suffix tables for the pattern.
+func NewBoyerMoore(pat string) *BoyerMooreMatcher {
+	m := len(pat)
+	b := BoyerMooreMatcher{
+		pat:   pat,
+		shift: make([]int, m+1),
+	}
+	for c := range b.right {
+		b.right[c] = -1
+	}
+	for j := 0; j < m; j++ {
+		b.right[pat[j]] = j
+	}
+
+	// border[i] is the start of the widest border of pat[i:] suffix,
+	// i.e., the longest proper suffix of pat[i:] that is also its prefix.
+	border := make([]int, m+1)
+	// The matched suffix occurs somewhere else in the pattern preceded by a different character.
+	i, j := m, m+1
+	border[i] = j
+	for i > 0 {
+		for j <= m && pat[i-1] != pat[j-1] {
+			if b.shift[j] == 0 {
+				b.shift[j] = j - i
+			}
+			j = border[j]
+		}
+		i--
+		j--
+		border[i] = j
+	}
+	// Only a part of the matched suffix occurs at the beginning of the pattern.
+	j = border[0]
+	for i = 0; i <= m; i++ {
+		if b.shift[i] == 0 {
+			b.shift[i] = j
+		}
+		if i == j {
+			j = border[j]
+		}
+	}
+	return &b
+}
+
+// skip returns how far the pattern can be moved when it's aligned at offset i of the text s
+// and there is a mismatch at pattern position j.
+func (b *BoyerMooreMatcher) skip(s string, i, j int) int {
+	return max(b.shift[j+1], j-b.right[s[i+j]])
+}
+
+// Index returns the offset of the first occurrence of the pattern in s, or -1 if there is none.
+func (b *BoyerMooreMatcher) Index(s string) int {
+	m := len(b.pat)
+	for i := 0; i <= len(s)-m; {
+		j := m - 1
+		for j >= 0 && b.pat[j] == s[i+j] {
+			j--
+		}
+		if j < 0 {
+			return i
+		}
+		i += b.skip(s, i, j)
+	}
+	return -1
+}
+
+/*
+FindAll returns the offsets of all the occurrences of the pattern in s including the overlapping ones.
+
+After an occurrence is found, the pattern is moved by its period p,
+since the next occurrence can't start earlier.
+Galil rule: the first m-p characters of the pattern are then known to match the text
+(they're equal to the last m-p characters of the previous occurrence), so they aren't compared again.
+Any mismatch resets that knowledge.
+*/
+func (b *BoyerMooreMatcher) FindAll(s string) []int {
+	m := len(b.pat)
+	if m == 0 {
+		return allOffsets(len(s))
+	}
+
+	var offsets []int
+	// known is the length of the pattern prefix known to match the text at the current offset.
+	known := 0
+	for i := 0; i <= len(s)-m; {
+		j := m - 1
+		for j >= known && b.pat[j] == s[i+j] {
+			j--
+		}
+		if j < known {
+			offsets = append(offsets, i)
+			i += b.shift[0]
+			known = m - b.shift[0]
+			continue
+		}
+		i += b.skip(s, i, j)
+		known = 0
+	}
+	return offsets
+}
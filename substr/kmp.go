@@ -0,0 +1,176 @@
+package substr
+
+import (
+	"bufio"
+	"io"
+)
+
+// radix is the number of possible characters (bytes) in the text.
+const radix = 256
+
+// KMP finds the first occurrence of a pattern string pat in a text string s
+// using Knuth-Morris-Pratt algorithm, see KMPMatcher.
+func KMP(s, pat string) int {
+	return NewKMP(pat).Index(s)
+}
+
+/*
+KMPMatcher searches for the pattern with Knuth-Morris-Pratt algorithm.
+The basic idea is that whenever we detect a mismatch, we already know some of the characters in the text
+(since they matched the pattern characters prior to the mismatch).
+This information is precomputed in a deterministic finite-state automaton (DFA):
+the number of pattern characters matched so far is a state, and dfa[j][c] is the next state
+after reading the text character c in state j.
+
+The search never backs up in the text, so it can read a text stream (see IndexReader).
+It accesses no more than m+n characters to search for a pattern of length m in a text of length n,
+and the DFA takes space proportional to R*m where R is the alphabet size (256 bytes).
+*/
+type KMPMatcher struct {
+	pat string
+	dfa [][radix]int32
+	// restart is the state to continue the search from after the pattern was found.
+	restart int32
+}
+
+/*
+NewKMP builds the DFA for the pattern.
+The state j means that j pattern characters matched, so on a match of pat[j] the next state is j+1.
+On a mismatch, the DFA goes where it would be if it had backed up in the text and rescanned
+the text characters pat[1:j] followed by c. Rather than rescanning, the DFA maintains
+restart state x which is the state after reading pat[1:j], so dfa[j][c] = dfa[x][c] for mismatches.
+*/
+func NewKMP(pat string) *KMPMatcher {
+	m := len(pat)
+	k := KMPMatcher{
+		pat: pat,
+		dfa: make([][radix]int32, m),
+	}
+	if m == 0 {
+		return &k
+	}
+
+	k.dfa[0][pat[0]] = 1
+	var x int32
+	for j := 1; j < m; j++ {
+		// Copy mismatch cases.
+		k.dfa[j] = k.dfa[x]
+		// Set match case.
+		k.dfa[j][pat[j]] = int32(j + 1)
+		// Update restart state.
+		x = k.dfa[x][pat[j]]
+	}
+	k.restart = x
+	return &k
+}
+
+// next returns the state after reading the character c in the state j.
+// After a full match, it continues from the restart state as if the last character was mismatched.
+func (k *KMPMatcher) next(j int32, c byte) int32 {
+	if int(j) == len(k.pat) {
+		j = k.restart
+	}
+	return k.dfa[j][c]
+}
+
+// Index returns the offset of the first occurrence of the pattern in s, or -1 if there is none.
+func (k *KMPMatcher) Index(s string) int {
+	m := int32(len(k.pat))
+	if m == 0 {
+		return 0
+	}
+
+	var j int32
+	for i := 0; i < len(s); i++ {
+		j = k.dfa[j][s[i]]
+		if j == m {
+			return i - int(m) + 1
+		}
+	}
+	return -1
+}
+
+// FindAll returns the offsets of all the occurrences of the pattern in s including the overlapping ones.
+func (k *KMPMatcher) FindAll(s string) []int {
+	m := int32(len(k.pat))
+	if m == 0 {
+		return allOffsets(len(s))
+	}
+
+	var (
+		offsets []int
+		j       int32
+	)
+	for i := 0; i < len(s); i++ {
+		if j = k.next(j, s[i]); j == m {
+			offsets = append(offsets, i-int(m)+1)
+		}
+	}
+	return offsets
+}
+
+// IndexReader returns the offset of the first occurrence of the pattern in the stream, or -1 if there is none.
+// If r implements io.ByteReader, it is consumed exactly up to the end of the first occurrence.
+// Otherwise r is wrapped in a bufio.Reader, so it may be read past the occurrence by up to 4096 bytes.
+func (k *KMPMatcher) IndexReader(r io.Reader) (int64, error) {
+	var offset int64 = -1
+	err := k.scan(r, func(i int64) bool {
+		offset = i
+		return false
+	})
+	return offset, err
+}
+
+// FindAllReader returns the offsets of all the occurrences of the pattern in the stream
+// including the overlapping ones. Like FindAll, the empty pattern occurs at every offset from 0 to the stream length.
+func (k *KMPMatcher) FindAllReader(r io.Reader) ([]int64, error) {
+	var offsets []int64
+	err := k.scan(r, func(i int64) bool {
+		offsets = append(offsets, i)
+		return true
+	})
+	return offsets, err
+}
+
+// scan reads the stream byte by byte calling fn with an offset of each occurrence of the pattern
+// until fn returns false.
+func (k *KMPMatcher) scan(r io.Reader, fn func(offset int64) bool) error {
+	m := int32(len(k.pat))
+	// The empty pattern occurs before the first byte and after each byte read.
+	if m == 0 && !fn(0) {
+		return nil
+	}
+
+	br, ok := r.(io.ByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	var j int32
+	for i := int64(0); ; i++ {
+		c, err := br.ReadByte()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if m == 0 {
+			if !fn(i + 1) {
+				return nil
+			}
+			continue
+		}
+		if j = k.next(j, c); j == m && !fn(i-int64(m)+1) {
+			return nil
+		}
+	}
+}
+
+// allOffsets returns every offset of the text of length n where an empty pattern matches.
+func allOffsets(n int) []int {
+	offsets := make([]int, n+1)
+	for i := range offsets {
+		offsets[i] = i
+	}
+	return offsets
+}
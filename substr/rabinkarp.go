@@ -0,0 +1,146 @@
+package substr
+
+import (
+	"math/big"
+	"math/rand"
+)
+
+// RabinKarp finds the first occurrence of a pattern string pat in a text string s
+// using Monte Carlo version of Rabin-Karp algorithm, see RabinKarpMatcher.
+func RabinKarp(s, pat string) int {
+	return NewRabinKarp(pat).Index(s)
+}
+
+// RabinKarpLasVegas finds the first occurrence of a pattern string pat in a text string s
+// using Las Vegas version of Rabin-Karp algorithm, see RabinKarpMatcher.
+func RabinKarpLasVegas(s, pat string) int {
+	return NewRabinKarp(pat, WithLasVegas()).Index(s)
+}
+
+/*
+RabinKarpMatcher searches for the pattern with Rabin-Karp fingerprint algorithm based on hashing.
+It computes a hash of the pattern and then looks for a match by using the same hash function
+for each possible m-character substring of the text.
+The hash of the next substring is computed in constant time from the previous one (rolling hash):
+the string of m characters is treated as an m-digit base-R number modulo a large prime q,
+so the leading digit is subtracted, and the trailing one is added.
+
+Monte Carlo version reports a match when the hashes are equal,
+and it's correct with very high probability: a collision occurs with probability ~1/q.
+Las Vegas version checks that the substring is equal to the pattern when the hashes match,
+so it's always correct, but it might take m*n time in the worst case (virtually never).
+
+The running time is linear, but the inner loop has several arithmetic operations
+as opposed to character compares in the other methods.
+*/
+type RabinKarpMatcher struct {
+	pat string
+	// patHash is the hash of the pattern.
+	patHash uint64
+	// q is a large prime modulus.
+	q uint64
+	// rm is R^(m-1) % q used to remove the leading digit.
+	rm uint64
+	// lasVegas indicates whether the hash matches are verified.
+	lasVegas bool
+}
+type rabinKarpOption func(*RabinKarpMatcher)
+
+// WithLasVegas makes the matcher verify hash matches by comparing the pattern with the text.
+func WithLasVegas() rabinKarpOption {
+	return func(rk *RabinKarpMatcher) {
+		rk.lasVegas = true
+	}
+}
+
+// withPrime sets the modulus of the hash function, a small prime makes collisions likely (used in tests).
+func withPrime(q uint64) rabinKarpOption {
+	return func(rk *RabinKarpMatcher) {
+		rk.q = q
+	}
+}
+
+// NewRabinKarp computes the hash of the pattern using a random 31-bit prime as the modulus,
+// so an adversary can't come up with a text that causes collisions.
+func NewRabinKarp(pat string, options ...rabinKarpOption) *RabinKarpMatcher {
+	rk := RabinKarpMatcher{
+		pat: pat,
+		q:   randomPrime(),
+		rm:  1,
+	}
+	for _, opt := range options {
+		opt(&rk)
+	}
+
+	for i := 1; i < len(pat); i++ {
+		rk.rm = (radix * rk.rm) % rk.q
+	}
+	rk.patHash = rk.hash(pat)
+	return &rk
+}
+
+// randomPrime returns a random 31-bit prime.
+// Multiplying it by the radix never overflows uint64.
+func randomPrime() uint64 {
+	for {
+		p := uint64(rand.Int63n(1<<31-1<<30) + 1<<30)
+		if big.NewInt(int64(p)).ProbablyPrime(20) {
+			return p
+		}
+	}
+}
+
+// hash computes the hash of the string using Horner's method.
+func (rk *RabinKarpMatcher) hash(s string) uint64 {
+	var h uint64
+	for i := 0; i < len(s); i++ {
+		h = (radix*h + uint64(s[i])) % rk.q
+	}
+	return h
+}
+
+// scan calls fn with an offset of each substring of s whose hash matches the pattern's hash
+// (and it's equal to the pattern in Las Vegas version) until fn returns false.
+func (rk *RabinKarpMatcher) scan(s string, fn func(offset int) bool) {
+	m := len(rk.pat)
+	if len(s) < m {
+		return
+	}
+
+	h := rk.hash(s[:m])
+	for i := 0; ; i++ {
+		if h == rk.patHash && (!rk.lasVegas || s[i:i+m] == rk.pat) && !fn(i) {
+			return
+		}
+		if i+m == len(s) {
+			return
+		}
+		// Remove the leading digit, add the trailing digit.
+		h = (h + rk.q - rk.rm*uint64(s[i])%rk.q) % rk.q
+		h = (h*radix + uint64(s[i+m])) % rk.q
+	}
+}
+
+// Index returns the offset of the first occurrence of the pattern in s, or -1 if there is none.
+func (rk *RabinKarpMatcher) Index(s string) int {
+	offset := -1
+	rk.scan(s, func(i int) bool {
+		offset = i
+		return false
+	})
+	return offset
+}
+
+// FindAll returns the offsets of all the occurrences of the pattern in s including the overlapping ones.
+func (rk *RabinKarpMatcher) FindAll(s string) []int {
+	if rk.pat == "" {
+		return allOffsets(len(s))
+	}
+
+	var offsets []int
+	rk.scan(s, func(i int) bool {
+		offsets = append(offsets, i)
+		return true
+	})
+	return offsets
+}
@@ -0,0 +1,220 @@
+package substr
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/iotest"
+)
+
+// algorithms are substring search functions with the same signature as Bruteforce.
+var algorithms = []struct {
+	name   string
+	search func(s, pat string) int
+}{
+	{"Bruteforce", Bruteforce},
+	{"KMP", KMP},
+	{"BoyerMoore", BoyerMoore},
+	{"RabinKarp", RabinKarp},
+	{"RabinKarpLasVegas", RabinKarpLasVegas},
+	{"Bitap", Bitap},
+}
+
+// matchers are substring search algorithms that can find all occurrences of a pattern.
+var matchers = []struct {
+	name    string
+	findAll func(s, pat string) []int
+}{
+	{"KMP", func(s, pat string) []int { return NewKMP(pat).FindAll(s) }},
+	{"BoyerMoore", func(s, pat string) []int { return NewBoyerMoore(pat).FindAll(s) }},
+	{"RabinKarp", func(s, pat string) []int { return NewRabinKarp(pat).FindAll(s) }},
+	{"RabinKarpLasVegas", func(s, pat string) []int { return NewRabinKarp(pat, WithLasVegas()).FindAll(s) }},
+}
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		text    string
+		pattern string
+		want    int
+	}{
+		{"ABACADABRA", "ABRA", 6},
+		{"ABACADABRA", "AB", 0},
+		{"ABACADABRA", "ABACADABRA", 0},
+		{"ABACADABRA", "ABACADABRAC", -1},
+		{"ABACADABRA", "", 0},
+		{"", "A", -1},
+		{"AAAAAAAAAB", "AAAAB", 5},
+		{"BCBAABACAABABACAA", "ABABAC", 9},
+		{"FINDINAHAYSTACKNEEDLEINA", "NEEDLE", 15},
+		{"日本語", "語", 6},
+		{"日本語", "👩", -1},
+	}
+
+	for _, alg := range algorithms {
+		for _, tc := range tests {
+			got := alg.search(tc.text, tc.pattern)
+			if got != tc.want {
+				t.Errorf("%s(%q, %q) = %d, want %d", alg.name, tc.text, tc.pattern, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestSearchRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		text := randomString(r, "AB", r.Intn(50))
+		pat := randomString(r, "AB", 1+r.Intn(5))
+		want := strings.Index(text, pat)
+		for _, alg := range algorithms {
+			if got := alg.search(text, pat); got != want {
+				t.Fatalf("%s(%q, %q) = %d, want %d", alg.name, text, pat, got, want)
+			}
+		}
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	tests := []struct {
+		text    string
+		pattern string
+		want    []int
+	}{
+		{"ABACADABRA", "ABRA", []int{6}},
+		{"ABACADABRA", "A", []int{0, 2, 4, 6, 9}},
+		{"AAAAA", "AA", []int{0, 1, 2, 3}},
+		{"ABABABA", "ABA", []int{0, 2, 4}},
+		{"ABABABCABABAB", "ABAB", []int{0, 2, 7, 9}},
+		{"AABAABAAABAAB", "AABAAB", []int{0, 7}},
+		{"ABC", "", []int{0, 1, 2, 3}},
+		{"ABC", "D", nil},
+		{"", "D", nil},
+	}
+
+	for _, m := range matchers {
+		for _, tc := range tests {
+			got := m.findAll(tc.text, tc.pattern)
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("%s(%q).FindAll(%q) = %v, want %v", m.name, tc.pattern, tc.text, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestFindAllRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		text := randomString(r, "ABC", r.Intn(100))
+		pat := randomString(r, "ABC", 1+r.Intn(4))
+		if i%2 == 0 {
+			// Periodic texts and patterns have many overlapping occurrences.
+			text = strings.Repeat(randomString(r, "AB", 1+r.Intn(3)), r.Intn(30))
+			pat = text[:min(len(text), 1+r.Intn(10))]
+		}
+		want := findAll(text, pat)
+		for _, m := range matchers {
+			if got := m.findAll(text, pat); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s(%q).FindAll(%q) = %v, want %v", m.name, pat, text, got, want)
+			}
+		}
+	}
+}
+
+func TestKMPReader(t *testing.T) {
+	tests := []struct {
+		text    string
+		pattern string
+		want    []int64
+	}{
+		{"ABACADABRA", "ABRA", []int64{6}},
+		{"AAAAA", "AA", []int64{0, 1, 2, 3}},
+		{"ABC", "D", nil},
+		{"ABC", "", []int64{0, 1, 2, 3}},
+		{"", "", []int64{0}},
+	}
+
+	for _, tc := range tests {
+		k := NewKMP(tc.pattern)
+
+		// OneByteReader makes sure the search doesn't depend on how the text is chunked.
+		got, err := k.FindAllReader(iotest.OneByteReader(strings.NewReader(tc.text)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("KMP(%q).FindAllReader(%q) = %v, want %v", tc.pattern, tc.text, got, tc.want)
+		}
+
+		var want int64 = -1
+		if tc.want != nil {
+			want = tc.want[0]
+		}
+		offset, err := k.IndexReader(strings.NewReader(tc.text))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if offset != want {
+			t.Errorf("KMP(%q).IndexReader(%q) = %d, want %d", tc.pattern, tc.text, offset, want)
+		}
+	}
+}
+
+func TestKMPReaderStopsAtMatch(t *testing.T) {
+	r := bytes.NewReader([]byte("needle in a haystack"))
+	offset, err := NewKMP("needle").IndexReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if offset != 0 {
+		t.Errorf("IndexReader() = %d, want 0", offset)
+	}
+	if rest, _ := io.ReadAll(r); string(rest) != " in a haystack" {
+		t.Errorf("IndexReader() left %q unread, want %q", rest, " in a haystack")
+	}
+}
+
+func TestKMPReaderError(t *testing.T) {
+	errRead := errors.New("read failed")
+	r := io.MultiReader(strings.NewReader("ABA"), iotest.ErrReader(errRead))
+	if _, err := NewKMP("ABAB").IndexReader(r); !errors.Is(err, errRead) {
+		t.Errorf("IndexReader() error = %v, want %v", err, errRead)
+	}
+}
+
+func TestRabinKarpCollision(t *testing.T) {
+	// With q=3 hashes of "AB" and "BA" collide: (65*256+66)%3 == (66*256+65)%3 == 2.
+	text, pat := "ABBA", "BA"
+
+	mc := NewRabinKarp(pat, withPrime(3))
+	if got := mc.Index(text); got != 0 {
+		t.Errorf("Monte Carlo Index(%q) = %d, want false positive 0", text, got)
+	}
+
+	lv := NewRabinKarp(pat, withPrime(3), WithLasVegas())
+	if got := lv.Index(text); got != 2 {
+		t.Errorf("Las Vegas Index(%q) = %d, want 2", text, got)
+	}
+}
+
+func randomString(r *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
+
+// findAll is a naive implementation of FindAll used to check the algorithms.
+func findAll(s, pat string) []int {
+	var offsets []int
+	for i := 0; i+len(pat) <= len(s); i++ {
+		if s[i:i+len(pat)] == pat {
+			offsets = append(offsets, i)
+		}
+	}
+	return offsets
+}
@@ -0,0 +1,85 @@
+package suffixarray
+
+/*
+PrefixDoubling returns the suffix array of the text built with Manber-Myers algorithm.
+It sorts the suffixes by their first character, then by the first 2, 4, 8, and so forth characters.
+The suffixes are ranked after each pass, so the order by the first 2*k characters
+is the order of pairs (rank of the first k characters, rank of the next k characters)
+which is found by two stable key-indexed counting sorts.
+Each pass takes linear time, and there are at most log n passes,
+so the running time is proportional to n*log n regardless of how repetitive the text is.
+*/
+func PrefixDoubling(text string) []int {
+	n := len(text)
+	sa := make([]int, n)
+	if n == 0 {
+		return sa
+	}
+
+	// rank[i] is the rank of the suffix i by its first k characters.
+	rank := make([]int, n)
+	for i := 0; i < n; i++ {
+		sa[i] = i
+		rank[i] = int(text[i])
+	}
+	count := make([]int, max(n, 256)+1)
+	tmp := make([]int, n)
+	countingSort(sa, tmp, rank, count)
+
+	for k := 1; ; k *= 2 {
+		// Order the suffixes by the second key: the suffixes shorter than k have no second key,
+		// so they go first, and then the suffixes i-k in order of the suffixes i.
+		j := 0
+		for i := n - k; i < n; i++ {
+			tmp[j] = i
+			j++
+		}
+		for _, i := range sa {
+			if i >= k {
+				tmp[j] = i - k
+				j++
+			}
+		}
+		// Stable sort by the first key.
+		copy(sa, tmp)
+		countingSort(sa, tmp, rank, count)
+
+		// Rerank the suffixes by their first 2*k characters.
+		tmp[sa[0]] = 0
+		for i := 1; i < n; i++ {
+			tmp[sa[i]] = tmp[sa[i-1]]
+			if rank[sa[i]] != rank[sa[i-1]] || secondRank(rank, sa[i], k) != secondRank(rank, sa[i-1], k) {
+				tmp[sa[i]]++
+			}
+		}
+		rank, tmp = tmp, rank
+		if rank[sa[n-1]] == n-1 {
+			return sa
+		}
+	}
+}
+
+// secondRank returns the rank of the suffix i+k, or -1 if the suffix i is shorter than k.
+func secondRank(rank []int, i, k int) int {
+	if i+k < len(rank) {
+		return rank[i+k]
+	}
+	return -1
+}
+
+// countingSort stably sorts the suffixes by their ranks using key-indexed counting.
+// The aux slice is used to distribute the suffixes, and count must have more elements than the max rank.
+func countingSort(sa, aux, rank, count []int) {
+	clear(count)
+	for _, i := range sa {
+		count[rank[i]+1]++
+	}
+	for r := 1; r < len(count); r++ {
+		count[r] += count[r-1]
+	}
+	for _, i := range sa {
+		aux[count[rank[i]]] = i
+		count[rank[i]]++
+	}
+	copy(sa, aux)
+}
@@ -0,0 +1,205 @@
+package suffixarray
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"math/bits"
+	"unsafe"
+)
+
+/*
+The binary index format consists of a header followed by the text, the suffix array, and the LCP array.
+The integers are little-endian, and the arrays are aligned to 8 bytes,
+so a memory-mapped index can be used without decoding on 64-bit little-endian platforms.
+
+	magic     4 bytes "SAIX"
+	version   uint32
+	n         uint64 text length in bytes
+	checksum  uint32 CRC-32 (Castagnoli) of the text, padding, and arrays
+	reserved  uint32
+	text      n bytes followed by zero padding to a multiple of 8 bytes
+	sa        n uint64
+	lcp       n uint64
+*/
+const (
+	indexMagic   = "SAIX"
+	indexVersion = 1
+	headerSize   = 24
+)
+
+var (
+	// ErrFormat indicates that the data is not a suffix array index.
+	ErrFormat = errors.New("suffixarray: invalid index format")
+	// ErrChecksum indicates that the index is corrupted.
+	ErrChecksum = errors.New("suffixarray: index checksum mismatch")
+
+	crcTable = crc32.MakeTable(crc32.Castagnoli)
+)
+
+// padding returns the number of zero bytes after the text of length n to align the arrays.
+func padding(n int) int {
+	return (8 - n%8) % 8
+}
+
+// WriteTo writes the index in the binary format to w.
+// It implements io.WriterTo interface.
+func (ki *KeywordIndex) WriteTo(w io.Writer) (int64, error) {
+	n := len(ki.text)
+	crc := crc32.New(crcTable)
+	body := func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		if _, err := io.WriteString(bw, ki.text); err != nil {
+			return err
+		}
+		if _, err := bw.Write(make([]byte, padding(n))); err != nil {
+			return err
+		}
+		var buf [8]byte
+		for _, a := range [][]int{ki.sa, ki.lcp} {
+			for _, v := range a {
+				binary.LittleEndian.PutUint64(buf[:], uint64(v))
+				if _, err := bw.Write(buf[:]); err != nil {
+					return err
+				}
+			}
+		}
+		return bw.Flush()
+	}
+	// The checksum is computed in a separate pass, so the index can be streamed to w.
+	if err := body(crc); err != nil {
+		return 0, err
+	}
+
+	header := make([]byte, headerSize)
+	copy(header, indexMagic)
+	binary.LittleEndian.PutUint32(header[4:], indexVersion)
+	binary.LittleEndian.PutUint64(header[8:], uint64(n))
+	binary.LittleEndian.PutUint32(header[16:], crc.Sum32())
+	cw := countingWriter{w: w}
+	if _, err := cw.Write(header); err != nil {
+		return cw.n, err
+	}
+	err := body(&cw)
+	return cw.n, err
+}
+
+// countingWriter counts the bytes written to w.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// parseHeader validates the header and the size of the data
+// and returns the text length and the checksum.
+func parseHeader(data []byte) (n int, checksum uint32, err error) {
+	if len(data) < headerSize || string(data[:4]) != indexMagic {
+		return 0, 0, ErrFormat
+	}
+	if v := binary.LittleEndian.Uint32(data[4:]); v != indexVersion {
+		return 0, 0, fmt.Errorf("suffixarray: unsupported index version %d", v)
+	}
+
+	size := binary.LittleEndian.Uint64(data[8:])
+	// The text and the arrays take 17 bytes per character (plus padding).
+	if size > uint64(len(data))/17 {
+		return 0, 0, ErrFormat
+	}
+	n = int(size)
+	if len(data) != headerSize+n+padding(n)+16*n {
+		return 0, 0, ErrFormat
+	}
+	return n, binary.LittleEndian.Uint32(data[16:]), nil
+}
+
+// Verify checks the header and the checksum of the index data.
+// It reads the whole index, so it takes time proportional to its size.
+func Verify(data []byte) error {
+	_, checksum, err := parseHeader(data)
+	if err != nil {
+		return err
+	}
+	if crc32.Checksum(data[headerSize:], crcTable) != checksum {
+		return ErrChecksum
+	}
+	return nil
+}
+
+/*
+Load returns the index stored in the data, e.g., a memory-mapped index file.
+On 64-bit little-endian platforms the index refers to the data instead of copying it,
+so the data must not be modified afterwards.
+
+The header is validated, and the arrays are range-checked in a single pass,
+so a corrupted index can't make queries panic (ErrFormat is returned instead).
+The checksum isn't computed, use Verify to detect that the text or the order of suffixes was damaged.
+*/
+func Load(data []byte) (*KeywordIndex, error) {
+	n, _, err := parseHeader(data)
+	if err != nil {
+		return nil, err
+	}
+
+	ki := KeywordIndex{}
+	if n == 0 {
+		return &ki, nil
+	}
+	textStart := headerSize
+	saStart := textStart + n + padding(n)
+	lcpStart := saStart + 8*n
+	ki.text = unsafe.String(&data[textStart], n)
+	ki.sa = loadInts(data[saStart:lcpStart])
+	ki.lcp = loadInts(data[lcpStart:])
+	if !inRange(ki.sa, ki.lcp) {
+		return nil, ErrFormat
+	}
+	return &ki, nil
+}
+
+// inRange reports whether the suffixes are within the text of length n
+// and the common prefix of adjacent suffixes doesn't run past the text end.
+func inRange(sa, lcp []int) bool {
+	n := len(sa)
+	if lcp[0] != 0 {
+		return false
+	}
+	for i, v := range sa {
+		if v < 0 || v >= n {
+			return false
+		}
+		if i > 0 && (lcp[i] < 0 || lcp[i] > n-max(v, sa[i-1])) {
+			return false
+		}
+	}
+	return true
+}
+
+// loadInts returns little-endian 64-bit integers stored in b.
+// The integers are decoded only if the platform's int layout differs or b isn't aligned.
+func loadInts(b []byte) []int {
+	n := len(b) / 8
+	p := unsafe.Pointer(&b[0])
+	if bits.UintSize == 64 && isLittleEndian() && uintptr(p)%8 == 0 {
+		return unsafe.Slice((*int)(p), n)
+	}
+
+	a := make([]int, n)
+	for i := range a {
+		a[i] = int(binary.LittleEndian.Uint64(b[8*i:]))
+	}
+	return a
+}
+
+// isLittleEndian reports whether the platform is little-endian.
+func isLittleEndian() bool {
+	return binary.NativeEndian.Uint16([]byte{1, 0}) == 1
+}
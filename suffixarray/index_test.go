@@ -0,0 +1,128 @@
+package suffixarray
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"slices"
+	"testing"
+)
+
+func TestIndexRoundTrip(t *testing.T) {
+	for _, text := range []string{"", "a", "banana", "it was the best of times it was the"} {
+		want := New(text)
+		var buf bytes.Buffer
+		n, err := want.WriteTo(&buf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n != int64(buf.Len()) {
+			t.Errorf("WriteTo(%q) = %d, wrote %d bytes", text, n, buf.Len())
+		}
+
+		data := buf.Bytes()
+		if err = Verify(data); err != nil {
+			t.Errorf("Verify(%q) error: %v", text, err)
+		}
+		// The unaligned copy of the data makes the index decode the arrays.
+		unaligned := append(make([]byte, 1, len(data)+1), data...)[1:]
+		for _, d := range [][]byte{data, unaligned} {
+			got, err := Load(d)
+			if err != nil {
+				t.Fatalf("Load(%q) error: %v", text, err)
+			}
+			if got.text != want.text || !slices.Equal(got.sa, want.sa) || !slices.Equal(got.lcp, want.lcp) {
+				t.Errorf("Load(%q) = %+v, want %+v", text, got, want)
+			}
+		}
+	}
+}
+
+func TestIndexLoadQuery(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := New("it was the best of times it was the").WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	idx, err := Load(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"the", "the best of times it was the"}
+	if got := idx.Search("th", 100); !reflect.DeepEqual(got, want) {
+		t.Errorf("Search(%q) = %q, want %q", "th", got, want)
+	}
+	if got := idx.Count("was"); got != 2 {
+		t.Errorf("Count(%q) = %d, want 2", "was", got)
+	}
+}
+
+func TestIndexCorrupted(t *testing.T) {
+	var buf bytes.Buffer
+	if _, err := New("banana").WriteTo(&buf); err != nil {
+		t.Fatal(err)
+	}
+	data := buf.Bytes()
+
+	corrupt := func(f func(d []byte) []byte) []byte {
+		d := append([]byte(nil), data...)
+		return f(d)
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"empty", nil, ErrFormat},
+		{"magic", corrupt(func(d []byte) []byte { d[0] = 'X'; return d }), ErrFormat},
+		{"truncated", data[:len(data)-1], ErrFormat},
+		{"length", corrupt(func(d []byte) []byte { d[8] = 7; return d }), ErrFormat},
+		{"huge length", corrupt(func(d []byte) []byte { d[15] = 0xff; return d }), ErrFormat},
+		{"text", corrupt(func(d []byte) []byte { d[headerSize] = 'B'; return d }), ErrChecksum},
+	}
+
+	for _, tc := range tests {
+		if err := Verify(tc.data); !errors.Is(err, tc.want) {
+			t.Errorf("Verify(%s) error = %v, want %v", tc.name, err, tc.want)
+		}
+	}
+
+	// The suffix array of "banana" starts after the padded text, and the LCP array follows it.
+	saStart := headerSize + 8
+	lcpStart := saStart + 8*6
+	loadTests := []struct {
+		name string
+		data []byte
+	}{
+		{"sa too large", corrupt(func(d []byte) []byte { d[saStart] = 0xff; return d })},
+		{"sa negative", corrupt(func(d []byte) []byte { d[saStart+7] = 0x80; return d })},
+		{"lcp too large", corrupt(func(d []byte) []byte { d[lcpStart+8] = 6; return d })},
+		{"lcp first", corrupt(func(d []byte) []byte { d[lcpStart] = 1; return d })},
+	}
+	for _, tc := range loadTests {
+		if _, err := Load(tc.data); err != ErrFormat {
+			t.Errorf("Load(%s) error = %v, want %v", tc.name, err, ErrFormat)
+		}
+	}
+
+	// Swapped suffixes are in range, so queries give wrong answers instead of panicking.
+	swapped := corrupt(func(d []byte) []byte {
+		d[saStart+24], d[saStart+32] = d[saStart+32], d[saStart+24]
+		return d
+	})
+	if err := Verify(swapped); err != ErrChecksum {
+		t.Errorf("Verify(swapped) error = %v, want %v", err, ErrChecksum)
+	}
+	idx, err := Load(swapped)
+	if err != nil {
+		t.Fatalf("Load(swapped) error = %v", err)
+	}
+	for _, q := range []string{"a", "ana", "banana", "nab", "z"} {
+		idx.Count(q)
+	}
+
+	d := corrupt(func(d []byte) []byte { d[4] = 2; return d })
+	if _, err := Load(d); err == nil || err.Error() != "suffixarray: unsupported index version 2" {
+		t.Errorf("Load(version) error = %v, want unsupported version", err)
+	}
+}
@@ -0,0 +1,44 @@
+package suffixarray
+
+/*
+LCP returns the longest common prefix array of the suffix array sa of the text:
+lcp[i] is the length of the longest common prefix of the suffixes sa[i-1] and sa[i], and lcp[0] is 0.
+It's computed in linear time by Kasai's algorithm which visits the suffixes in text order.
+If the suffix i has a common prefix of length h with its predecessor in the suffix array,
+then the suffix i+1 has a common prefix of at least h-1 with its predecessor,
+so the number of character compares is at most 2*n.
+*/
+func LCP(text string, sa []int) []int {
+	return kasai(sa, func(i, j int) bool {
+		return text[i] == text[j]
+	})
+}
+
+// kasai computes the LCP array of the suffix array sa
+// where equal reports whether the text characters at offsets i and j are equal.
+func kasai(sa []int, equal func(i, j int) bool) []int {
+	n := len(sa)
+	lcp := make([]int, n)
+	// rank is the inverse suffix array: rank[sa[i]] = i.
+	rank := make([]int, n)
+	for i, suffix := range sa {
+		rank[suffix] = i
+	}
+
+	h := 0
+	for i := 0; i < n; i++ {
+		if rank[i] == 0 {
+			h = 0
+			continue
+		}
+		j := sa[rank[i]-1]
+		for i+h < n && j+h < n && equal(i+h, j+h) {
+			h++
+		}
+		lcp[rank[i]] = h
+		if h > 0 {
+			h--
+		}
+	}
+	return lcp
+}
@@ -0,0 +1,181 @@
+package suffixarray
+
+import (
+	"sort"
+
+	"github.com/marselester/alg/stack"
+)
+
+// Count returns the number of occurrences of the query in the text.
+// It takes time proportional to m*log n where m is the query length.
+func (ki *KeywordIndex) Count(query string) int {
+	lo, hi := ki.interval(query)
+	return hi - lo
+}
+
+// Locate returns the offsets of all occurrences of the query in the text in increasing order.
+func (ki *KeywordIndex) Locate(query string) []int {
+	lo, hi := ki.interval(query)
+	if lo == hi {
+		return nil
+	}
+
+	offsets := make([]int, hi-lo)
+	copy(offsets, ki.sa[lo:hi])
+	sort.Ints(offsets)
+	return offsets
+}
+
+// Occurrence is a keyword in context: a match of the query in the text
+// with the specified number of characters before and after it.
+type Occurrence struct {
+	// Offset is the byte offset of the match in the text.
+	Offset int
+	Before string
+	Match  string
+	After  string
+}
+
+// Context returns all occurrences of the query in order of their offsets
+// with up to before characters preceding the match and up to after characters following it.
+func (ki *KeywordIndex) Context(query string, before, after int) []Occurrence {
+	offsets := ki.Locate(query)
+	found := make([]Occurrence, len(offsets))
+	for i, off := range offsets {
+		end := off + len(query)
+		found[i] = Occurrence{
+			Offset: off,
+			Before: ki.text[max(0, off-before):off],
+			Match:  ki.text[off:end],
+			After:  ki.text[end:min(len(ki.text), end+after)],
+		}
+	}
+	return found
+}
+
+// DistinctSubstrings returns the number of distinct non-empty substrings of the text.
+// Each suffix s contributes its prefixes except for the ones it shares with the preceding suffix,
+// i.e., len(s)-lcp of them.
+func (ki *KeywordIndex) DistinctSubstrings() int {
+	n := len(ki.text)
+	count := n * (n + 1) / 2
+	for _, l := range ki.lcp {
+		count -= l
+	}
+	return count
+}
+
+// Repeat is a substring that occurs more than once in the text.
+type Repeat struct {
+	Substring string
+	Count     int
+}
+
+/*
+Repeats returns the repeated substrings at least minLen long in sorted order.
+Only the right-maximal repeats are reported, i.e., the ones that can't be extended to the right
+without losing an occurrence: every repeated substring is a prefix of exactly one reported repeat
+that occurs the same number of times. For example, "banana" has "a", "ana", and "na" repeats,
+and "an" is implied by "ana" since it's always followed by "a".
+
+The suffixes that start with a repeat form an interval in the suffix array
+where LCP values are at least the repeat's length.
+The intervals are nested, and they're found in a single scan of the LCP array with a stack.
+*/
+func (ki *KeywordIndex) Repeats(minLen int) []Repeat {
+	minLen = max(minLen, 1)
+	type interval struct {
+		// lcp is the length of the common prefix of the suffixes in the interval.
+		lcp int
+		// lo is the first suffix of the interval.
+		lo int
+	}
+
+	var repeats []Repeat
+	intervals := stack.NewArrayOf[interval](0)
+	intervals.Push(interval{})
+	for i := 1; i <= len(ki.sa); i++ {
+		l := 0
+		if i < len(ki.sa) {
+			l = ki.lcp[i]
+		}
+
+		// Close the intervals which don't extend to the suffix i.
+		lo := i - 1
+		for top, _ := intervals.Peek(); l < top.lcp; top, _ = intervals.Peek() {
+			intervals.Pop()
+			if top.lcp >= minLen {
+				start := ki.sa[top.lo]
+				repeats = append(repeats, Repeat{
+					Substring: ki.text[start : start+top.lcp],
+					Count:     i - top.lo,
+				})
+			}
+			lo = top.lo
+		}
+		if top, _ := intervals.Peek(); l > top.lcp {
+			intervals.Push(interval{lcp: l, lo: lo})
+		}
+	}
+
+	sort.Slice(repeats, func(i, j int) bool {
+		return repeats[i].Substring < repeats[j].Substring
+	})
+	return repeats
+}
+
+// KMers returns all the distinct substrings of length k in sorted order with their number of occurrences.
+// The suffixes that start with the same k-mer are adjacent in the suffix array.
+func (ki *KeywordIndex) KMers(k int) []Repeat {
+	var kmers []Repeat
+	if k <= 0 {
+		return nil
+	}
+	for i := 0; i < len(ki.sa); i++ {
+		start := ki.sa[i]
+		if start+k > len(ki.text) {
+			continue
+		}
+		if n := len(kmers); n > 0 && ki.lcp[i] >= k {
+			kmers[n-1].Count++
+			continue
+		}
+		kmers = append(kmers, Repeat{
+			Substring: ki.text[start : start+k],
+			Count:     1,
+		})
+	}
+	return kmers
+}
+
+/*
+LongestCommonSubstring returns the longest string that appears in both texts.
+It builds the suffix array of the texts concatenated with a unique separator,
+so the common prefix of two suffixes never spans both texts.
+The longest common substring is the longest common prefix of two adjacent suffixes
+that come from different texts.
+*/
+func LongestCommonSubstring(a, b string) string {
+	// The separator is 256 which is larger than any byte.
+	const sep = 256
+	s := make([]int, 0, len(a)+1+len(b))
+	for i := 0; i < len(a); i++ {
+		s = append(s, int(a[i]))
+	}
+	s = append(s, sep)
+	for i := 0; i < len(b); i++ {
+		s = append(s, int(b[i]))
+	}
+
+	sa := sais(s, sep)
+	lcp := kasai(sa, func(i, j int) bool {
+		return s[i] == s[j]
+	})
+	var start, length int
+	for i := 1; i < len(sa); i++ {
+		if inA, prevInA := sa[i] < len(a), sa[i-1] < len(a); inA != prevInA && lcp[i] > length {
+			start, length = min(sa[i], sa[i-1]), lcp[i]
+		}
+	}
+	return a[start : start+length]
+}
@@ -0,0 +1,209 @@
+package suffixarray
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestKeywordIndexCount(t *testing.T) {
+	idx := New("it was the best of times it was the")
+	tests := []struct {
+		query string
+		want  int
+	}{
+		{"th", 2},
+		{"it was", 2},
+		{"t", 6},
+		{"times", 1},
+		{"worst", 0},
+		{"the end", 0},
+	}
+
+	for _, tc := range tests {
+		if got := idx.Count(tc.query); got != tc.want {
+			t.Errorf("Count(%q) = %d, want %d", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestKeywordIndexLocate(t *testing.T) {
+	idx := New("it was the best of times it was the")
+	tests := []struct {
+		query string
+		want  []int
+	}{
+		{"th", []int{7, 32}},
+		{"was", []int{3, 28}},
+		{"s", []int{5, 13, 23, 30}},
+		{"worst", nil},
+	}
+
+	for _, tc := range tests {
+		if got := idx.Locate(tc.query); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Locate(%q) = %v, want %v", tc.query, got, tc.want)
+		}
+	}
+}
+
+func TestKeywordIndexContext(t *testing.T) {
+	idx := New("it was the best of times it was the")
+	want := []Occurrence{
+		{Offset: 7, Before: "was ", Match: "the", After: " bes"},
+		{Offset: 32, Before: "was ", Match: "the", After: ""},
+	}
+	if got := idx.Context("the", 4, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("Context(%q) = %+v, want %+v", "the", got, want)
+	}
+
+	want = []Occurrence{
+		{Offset: 0, Before: "", Match: "it", After: " was"},
+		{Offset: 25, Before: "times ", Match: "it", After: " was"},
+	}
+	if got := idx.Context("it", 6, 4); !reflect.DeepEqual(got, want) {
+		t.Errorf("Context(%q) = %+v, want %+v", "it", got, want)
+	}
+}
+
+func TestKeywordIndexDistinctSubstrings(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		text := randomText(r, "ab", r.Intn(30))
+		want := len(substringCounts(text))
+		if got := New(text).DistinctSubstrings(); got != want {
+			t.Fatalf("DistinctSubstrings(%q) = %d, want %d", text, got, want)
+		}
+	}
+}
+
+func TestKeywordIndexRepeats(t *testing.T) {
+	tests := []struct {
+		text   string
+		minLen int
+		want   []Repeat
+	}{
+		{"abcab", 1, []Repeat{{"ab", 2}, {"b", 2}}},
+		{"banana", 1, []Repeat{{"a", 3}, {"ana", 2}, {"na", 2}}},
+		{"banana", 2, []Repeat{{"ana", 2}, {"na", 2}}},
+		{"banana", 4, nil},
+		{"aaaa", 0, []Repeat{{"a", 4}, {"aa", 3}, {"aaa", 2}}},
+	}
+
+	for _, tc := range tests {
+		if got := New(tc.text).Repeats(tc.minLen); !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("Repeats(%q, %d) = %v, want %v", tc.text, tc.minLen, got, tc.want)
+		}
+	}
+}
+
+func TestKeywordIndexRepeatsRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		text := randomText(r, "abc", r.Intn(30))
+		minLen := r.Intn(4)
+		want := naiveRepeats(text, minLen)
+		if got := New(text).Repeats(minLen); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Repeats(%q, %d) = %v, want %v", text, minLen, got, want)
+		}
+	}
+}
+
+func TestKeywordIndexKMers(t *testing.T) {
+	want := []Repeat{{"an", 2}, {"ba", 1}, {"na", 2}}
+	if got := New("banana").KMers(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("KMers(%q, 2) = %v, want %v", "banana", got, want)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		text := randomText(r, "ACGT", r.Intn(50))
+		k := 1 + r.Intn(4)
+
+		var want []Repeat
+		for s, count := range substringCounts(text) {
+			if len(s) == k {
+				want = append(want, Repeat{s, count})
+			}
+		}
+		sortRepeats(want)
+		if got := New(text).KMers(k); !reflect.DeepEqual(got, want) {
+			t.Fatalf("KMers(%q, %d) = %v, want %v", text, k, got, want)
+		}
+	}
+}
+
+func TestLongestCommonSubstring(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want string
+	}{
+		{"ABABC", "BABCA", "BABC"},
+		{"it was the best of times", "it was the worst of times", "it was the "},
+		{"abc", "xyz", ""},
+		{"", "abc", ""},
+		{"aaa", "aa", "aa"},
+	}
+
+	for _, tc := range tests {
+		if got := LongestCommonSubstring(tc.a, tc.b); got != tc.want {
+			t.Errorf("LongestCommonSubstring(%q, %q) = %q, want %q", tc.a, tc.b, got, tc.want)
+		}
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 300; i++ {
+		a, b := randomText(r, "ab", r.Intn(20)), randomText(r, "ab", r.Intn(20))
+		got := LongestCommonSubstring(a, b)
+		if !strings.Contains(a, got) || !strings.Contains(b, got) {
+			t.Fatalf("LongestCommonSubstring(%q, %q) = %q is not common", a, b, got)
+		}
+		for s := range substringCounts(a) {
+			if len(s) > len(got) && strings.Contains(b, s) {
+				t.Fatalf("LongestCommonSubstring(%q, %q) = %q, want %q", a, b, got, s)
+			}
+		}
+	}
+}
+
+// substringCounts returns the number of occurrences of each distinct substring of the text.
+func substringCounts(text string) map[string]int {
+	counts := make(map[string]int)
+	for i := 0; i < len(text); i++ {
+		for j := i + 1; j <= len(text); j++ {
+			counts[text[i:j]]++
+		}
+	}
+	return counts
+}
+
+// naiveRepeats returns the right-maximal repeats at least minLen long:
+// the substrings which occur more often than any of their one-character extensions.
+func naiveRepeats(text string, minLen int) []Repeat {
+	counts := substringCounts(text)
+	var repeats []Repeat
+	for s, count := range counts {
+		if count < 2 || len(s) < max(minLen, 1) {
+			continue
+		}
+		maximal := true
+		for c := range 256 {
+			if counts[s+string([]byte{byte(c)})] == count {
+				maximal = false
+				break
+			}
+		}
+		if maximal {
+			repeats = append(repeats, Repeat{s, count})
+		}
+	}
+	sortRepeats(repeats)
+	return repeats
+}
+
+func sortRepeats(repeats []Repeat) {
+	sort.Slice(repeats, func(i, j int) bool {
+		return repeats[i].Substring < repeats[j].Substring
+	})
+}
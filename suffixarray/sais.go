@@ -0,0 +1,164 @@
+package suffixarray
+
+/*
+SAIS returns the suffix array of the text: the starting offsets of the text suffixes in sorted order.
+It's built in linear time by the SA-IS algorithm (suffix array by induced sorting) by Nong, Zhang, and Chan.
+
+Each suffix is classified as S-type if it's smaller than the next suffix (one character shorter),
+or L-type if it's larger. An S-type suffix preceded by an L-type one is called leftmost S-type (LMS).
+The key observation is that once the LMS suffixes are sorted,
+the order of all the other suffixes can be induced in two linear scans:
+L-type suffixes are placed at the beginnings of their first-character buckets from left to right,
+and S-type suffixes at the ends of the buckets from right to left.
+
+The LMS suffixes are sorted recursively: the text is split into LMS substrings
+which are sorted by the same induction, named by their ranks,
+and the names form a reduced text at most half as long as the original one.
+*/
+func SAIS(text string) []int {
+	s := make([]int, len(text))
+	for i := 0; i < len(text); i++ {
+		s[i] = int(text[i])
+	}
+	return sais(s, 255)
+}
+
+// sais returns the suffix array of s whose characters are in [0, upper] range.
+func sais(s []int, upper int) []int {
+	n := len(s)
+	switch n {
+	case 0:
+		return []int{}
+	case 1:
+		return []int{0}
+	case 2:
+		if s[0] < s[1] {
+			return []int{0, 1}
+		}
+		return []int{1, 0}
+	}
+
+	// isS[i] reports whether the suffix i is S-type.
+	// The last suffix is L-type since it's larger than the empty suffix.
+	isS := make([]bool, n)
+	for i := n - 2; i >= 0; i-- {
+		if s[i] == s[i+1] {
+			isS[i] = isS[i+1]
+		} else {
+			isS[i] = s[i] < s[i+1]
+		}
+	}
+
+	// Bucket boundaries: L-type suffixes of character c start at sumL[c],
+	// and S-type suffixes of character c start at sumS[c] (they go after L-type ones).
+	sumL := make([]int, upper+2)
+	sumS := make([]int, upper+2)
+	for i := 0; i < n; i++ {
+		if !isS[i] {
+			sumS[s[i]]++
+		} else {
+			sumL[s[i]+1]++
+		}
+	}
+	for c := 0; c <= upper; c++ {
+		sumS[c] += sumL[c]
+		sumL[c+1] += sumS[c]
+	}
+
+	sa := make([]int, n)
+	buf := make([]int, upper+2)
+	induce := func(lms []int) {
+		for i := range sa {
+			sa[i] = -1
+		}
+		// Put the LMS suffixes into the S-type parts of their buckets.
+		copy(buf, sumS)
+		for _, d := range lms {
+			sa[buf[s[d]]] = d
+			buf[s[d]]++
+		}
+		// Induce L-type suffixes from left to right.
+		copy(buf, sumL)
+		sa[buf[s[n-1]]] = n - 1
+		buf[s[n-1]]++
+		for i := 0; i < n; i++ {
+			if v := sa[i]; v >= 1 && !isS[v-1] {
+				sa[buf[s[v-1]]] = v - 1
+				buf[s[v-1]]++
+			}
+		}
+		// Induce S-type suffixes from right to left filling the buckets from their ends.
+		copy(buf, sumL)
+		for i := n - 1; i >= 0; i-- {
+			if v := sa[i]; v >= 1 && isS[v-1] {
+				buf[s[v-1]+1]--
+				sa[buf[s[v-1]+1]] = v - 1
+			}
+		}
+	}
+
+	// lmsIndex maps a text offset of LMS suffix to its index in lms, or -1 if it's not LMS.
+	lmsIndex := make([]int, n+1)
+	var lms []int
+	for i := range lmsIndex {
+		lmsIndex[i] = -1
+	}
+	for i := 1; i < n; i++ {
+		if !isS[i-1] && isS[i] {
+			lmsIndex[i] = len(lms)
+			lms = append(lms, i)
+		}
+	}
+	m := len(lms)
+
+	// Induced sorting of LMS suffixes by their LMS substrings.
+	induce(lms)
+	if m == 0 {
+		return sa
+	}
+
+	sortedLMS := make([]int, 0, m)
+	for _, v := range sa {
+		if lmsIndex[v] != -1 {
+			sortedLMS = append(sortedLMS, v)
+		}
+	}
+
+	// Name LMS substrings: equal substrings get the same name, so the reduced text preserves the order.
+	reduced := make([]int, m)
+	name := 0
+	reduced[lmsIndex[sortedLMS[0]]] = 0
+	for i := 1; i < m; i++ {
+		l, r := sortedLMS[i-1], sortedLMS[i]
+		endL, endR := n, n
+		if lmsIndex[l]+1 < m {
+			endL = lms[lmsIndex[l]+1]
+		}
+		if lmsIndex[r]+1 < m {
+			endR = lms[lmsIndex[r]+1]
+		}
+
+		same := endL-l == endR-r
+		if same {
+			for l < endL && s[l] == s[r] {
+				l++
+				r++
+			}
+			if l == n || s[l] != s[r] {
+				same = false
+			}
+		}
+		if !same {
+			name++
+		}
+		reduced[lmsIndex[sortedLMS[i]]] = name
+	}
+
+	// Sort the LMS suffixes by recursion, and induce the final order from them.
+	reducedSA := sais(reduced, name)
+	for i := 0; i < m; i++ {
+		sortedLMS[i] = lms[reducedSA[i]]
+	}
+	induce(sortedLMS)
+	return sa
+}
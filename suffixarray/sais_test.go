@@ -0,0 +1,124 @@
+package suffixarray
+
+import (
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// constructions are the suffix array construction algorithms.
+var constructions = []struct {
+	name string
+	sort func(text string) []int
+}{
+	{"SAIS", SAIS},
+	{"PrefixDoubling", PrefixDoubling},
+}
+
+func TestSuffixArray(t *testing.T) {
+	tests := []struct {
+		text string
+		want []int
+	}{
+		{"", []int{}},
+		{"a", []int{0}},
+		{"ab", []int{0, 1}},
+		{"ba", []int{1, 0}},
+		{"aaa", []int{2, 1, 0}},
+		{"banana", []int{5, 3, 1, 0, 4, 2}},
+		{"mississippi", []int{10, 7, 4, 1, 0, 9, 8, 6, 3, 5, 2}},
+		{"ABRACADABRA!", []int{11, 10, 7, 0, 3, 5, 8, 1, 4, 6, 9, 2}},
+	}
+
+	for _, c := range constructions {
+		for _, tc := range tests {
+			if got := c.sort(tc.text); !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("%s(%q) = %v, want %v", c.name, tc.text, got, tc.want)
+			}
+		}
+	}
+}
+
+func TestSuffixArrayRandom(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		alphabet := []string{"a", "ab", "abc", "ACGT", "\x00\xff"}[r.Intn(5)]
+		text := randomText(r, alphabet, r.Intn(100))
+		want := naiveSuffixArray(text)
+		for _, c := range constructions {
+			if got := c.sort(text); !reflect.DeepEqual(got, want) {
+				t.Fatalf("%s(%q) = %v, want %v", c.name, text, got, want)
+			}
+		}
+	}
+}
+
+func TestLCP(t *testing.T) {
+	text := "banana"
+	want := []int{0, 1, 3, 0, 0, 2}
+	if got := LCP(text, SAIS(text)); !reflect.DeepEqual(got, want) {
+		t.Errorf("LCP(%q) = %v, want %v", text, got, want)
+	}
+
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		text := randomText(r, "ab", r.Intn(50))
+		sa := SAIS(text)
+		got := LCP(text, sa)
+		for j := 1; j < len(sa); j++ {
+			if want := len(LongestPrefix(text[sa[j-1]:], text[sa[j]:])); got[j] != want {
+				t.Fatalf("LCP(%q)[%d] = %d, want %d", text, j, got[j], want)
+			}
+		}
+	}
+}
+
+func BenchmarkSuffixArray(b *testing.B) {
+	r := rand.New(rand.NewSource(1))
+	texts := []struct {
+		name string
+		text string
+	}{
+		{"random", randomText(r, "abcdefghijklmnopqrstuvwxyz ", 1<<16)},
+		{"repetitive", strings.Repeat("a", 1<<16)},
+	}
+
+	for _, tc := range texts {
+		for _, c := range constructions {
+			b.Run(c.name+"/"+tc.name, func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					c.sort(tc.text)
+				}
+			})
+		}
+		b.Run("naive/"+tc.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				naiveSuffixArray(tc.text)
+			}
+		})
+	}
+}
+
+// naiveSuffixArray sorts the suffixes as strings.
+func naiveSuffixArray(text string) []int {
+	sa := make([]int, len(text))
+	for i := range sa {
+		sa[i] = i
+	}
+	sort.Slice(sa, func(i, j int) bool {
+		return text[sa[i]:] < text[sa[j]:]
+	})
+	return sa
+}
+
+func randomText(r *rand.Rand, alphabet string, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = alphabet[r.Intn(len(alphabet))]
+	}
+	return string(b)
+}
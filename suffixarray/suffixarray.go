@@ -1,15 +1,22 @@
 /*
 Package suffixarray provides substring search using suffix array — the abstraction of a sorted list of
-suffix strings. The cost of comparing two suffixes may be proportional to the length of the suffixes
-in the case when their common prefix is very long, but most comparisons in typical applications involve
-only a few characters. If so, the running time of the suffix sort is linearithmic.
-If all the characters are equal, the sort examines every character in each suffix and thus takes
-quadratic time.
+suffix strings. Rather than materializing the suffixes, the suffix array keeps their starting offsets
+in the text sorted by the suffixes they refer to.
+
+Sorting the suffixes as strings takes linearithmic time in typical applications,
+but if the text is repetitive (all the characters are equal), the sort examines every character
+in each suffix and thus takes quadratic time.
+SAIS builds the suffix array in linear time, and PrefixDoubling in linearithmic time
+no matter how repetitive the text is.
+The LCP array of the longest common prefixes of the adjacent suffixes is computed in linear time as well.
+
+The text is treated as a sequence of bytes.
 */
 package suffixarray
 
 import (
 	"sort"
+	"strings"
 )
 
 // LongestPrefix returns a longest common prefix of given two strings.
@@ -35,45 +42,37 @@ func LongestPrefix(s1, s2 string) string {
 
 /*
 LongestRepeatedSubstring returns the longest substring that appears at least twice in a given string.
-The idea is to make an array of the n suffixes (the substrings starting at each position and
-going to the end), and then sort this array. Every substring appears somewhere as a prefix of one
-of the suffixes in the array. After sorting, the longest repeated substrings will appear in adjacent
-positions in the array. Thus, we can make a single pass through the sorted array, keeping track of
-the longest matching prefixes between adjacent strings.
+The idea is to sort the n suffixes (the substrings starting at each position and going to the end).
+Every substring appears somewhere as a prefix of one of the suffixes in the array.
+After sorting, the longest repeated substrings will appear in adjacent positions in the array.
+Thus, the longest repeated substring is the longest common prefix of adjacent suffixes
+which is the max value of the LCP array.
 */
 func LongestRepeatedSubstring(s string) string {
 	if len(s) < 2 {
 		return ""
 	}
 
-	suffix := make([]string, len(s))
-	// For simplicity's sake, s is considered to be ASCII string.
-	for i := 0; i < len(s); i++ {
-		suffix[i] = s[i:]
-	}
-	sort.Strings(suffix)
-
-	var longest string
-	for i, j := 0, 1; j < len(suffix); i, j = i+1, j+1 {
-		p := LongestPrefix(suffix[i], suffix[j])
-		if len(p) > len(longest) {
-			longest = p
+	sa := SAIS(s)
+	lcp := LCP(s, sa)
+	var start, length int
+	for i := 1; i < len(sa); i++ {
+		if lcp[i] > length {
+			start, length = sa[i], lcp[i]
 		}
 	}
-	return longest
+	return s[start : start+length]
 }
 
 // New returns a new KeywordIndex index to find a substring in a text.
-// For simplicity's sake, a text is considered to be ASCII string.
+// It takes time and space proportional to the text length.
 func New(text string) *KeywordIndex {
-	ki := KeywordIndex{
-		suffix: make([]string, len(text)),
-	}
-	for i := 0; i < len(text); i++ {
-		ki.suffix[i] = text[i:]
+	sa := SAIS(text)
+	return &KeywordIndex{
+		text: text,
+		sa:   sa,
+		lcp:  LCP(text, sa),
 	}
-	sort.Strings(ki.suffix)
-	return &ki
 }
 
 /*
@@ -83,22 +82,42 @@ going to the end), and then sort this array. A binary search is used to search i
 comparing the search key with each suffix.
 */
 type KeywordIndex struct {
-	suffix []string
+	text string
+	// sa is the suffix array: sa[i] is the offset of i-th smallest suffix of the text.
+	sa []int
+	// lcp[i] is the length of the longest common prefix of the suffixes sa[i-1] and sa[i].
+	lcp []int
+}
+
+// suffix returns i-th smallest suffix of the text.
+func (ki *KeywordIndex) suffix(i int) string {
+	return ki.text[ki.sa[i]:]
 }
 
 // Search returns all occurrences that matched the query including specified number of characters
-// after to give context.
+// after to give context. The occurrences are ordered as the suffixes they start.
 func (ki *KeywordIndex) Search(query string, ctxlen int) []string {
+	lo, hi := ki.interval(query)
 	var found []string
-	for i := ki.rank(query); i < len(ki.suffix); i++ {
-		if p := LongestPrefix(query, ki.suffix[i]); len(query) == len(p) {
-			n := min(len(ki.suffix[i]), len(query)+ctxlen)
-			found = append(found, ki.suffix[i][:n])
-		}
+	for i := lo; i < hi; i++ {
+		s := ki.suffix(i)
+		n := min(len(s), len(query)+ctxlen)
+		found = append(found, s[:n])
 	}
 	return found
 }
 
+// interval returns the range [lo, hi) of the suffix array where the suffixes start with the query.
+// All the suffixes that have the query as a prefix are adjacent,
+// so the range is found with two binary searches in time proportional to m*log n.
+func (ki *KeywordIndex) interval(query string) (lo, hi int) {
+	lo = ki.rank(query)
+	hi = lo + sort.Search(len(ki.sa)-lo, func(i int) bool {
+		return !strings.HasPrefix(ki.suffix(lo+i), query)
+	})
+	return lo, hi
+}
+
 // rank returns the number of suffixes less than the given search key.
 // It helps to find the first possible suffix in the sorted suffix list that has key as prefix
 // and that all other occurrences of key in the text immediately follow.
@@ -106,16 +125,17 @@ func (ki *KeywordIndex) Search(query string, ctxlen int) []string {
 // Otherwise, it also returns the number of keys that are smaller than the search key.
 func (ki *KeywordIndex) rank(s string) int {
 	var lo, mid, hi int
-	hi = len(ki.suffix) - 1
+	hi = len(ki.sa) - 1
 
 	for lo <= hi {
 		mid = lo + (hi-lo)/2
+		suffix := ki.suffix(mid)
 		switch {
-		case s == ki.suffix[mid]:
+		case s == suffix:
 			return mid
-		case s > ki.suffix[mid]:
+		case s > suffix:
 			lo = mid + 1
-		case s < ki.suffix[mid]:
+		case s < suffix:
 			hi = mid - 1
 		}
 	}
@@ -123,9 +143,3 @@ func (ki *KeywordIndex) rank(s string) int {
 	// lo always equals to number of keys that are smaller than the search key.
 	return lo
 }
-func min(i, j int) int {
-	if i >= j {
-		return j
-	}
-	return i
-}
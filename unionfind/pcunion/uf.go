@@ -0,0 +1,119 @@
+// Package pcunion solves dynamic connectivity problem by weighted quick-union algorithm
+// with path compression. Ideally we would like every site to link directly to the root
+// of its tree, but we don't want to pay the price of changing a large number of links
+// as in quick-find. Instead, the sites are linked closer to the root
+// as a side effect of Find, so the trees become almost completely flat.
+//
+// Amortized cost per operation is bounded by inverse Ackermann function
+// which is less than 5 for any conceivable n, i.e., it's nearly constant.
+package pcunion
+
+// Network represents dynamic connections in a network.
+type Network struct {
+	// sites is an array of sites (e.g., a computer in a network);
+	// it's a parent-link representation of a forest of trees.
+	// An index represents a site number, value is a "link" to another site which
+	// belongs to the same component (root of a tree).
+	sites []int
+	// sizes represents the size of each tree (how many sites belong to component).
+	sizes []int
+	// count is a number of components in the network.
+	count int
+	// halving indicates whether Find uses path halving instead of full path compression.
+	halving bool
+}
+
+// New creates a Network of size n whose Find links every examined site directly to the root
+// (full path compression).
+// Its components have IDs that correspond to array index.
+// Initially each component has one site (size is one).
+func New(n int) *Network {
+	net := Network{
+		sites: make([]int, n),
+		sizes: make([]int, n),
+		count: n,
+	}
+	for i := 0; i < n; i++ {
+		net.sites[i] = i
+		net.sizes[i] = 1
+	}
+	return &net
+}
+
+// NewHalving creates a Network of size n whose Find links every other examined site
+// to its grandparent (path halving). It makes a single pass up the tree,
+// and has the same asymptotic cost as full path compression.
+func NewHalving(n int) *Network {
+	net := New(n)
+	net.halving = true
+	return net
+}
+
+// Find returns the component identifier for a given site p.
+// It follows site links until reaching a root site (component ID) that
+// has a link to itself, and then compresses the path.
+func (net *Network) Find(p int) int {
+	if net.halving {
+		for net.sites[p] != p {
+			net.sites[p] = net.sites[net.sites[p]]
+			p = net.sites[p]
+		}
+		return p
+	}
+
+	root := p
+	for net.sites[root] != root {
+		root = net.sites[root]
+	}
+	// Second pass links all the sites on the path directly to the root.
+	for p != root {
+		p, net.sites[p] = net.sites[p], root
+	}
+	return root
+}
+
+// Connect adds a connection between p and q by merging components
+// if the two sites are in different components. Each merge decrements
+// the number of components by one.
+//
+// To combine the two components into one, find their roots and link
+// smaller tree to the larger.
+func (net *Network) Connect(p, q int) {
+	pID := net.Find(p)
+	qID := net.Find(q)
+	if pID == qID {
+		return
+	}
+
+	if net.sizes[pID] < net.sizes[qID] {
+		net.sites[pID] = qID
+		net.sizes[qID] += net.sizes[pID]
+	} else {
+		net.sites[qID] = pID
+		net.sizes[pID] += net.sizes[qID]
+	}
+
+	net.count--
+}
+
+// IsConnected tells whether p and q are in the same component (they have the same root).
+func (net *Network) IsConnected(p, q int) bool {
+	return net.Find(p) == net.Find(q)
+}
+
+// Count returns number of components. Initially, there are n components,
+// with each site in its own component.
+func (net *Network) Count() int {
+	return net.count
+}
+
+// Depth returns the number of links from p to the root of its tree without compressing the path.
+// It's useful to study how the trees grow, since the cost of Find is proportional to the depth.
+func (net *Network) Depth(p int) int {
+	var d int
+	for net.sites[p] != p {
+		p = net.sites[p]
+		d++
+	}
+	return d
+}
@@ -0,0 +1,165 @@
+package pcunion
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/marselester/alg/unionfind"
+)
+
+var _ unionfind.Network = (*Network)(nil)
+
+func equal(seq1, seq2 [][2]int) bool {
+	if len(seq1) != len(seq2) {
+		return false
+	}
+	for i := 0; i < len(seq1); i++ {
+		if seq1[i] != seq2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNetwork(t *testing.T) {
+	net := New(10)
+	seq := [][2]int{
+		{4, 3},
+		{3, 8},
+		{6, 5},
+		{9, 4},
+		{2, 1},
+		{8, 9},
+		{5, 0},
+		{7, 2},
+		{6, 1},
+		{1, 0},
+		{6, 7},
+	}
+	want := [][2]int{
+		{4, 3},
+		{3, 8},
+		{6, 5},
+		{9, 4},
+		{2, 1},
+		// {8, 9},
+		{5, 0},
+		{7, 2},
+		{6, 1},
+		// {1, 0},
+		// {6, 7},
+	}
+	var got [][2]int
+
+	for _, pair := range seq {
+		if !net.IsConnected(pair[0], pair[1]) {
+			net.Connect(pair[0], pair[1])
+			got = append(got, pair)
+		}
+	}
+	if !equal(got, want) {
+		t.Errorf("Network weighted quick-union with path compression connectivity is %v, want %v", got, want)
+	}
+	if net.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", net.Count())
+	}
+}
+
+func randPairs(n int) [][2]int {
+	src := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(src)
+	pairs := make([][2]int, n)
+	for i := 0; i < n; i++ {
+		pairs[i] = [2]int{
+			r.Int() % n,
+			r.Int() % n,
+		}
+	}
+	return pairs
+}
+
+func BenchmarkPathCompression(b *testing.B) {
+	var tt = []struct {
+		name  string
+		net   *Network
+		pairs [][2]int
+	}{
+		{"size=1K", New(1e3), randPairs(1e3)},
+		{"size=10K", New(1e4), randPairs(1e4)},
+		{"size=100K", New(1e5), randPairs(1e5)},
+		{"size=200K", New(2e5), randPairs(2e5)},
+	}
+	b.ResetTimer()
+
+	for _, tc := range tt {
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, pair := range tc.pairs {
+					tc.net.Connect(pair[0], pair[1])
+				}
+			}
+		})
+	}
+}
+
+func TestFind(t *testing.T) {
+	var tt = []struct {
+		name string
+		net  *Network
+		// want is a parent of site 0 after Find.
+		want int
+	}{
+		{"full compression", New(4), 3},
+		{"halving", NewHalving(4), 2},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			// Build a path 0 -> 1 -> 2 -> 3 which weighting wouldn't allow.
+			tc.net.sites = []int{1, 2, 3, 3}
+			if got := tc.net.Find(0); got != 3 {
+				t.Fatalf("Find(0) = %d, want 3", got)
+			}
+			if got := tc.net.sites[0]; got != tc.want {
+				t.Errorf("parent of 0 = %d, want %d", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestHalvingNetwork(t *testing.T) {
+	full, halving := New(100), NewHalving(100)
+	for _, pair := range randPairs(100) {
+		full.Connect(pair[0], pair[1])
+		halving.Connect(pair[0], pair[1])
+	}
+	for p := 0; p < 100; p++ {
+		for q := 0; q < 100; q++ {
+			if full.IsConnected(p, q) != halving.IsConnected(p, q) {
+				t.Fatalf("IsConnected(%d, %d) differs", p, q)
+			}
+		}
+	}
+	if full.Count() != halving.Count() {
+		t.Errorf("Count() = %d, want %d", halving.Count(), full.Count())
+	}
+}
+
+func TestDepth(t *testing.T) {
+	net := New(4)
+	net.Connect(0, 1)
+	net.Connect(2, 3)
+	net.Connect(1, 3)
+	root := net.Find(0)
+	if got := net.Depth(root); got != 0 {
+		t.Errorf("Depth(%d) = %d, want 0", root, got)
+	}
+	var maxDepth int
+	for p := 0; p < 4; p++ {
+		maxDepth = max(maxDepth, net.Depth(p))
+	}
+	if maxDepth != 2 {
+		t.Errorf("max depth = %d, want 2", maxDepth)
+	}
+}
@@ -0,0 +1,188 @@
+// Package persistent solves dynamic connectivity problem by weighted quick-union algorithm
+// which preserves all previous versions of the network.
+// Each Connect call creates a new version, and any version can be checked out to answer queries
+// about the network as it was at that point or to branch off new connections from there.
+//
+// The parent links and tree sizes are stored in persistent arrays: a binary tree
+// whose leaves are array items. Updating an item copies only lg n nodes on the path
+// from the root to the leaf, and the rest of the nodes are shared with the previous version.
+// Path compression is not used because it would have to create new versions on Find,
+// hence Find takes lg² n time (lg n links, each read in lg n time).
+package persistent
+
+// Network represents dynamic connections in a network.
+type Network struct {
+	// versions is a list of snapshots of the network, a version number is an index.
+	versions []version
+	// current is the version number used by Find, Connect, IsConnected and Count.
+	current int
+}
+
+// version is a snapshot of the network.
+type version struct {
+	// sites is a parent-link representation of a forest of trees.
+	sites *array
+	// sizes represents the size of each tree (how many sites belong to component).
+	sizes *array
+	// count is a number of components in the network.
+	count int
+}
+
+// New creates a Network of size n. Its components have IDs that correspond
+// to array index. Initially each component has one site (size is one).
+// The initial state of the network is version 0.
+func New(n int) *Network {
+	sites := make([]int, n)
+	sizes := make([]int, n)
+	for i := 0; i < n; i++ {
+		sites[i] = i
+		sizes[i] = 1
+	}
+	v := version{
+		sites: newArray(sites),
+		sizes: newArray(sizes),
+		count: n,
+	}
+	return &Network{
+		versions: []version{v},
+	}
+}
+
+// Find returns the component identifier for a given site p in the current version.
+// It follows site links until reaching a root site (component ID) that
+// has a link to itself.
+func (net *Network) Find(p int) int {
+	sites := net.versions[net.current].sites
+	for {
+		parent := sites.get(p)
+		if parent == p {
+			return p
+		}
+		p = parent
+	}
+}
+
+// Connect adds a connection between p and q by merging components
+// if the two sites are in different components.
+// It creates a new version of the network which becomes the current one,
+// even if the sites were already connected.
+//
+// To combine the two components into one, find their roots and link
+// smaller tree to the larger.
+func (net *Network) Connect(p, q int) {
+	v := net.versions[net.current]
+	pID := net.Find(p)
+	qID := net.Find(q)
+	if pID != qID {
+		pSize, qSize := v.sizes.get(pID), v.sizes.get(qID)
+		if pSize < qSize {
+			pID, qID = qID, pID
+		}
+		v.sites = v.sites.set(qID, pID)
+		v.sizes = v.sizes.set(pID, pSize+qSize)
+		v.count--
+	}
+
+	net.versions = append(net.versions, v)
+	net.current = len(net.versions) - 1
+}
+
+// IsConnected tells whether p and q are in the same component (they have the same root)
+// in the current version.
+func (net *Network) IsConnected(p, q int) bool {
+	return net.Find(p) == net.Find(q)
+}
+
+// Count returns number of components in the current version.
+func (net *Network) Count() int {
+	return net.versions[net.current].count
+}
+
+// Version returns the current version number.
+func (net *Network) Version() int {
+	return net.current
+}
+
+// Checkout makes the given version current.
+// Subsequent Connect calls create new versions based on it,
+// the versions that were created after it are kept intact.
+// It returns false if the version doesn't exist.
+func (net *Network) Checkout(version int) bool {
+	if version < 0 || version >= len(net.versions) {
+		return false
+	}
+	net.current = version
+	return true
+}
+
+// array is a persistent array of ints represented as a complete binary tree.
+// Values are stored in the leaves, the array is never modified in place.
+type array struct {
+	root *node
+	n    int
+}
+type node struct {
+	left, right *node
+	value       int
+}
+
+// newArray creates a persistent array with a copy of values a.
+func newArray(a []int) *array {
+	return &array{
+		root: build(a, 0, len(a)-1),
+		n:    len(a),
+	}
+}
+
+// build creates a tree for values a[lo..hi].
+func build(a []int, lo, hi int) *node {
+	if lo > hi {
+		return nil
+	}
+	if lo == hi {
+		return &node{value: a[lo]}
+	}
+	mid := lo + (hi-lo)/2
+	return &node{
+		left:  build(a, lo, mid),
+		right: build(a, mid+1, hi),
+	}
+}
+
+// get returns the i-th value of the array.
+func (a *array) get(i int) int {
+	x := a.root
+	lo, hi := 0, a.n-1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		if i <= mid {
+			x, hi = x.left, mid
+		} else {
+			x, lo = x.right, mid+1
+		}
+	}
+	return x.value
+}
+
+// set returns a new version of the array where i-th value is replaced with v.
+func (a *array) set(i, v int) *array {
+	return &array{
+		root: set(a.root, 0, a.n-1, i, v),
+		n:    a.n,
+	}
+}
+
+// set copies the path from x node to i-th leaf which covers [lo; hi] interval.
+func set(x *node, lo, hi, i, v int) *node {
+	if lo == hi {
+		return &node{value: v}
+	}
+	mid := lo + (hi-lo)/2
+	copied := *x
+	if i <= mid {
+		copied.left = set(x.left, lo, mid, i, v)
+	} else {
+		copied.right = set(x.right, mid+1, hi, i, v)
+	}
+	return &copied
+}
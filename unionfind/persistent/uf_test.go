@@ -0,0 +1,164 @@
+package persistent
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/marselester/alg/unionfind"
+)
+
+var _ unionfind.Network = (*Network)(nil)
+
+func equal(seq1, seq2 [][2]int) bool {
+	if len(seq1) != len(seq2) {
+		return false
+	}
+	for i := 0; i < len(seq1); i++ {
+		if seq1[i] != seq2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNetwork(t *testing.T) {
+	net := New(10)
+	seq := [][2]int{
+		{4, 3},
+		{3, 8},
+		{6, 5},
+		{9, 4},
+		{2, 1},
+		{8, 9},
+		{5, 0},
+		{7, 2},
+		{6, 1},
+		{1, 0},
+		{6, 7},
+	}
+	want := [][2]int{
+		{4, 3},
+		{3, 8},
+		{6, 5},
+		{9, 4},
+		{2, 1},
+		// {8, 9},
+		{5, 0},
+		{7, 2},
+		{6, 1},
+		// {1, 0},
+		// {6, 7},
+	}
+	var got [][2]int
+
+	for _, pair := range seq {
+		if !net.IsConnected(pair[0], pair[1]) {
+			net.Connect(pair[0], pair[1])
+			got = append(got, pair)
+		}
+	}
+	if !equal(got, want) {
+		t.Errorf("Network persistent weighted quick-union connectivity is %v, want %v", got, want)
+	}
+	if net.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", net.Count())
+	}
+}
+
+func randPairs(n int) [][2]int {
+	src := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(src)
+	pairs := make([][2]int, n)
+	for i := 0; i < n; i++ {
+		pairs[i] = [2]int{
+			r.Int() % n,
+			r.Int() % n,
+		}
+	}
+	return pairs
+}
+
+func BenchmarkPersistent(b *testing.B) {
+	var tt = []struct {
+		name  string
+		net   *Network
+		pairs [][2]int
+	}{
+		{"size=1K", New(1e3), randPairs(1e3)},
+		{"size=10K", New(1e4), randPairs(1e4)},
+		{"size=100K", New(1e5), randPairs(1e5)},
+		{"size=200K", New(2e5), randPairs(2e5)},
+	}
+	b.ResetTimer()
+
+	for _, tc := range tt {
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, pair := range tc.pairs {
+					tc.net.Connect(pair[0], pair[1])
+				}
+			}
+		})
+	}
+}
+
+func TestCheckout(t *testing.T) {
+	net := New(4)
+	net.Connect(0, 1)
+	v1 := net.Version()
+	net.Connect(2, 3)
+	net.Connect(1, 2)
+	if net.Count() != 1 || !net.IsConnected(0, 3) {
+		t.Fatalf("all sites should be connected")
+	}
+
+	if !net.Checkout(v1) {
+		t.Fatalf("Checkout(%d) = false, want true", v1)
+	}
+	if net.Count() != 3 {
+		t.Errorf("Count() = %d, want 3", net.Count())
+	}
+	if net.IsConnected(2, 3) {
+		t.Errorf("IsConnected(2, 3) = true, want false")
+	}
+
+	// Branch off version 1.
+	net.Connect(0, 3)
+	if net.Version() != 4 {
+		t.Errorf("Version() = %d, want 4", net.Version())
+	}
+	if !net.IsConnected(1, 3) || net.IsConnected(2, 3) {
+		t.Errorf("branched version has wrong connections")
+	}
+
+	net.Checkout(3)
+	if !net.IsConnected(2, 3) || net.Count() != 1 {
+		t.Errorf("version 3 was modified")
+	}
+	net.Checkout(0)
+	if net.Count() != 4 || net.IsConnected(0, 1) {
+		t.Errorf("version 0 was modified")
+	}
+
+	if net.Checkout(5) || net.Checkout(-1) {
+		t.Errorf("Checkout() of missing version = true, want false")
+	}
+}
+
+func TestArray(t *testing.T) {
+	want := []int{5, 1, 4, 2, 3}
+	a := newArray(want)
+	b := a.set(2, 40)
+	for i := range want {
+		if got := a.get(i); got != want[i] {
+			t.Errorf("a.get(%d) = %d, want %d", i, got, want[i])
+		}
+	}
+	if got := b.get(2); got != 40 {
+		t.Errorf("b.get(2) = %d, want 40", got)
+	}
+	if got := b.get(4); got != 3 {
+		t.Errorf("b.get(4) = %d, want 3", got)
+	}
+}
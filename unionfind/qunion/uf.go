@@ -67,3 +67,14 @@ func (net *Network) IsConnected(p, q int) bool {
 func (net *Network) Count() int {
 	return net.count
 }
+
+// Depth returns the number of links from p to the root of its tree.
+// It's useful to study how the trees grow, since the cost of Find is proportional to the depth.
+func (net *Network) Depth(p int) int {
+	var d int
+	for net.sites[p] != p {
+		p = net.sites[p]
+		d++
+	}
+	return d
+}
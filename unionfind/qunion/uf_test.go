@@ -95,3 +95,21 @@ func BenchmarkQuickUnion(b *testing.B) {
 		})
 	}
 }
+
+func TestDepth(t *testing.T) {
+	net := New(4)
+	net.Connect(0, 1)
+	net.Connect(2, 3)
+	net.Connect(1, 3)
+	root := net.Find(0)
+	if got := net.Depth(root); got != 0 {
+		t.Errorf("Depth(%d) = %d, want 0", root, got)
+	}
+	var maxDepth int
+	for p := 0; p < 4; p++ {
+		maxDepth = max(maxDepth, net.Depth(p))
+	}
+	if maxDepth != 2 {
+		t.Errorf("max depth = %d, want 2", maxDepth)
+	}
+}
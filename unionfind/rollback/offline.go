@@ -0,0 +1,109 @@
+package rollback
+
+// Operation kinds of the offline dynamic connectivity problem.
+const (
+	// OpConnect adds a connection between P and Q.
+	OpConnect = iota
+	// OpDisconnect removes a connection between P and Q that was added earlier.
+	OpDisconnect
+	// OpQuery asks whether P and Q are connected.
+	OpQuery
+)
+
+// Op is an operation of the offline dynamic connectivity problem.
+type Op struct {
+	Kind int
+	P, Q int
+}
+
+// Offline answers queries in a sequence of operations on a network of size n
+// where connections can be both added and removed.
+// The answers are returned in the order of the queries.
+//
+// Each connection lives during a time interval [added; removed) of operation indexes.
+// The intervals are stored in a segment tree over the operation indexes, so each interval is split
+// into at most 2 lg m tree nodes, where m is the number of operations.
+// Depth-first traversal of the tree connects the sites when it enters a node,
+// answers a query when it reaches a leaf, and rolls back the connections when it leaves the node.
+// The running time is m lg m lg n.
+func Offline(n int, ops []Op) []bool {
+	m := len(ops)
+	if m == 0 {
+		return nil
+	}
+
+	s := segtree{
+		edges: make([][][2]int, 4*m),
+		ops:   ops,
+		net:   New(n),
+	}
+	// added maps a connection to a stack of times it was added,
+	// since the same pair of sites can be connected several times.
+	added := make(map[[2]int][]int)
+	for t, op := range ops {
+		e := [2]int{min(op.P, op.Q), max(op.P, op.Q)}
+		switch op.Kind {
+		case OpConnect:
+			added[e] = append(added[e], t)
+		case OpDisconnect:
+			starts := added[e]
+			if len(starts) == 0 {
+				continue
+			}
+			s.add(1, 0, m-1, starts[len(starts)-1], t-1, e)
+			added[e] = starts[:len(starts)-1]
+		}
+	}
+	for e, starts := range added {
+		for _, start := range starts {
+			s.add(1, 0, m-1, start, m-1, e)
+		}
+	}
+
+	s.walk(1, 0, m-1)
+	return s.answers
+}
+
+// segtree is a segment tree over operation indexes where each node
+// keeps connections that are alive during the whole node's time range.
+type segtree struct {
+	edges   [][][2]int
+	ops     []Op
+	net     *Network
+	answers []bool
+}
+
+// add stores connection e in the nodes that cover time interval [from; to].
+// The node x covers [lo; hi] interval.
+func (s *segtree) add(x, lo, hi, from, to int, e [2]int) {
+	if from > to || to < lo || hi < from {
+		return
+	}
+	if from <= lo && hi <= to {
+		s.edges[x] = append(s.edges[x], e)
+		return
+	}
+	mid := lo + (hi-lo)/2
+	s.add(2*x, lo, mid, from, to, e)
+	s.add(2*x+1, mid+1, hi, from, to, e)
+}
+
+// walk traverses the tree in depth-first order answering the queries in the leaves.
+func (s *segtree) walk(x, lo, hi int) {
+	checkpoint := s.net.Checkpoint()
+	for _, e := range s.edges[x] {
+		s.net.Connect(e[0], e[1])
+	}
+
+	if lo == hi {
+		if op := s.ops[lo]; op.Kind == OpQuery {
+			s.answers = append(s.answers, s.net.IsConnected(op.P, op.Q))
+		}
+	} else {
+		mid := lo + (hi-lo)/2
+		s.walk(2*x, lo, mid)
+		s.walk(2*x+1, mid+1, hi)
+	}
+
+	s.net.Rollback(checkpoint)
+}
@@ -0,0 +1,117 @@
+// Package rollback solves dynamic connectivity problem by weighted quick-union algorithm
+// that can undo connections in reverse order.
+// Path compression is not used because it changes links that would have to be restored,
+// so Find takes logarithmic time, and undoing a connection takes constant time.
+//
+// Union-find can't delete connections in general, but when all operations are known
+// in advance, connections can be added and undone in a divide-and-conquer manner,
+// see Offline.
+package rollback
+
+// Network represents dynamic connections in a network.
+type Network struct {
+	// sites is an array of sites (e.g., a computer in a network);
+	// it's a parent-link representation of a forest of trees.
+	// An index represents a site number, value is a "link" to another site which
+	// belongs to the same component (root of a tree).
+	sites []int
+	// sizes represents the size of each tree (how many sites belong to component).
+	sizes []int
+	// count is a number of components in the network.
+	count int
+	// history is a stack of roots that were linked to another tree by Connect.
+	// A connection of already connected sites is recorded as -1 so it can be undone as well.
+	history []int
+}
+
+// New creates a Network of size n. Its components have IDs that correspond
+// to array index. Initially each component has one site (size is one).
+func New(n int) *Network {
+	net := Network{
+		sites: make([]int, n),
+		sizes: make([]int, n),
+		count: n,
+	}
+	for i := 0; i < n; i++ {
+		net.sites[i] = i
+		net.sizes[i] = 1
+	}
+	return &net
+}
+
+// Find returns the component identifier for a given site p.
+// It follows site links until reaching a root site (component ID) that
+// has a link to itself.
+func (net *Network) Find(p int) int {
+	for net.sites[p] != p {
+		p = net.sites[p]
+	}
+	return p
+}
+
+// Connect adds a connection between p and q by merging components
+// if the two sites are in different components. Each merge decrements
+// the number of components by one.
+//
+// To combine the two components into one, find their roots and link
+// smaller tree to the larger. The linked root is remembered to undo the connection.
+func (net *Network) Connect(p, q int) {
+	pID := net.Find(p)
+	qID := net.Find(q)
+	if pID == qID {
+		net.history = append(net.history, -1)
+		return
+	}
+
+	if net.sizes[pID] < net.sizes[qID] {
+		pID, qID = qID, pID
+	}
+	net.sites[qID] = pID
+	net.sizes[pID] += net.sizes[qID]
+	net.history = append(net.history, qID)
+
+	net.count--
+}
+
+// Undo reverts the latest Connect call.
+// It returns false when there is nothing to undo.
+func (net *Network) Undo() bool {
+	if len(net.history) == 0 {
+		return false
+	}
+	child := net.history[len(net.history)-1]
+	net.history = net.history[:len(net.history)-1]
+	if child == -1 {
+		return true
+	}
+
+	root := net.sites[child]
+	net.sites[child] = child
+	net.sizes[root] -= net.sizes[child]
+	net.count++
+	return true
+}
+
+// Checkpoint returns the number of Connect calls that can be undone.
+// It's used to return the network to the current state with Rollback.
+func (net *Network) Checkpoint() int {
+	return len(net.history)
+}
+
+// Rollback undoes the Connect calls made after the given checkpoint.
+func (net *Network) Rollback(checkpoint int) {
+	for len(net.history) > checkpoint {
+		net.Undo()
+	}
+}
+
+// IsConnected tells whether p and q are in the same component (they have the same root).
+func (net *Network) IsConnected(p, q int) bool {
+	return net.Find(p) == net.Find(q)
+}
+
+// Count returns number of components. Initially, there are n components,
+// with each site in its own component.
+func (net *Network) Count() int {
+	return net.count
+}
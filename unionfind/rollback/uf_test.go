@@ -0,0 +1,206 @@
+package rollback
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/marselester/alg/unionfind"
+)
+
+var _ unionfind.Network = (*Network)(nil)
+
+func equal(seq1, seq2 [][2]int) bool {
+	if len(seq1) != len(seq2) {
+		return false
+	}
+	for i := 0; i < len(seq1); i++ {
+		if seq1[i] != seq2[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func TestNetwork(t *testing.T) {
+	net := New(10)
+	seq := [][2]int{
+		{4, 3},
+		{3, 8},
+		{6, 5},
+		{9, 4},
+		{2, 1},
+		{8, 9},
+		{5, 0},
+		{7, 2},
+		{6, 1},
+		{1, 0},
+		{6, 7},
+	}
+	want := [][2]int{
+		{4, 3},
+		{3, 8},
+		{6, 5},
+		{9, 4},
+		{2, 1},
+		// {8, 9},
+		{5, 0},
+		{7, 2},
+		{6, 1},
+		// {1, 0},
+		// {6, 7},
+	}
+	var got [][2]int
+
+	for _, pair := range seq {
+		if !net.IsConnected(pair[0], pair[1]) {
+			net.Connect(pair[0], pair[1])
+			got = append(got, pair)
+		}
+	}
+	if !equal(got, want) {
+		t.Errorf("Network rollback weighted quick-union connectivity is %v, want %v", got, want)
+	}
+	if net.Count() != 2 {
+		t.Errorf("Count() = %d, want 2", net.Count())
+	}
+}
+
+func randPairs(n int) [][2]int {
+	src := rand.NewSource(time.Now().UnixNano())
+	r := rand.New(src)
+	pairs := make([][2]int, n)
+	for i := 0; i < n; i++ {
+		pairs[i] = [2]int{
+			r.Int() % n,
+			r.Int() % n,
+		}
+	}
+	return pairs
+}
+
+func BenchmarkRollback(b *testing.B) {
+	var tt = []struct {
+		name  string
+		net   *Network
+		pairs [][2]int
+	}{
+		{"size=1K", New(1e3), randPairs(1e3)},
+		{"size=10K", New(1e4), randPairs(1e4)},
+		{"size=100K", New(1e5), randPairs(1e5)},
+		{"size=200K", New(2e5), randPairs(2e5)},
+	}
+	b.ResetTimer()
+
+	for _, tc := range tt {
+		b.Run(tc.name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				for _, pair := range tc.pairs {
+					tc.net.Connect(pair[0], pair[1])
+				}
+			}
+		})
+	}
+}
+
+func TestUndo(t *testing.T) {
+	net := New(5)
+	net.Connect(0, 1)
+	checkpoint := net.Checkpoint()
+	net.Connect(2, 3)
+	net.Connect(1, 0)
+	net.Connect(3, 0)
+	if net.Count() != 2 {
+		t.Fatalf("Count() = %d, want 2", net.Count())
+	}
+
+	net.Rollback(checkpoint)
+	if net.Count() != 4 {
+		t.Errorf("Count() = %d, want 4", net.Count())
+	}
+	if !net.IsConnected(0, 1) {
+		t.Errorf("IsConnected(0, 1) = false, want true")
+	}
+	if net.IsConnected(2, 3) {
+		t.Errorf("IsConnected(2, 3) = true, want false")
+	}
+
+	if !net.Undo() {
+		t.Fatalf("Undo() = false, want true")
+	}
+	if net.Undo() {
+		t.Errorf("Undo() = true, want false")
+	}
+	for i, s := range net.sizes {
+		if s != 1 || net.sites[i] != i {
+			t.Errorf("site %d wasn't restored", i)
+		}
+	}
+}
+
+// bruteConnected rebuilds a network from the given connections to answer the query.
+func bruteConnected(n int, edges map[[2]int]int, p, q int) bool {
+	net := New(n)
+	for e, count := range edges {
+		if count > 0 {
+			net.Connect(e[0], e[1])
+		}
+	}
+	return net.IsConnected(p, q)
+}
+
+func TestOffline(t *testing.T) {
+	const n = 20
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	edges := make(map[[2]int]int)
+	var live [][2]int
+	var ops []Op
+	var want []bool
+	for i := 0; i < 500; i++ {
+		p, q := r.Intn(n), r.Intn(n)
+		e := [2]int{min(p, q), max(p, q)}
+		switch r.Intn(3) {
+		case OpConnect:
+			ops = append(ops, Op{Kind: OpConnect, P: p, Q: q})
+			edges[e]++
+			live = append(live, e)
+		case OpDisconnect:
+			if len(live) == 0 {
+				continue
+			}
+			j := r.Intn(len(live))
+			e = live[j]
+			live = append(live[:j], live[j+1:]...)
+			// Sites order shouldn't matter.
+			ops = append(ops, Op{Kind: OpDisconnect, P: e[1], Q: e[0]})
+			edges[e]--
+		case OpQuery:
+			ops = append(ops, Op{Kind: OpQuery, P: p, Q: q})
+			want = append(want, bruteConnected(n, edges, p, q))
+		}
+	}
+
+	got := Offline(n, ops)
+	if len(got) != len(want) {
+		t.Fatalf("Offline() returned %d answers, want %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("query %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestOfflineEmpty(t *testing.T) {
+	if got := Offline(3, nil); got != nil {
+		t.Errorf("Offline() = %v, want nil", got)
+	}
+	got := Offline(3, []Op{
+		{Kind: OpDisconnect, P: 0, Q: 1},
+		{Kind: OpQuery, P: 0, Q: 1},
+		{Kind: OpQuery, P: 2, Q: 2},
+	})
+	if len(got) != 2 || got[0] || !got[1] {
+		t.Errorf("Offline() = %v, want [false true]", got)
+	}
+}
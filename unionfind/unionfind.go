@@ -0,0 +1,15 @@
+// Package unionfind defines Network interface that is implemented by
+// dynamic connectivity algorithms in the subpackages, so clients can swap them.
+package unionfind
+
+// Network represents dynamic connections in a network of n sites numbered from 0 to n-1.
+type Network interface {
+	// Find returns the component identifier for a given site p.
+	Find(p int) int
+	// Connect adds a connection between p and q.
+	Connect(p, q int)
+	// IsConnected tells whether p and q are in the same component.
+	IsConnected(p, q int) bool
+	// Count returns number of components.
+	Count() int
+}
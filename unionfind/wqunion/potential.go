@@ -0,0 +1,108 @@
+package wqunion
+
+// Potential represents a network where each site p has an unknown value x[p] (potential),
+// and a connection between p and q is a constraint x[p] - x[q] = d.
+// For example, the sites could be people and d the difference in their heights,
+// or the sites could be accounts and d the difference in their balances.
+// It answers what the difference between any two connected sites is,
+// and detects a constraint that contradicts the ones added earlier.
+//
+// Besides the parent link, every site stores the difference between its potential and
+// the potential of its parent, so the difference from a site to its root is
+// the sum of the differences along the path.
+type Potential struct {
+	// sites is a parent-link representation of a forest of trees.
+	sites []int
+	// diffs is the difference between potential of a site and its parent x[p] - x[sites[p]].
+	diffs []int
+	// sizes represents the size of each tree (how many sites belong to component).
+	sizes []int
+	// count is a number of components in the network.
+	count int
+}
+
+// NewPotential creates a Potential network of size n without constraints.
+// Its components have IDs that correspond to array index.
+func NewPotential(n int) *Potential {
+	net := Potential{
+		sites: make([]int, n),
+		diffs: make([]int, n),
+		sizes: make([]int, n),
+		count: n,
+	}
+	for i := 0; i < n; i++ {
+		net.sites[i] = i
+		net.sizes[i] = 1
+	}
+	return &net
+}
+
+// Find returns the component identifier for a given site p.
+func (net *Potential) Find(p int) int {
+	root, _ := net.find(p)
+	return root
+}
+
+// find returns the root of p and the difference x[p] - x[root].
+func (net *Potential) find(p int) (root, diff int) {
+	for net.sites[p] != p {
+		diff += net.diffs[p]
+		p = net.sites[p]
+	}
+	return p, diff
+}
+
+// Relate adds a constraint x[p] - x[q] = d.
+// If p and q are already connected, the constraint isn't added,
+// and Relate reports whether it agrees with the existing ones.
+//
+// To combine the two components into one, find their roots and link
+// smaller tree to the larger. The difference between the roots follows from
+// x[p] - x[q] = d, where x[p] = x[pID] + pDiff and x[q] = x[qID] + qDiff.
+func (net *Potential) Relate(p, q, d int) bool {
+	pID, pDiff := net.find(p)
+	qID, qDiff := net.find(q)
+	if pID == qID {
+		return pDiff-qDiff == d
+	}
+
+	if net.sizes[pID] < net.sizes[qID] {
+		net.sites[pID] = qID
+		net.diffs[pID] = d - pDiff + qDiff
+		net.sizes[qID] += net.sizes[pID]
+	} else {
+		net.sites[qID] = pID
+		net.diffs[qID] = pDiff - qDiff - d
+		net.sizes[pID] += net.sizes[qID]
+	}
+
+	net.count--
+	return true
+}
+
+// Diff returns x[p] - x[q] if p and q are connected.
+// Otherwise the difference is unknown, and false is returned.
+func (net *Potential) Diff(p, q int) (int, bool) {
+	pID, pDiff := net.find(p)
+	qID, qDiff := net.find(q)
+	if pID != qID {
+		return 0, false
+	}
+	return pDiff - qDiff, true
+}
+
+// IsConnected tells whether p and q are in the same component (they have the same root).
+func (net *Potential) IsConnected(p, q int) bool {
+	return net.Find(p) == net.Find(q)
+}
+
+// Size returns the number of sites in the component that p belongs to.
+func (net *Potential) Size(p int) int {
+	return net.sizes[net.Find(p)]
+}
+
+// Count returns number of components. Initially, there are n components,
+// with each site in its own component.
+func (net *Potential) Count() int {
+	return net.count
+}
@@ -0,0 +1,81 @@
+package wqunion
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/marselester/alg/graph"
+)
+
+func TestPotential(t *testing.T) {
+	net := NewPotential(4)
+	var tt = []struct {
+		p, q, d int
+		want    bool
+	}{
+		{0, 1, 3, true},
+		{1, 2, -5, true},
+		{0, 2, -2, true},
+		{2, 0, 2, true},
+		{0, 2, 2, false},
+		{3, 3, 0, true},
+		{3, 3, 1, false},
+	}
+	for _, tc := range tt {
+		if got := net.Relate(tc.p, tc.q, tc.d); got != tc.want {
+			t.Errorf("Relate(%d, %d, %d) = %v, want %v", tc.p, tc.q, tc.d, got, tc.want)
+		}
+	}
+
+	if d, ok := net.Diff(2, 1); !ok || d != 5 {
+		t.Errorf("Diff(2, 1) = %d, %v, want 5, true", d, ok)
+	}
+	if _, ok := net.Diff(0, 3); ok {
+		t.Errorf("Diff(0, 3) is known, want unknown")
+	}
+	if net.Count() != 2 || net.Size(1) != 3 {
+		t.Errorf("Count() = %d, Size(1) = %d, want 2, 3", net.Count(), net.Size(1))
+	}
+}
+
+func TestPotentialRandom(t *testing.T) {
+	const n = 200
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	x := make([]int, n)
+	for i := range x {
+		x[i] = r.Intn(1000) - 500
+	}
+
+	net := NewPotential(n)
+	g := graph.NewAdjacencyList(n)
+	for _, pair := range randPairs(n)[:n/2] {
+		p, q := pair[0], pair[1]
+		if !net.Relate(p, q, x[p]-x[q]) {
+			t.Fatalf("Relate(%d, %d) of consistent constraint = false", p, q)
+		}
+		g.Add(p, q)
+	}
+	cc := graph.NewConnectedComponent(g)
+	if net.Count() != cc.Count() {
+		t.Fatalf("Count() = %d, want %d", net.Count(), cc.Count())
+	}
+
+	for p := 0; p < n; p++ {
+		for q := 0; q < n; q++ {
+			d, ok := net.Diff(p, q)
+			if ok != cc.IsConnected(p, q) {
+				t.Fatalf("Diff(%d, %d) known = %v, want %v", p, q, ok, cc.IsConnected(p, q))
+			}
+			if !ok {
+				continue
+			}
+			if d != x[p]-x[q] {
+				t.Fatalf("Diff(%d, %d) = %d, want %d", p, q, d, x[p]-x[q])
+			}
+			if net.Relate(p, q, d+1) {
+				t.Fatalf("Relate(%d, %d) of inconsistent constraint = true", p, q)
+			}
+		}
+	}
+}
@@ -14,6 +14,10 @@ type Network struct {
 	sites []int
 	// sizes represents the size of each tree (how many sites belong to component).
 	sizes []int
+	// next links the sites of a component into a circular list, so its members
+	// can be enumerated without scanning all the sites.
+	// Two circular lists are merged into one by swapping next links of their roots.
+	next []int
 	// count is a number of components in the network.
 	count int
 }
@@ -24,11 +28,13 @@ func New(n int) *Network {
 	net := Network{
 		sites: make([]int, n),
 		sizes: make([]int, n),
+		next:  make([]int, n),
 		count: n,
 	}
 	for i := 0; i < n; i++ {
 		net.sites[i] = i
 		net.sizes[i] = 1
+		net.next[i] = i
 	}
 	return &net
 }
@@ -65,6 +71,7 @@ func (net *Network) Connect(p, q int) {
 		net.sites[qID] = pID
 		net.sizes[pID] += net.sizes[qID]
 	}
+	net.next[pID], net.next[qID] = net.next[qID], net.next[pID]
 
 	net.count--
 }
@@ -79,3 +86,29 @@ func (net *Network) IsConnected(p, q int) bool {
 func (net *Network) Count() int {
 	return net.count
 }
+
+// Size returns the number of sites in the component that p belongs to.
+func (net *Network) Size(p int) int {
+	return net.sizes[net.Find(p)]
+}
+
+// Members returns the sites of the component that p belongs to starting with p.
+// It takes time proportional to the size of the component.
+func (net *Network) Members(p int) []int {
+	members := []int{p}
+	for q := net.next[p]; q != p; q = net.next[q] {
+		members = append(members, q)
+	}
+	return members
+}
+
+// Depth returns the number of links from p to the root of its tree.
+// It's useful to study how the trees grow, since the cost of Find is proportional to the depth.
+func (net *Network) Depth(p int) int {
+	var d int
+	for net.sites[p] != p {
+		p = net.sites[p]
+		d++
+	}
+	return d
+}
@@ -2,8 +2,11 @@ package wqunion
 
 import (
 	"math/rand"
+	"sort"
 	"testing"
 	"time"
+
+	"github.com/marselester/alg/graph"
 )
 
 func equal(seq1, seq2 [][2]int) bool {
@@ -59,6 +62,59 @@ func TestNetwork(t *testing.T) {
 	}
 }
 
+func TestNetworkComponents(t *testing.T) {
+	const n = 200
+	net := New(n)
+	g := graph.NewAdjacencyList(n)
+	for _, pair := range randPairs(n)[:n/2] {
+		net.Connect(pair[0], pair[1])
+		g.Add(pair[0], pair[1])
+	}
+	cc := graph.NewConnectedComponent(g)
+
+	if net.Count() != cc.Count() {
+		t.Fatalf("Count() = %d, want %d", net.Count(), cc.Count())
+	}
+	// components lists vertices of each component found by depth-first search.
+	components := make([][]int, cc.Count())
+	for v := 0; v < n; v++ {
+		components[cc.ID(v)] = append(components[cc.ID(v)], v)
+	}
+
+	for p := 0; p < n; p++ {
+		for q := 0; q < n; q++ {
+			if got, want := net.IsConnected(p, q), cc.IsConnected(p, q); got != want {
+				t.Fatalf("IsConnected(%d, %d) = %v, want %v", p, q, got, want)
+			}
+		}
+
+		want := components[cc.ID(p)]
+		if got := net.Size(p); got != len(want) {
+			t.Errorf("Size(%d) = %d, want %d", p, got, len(want))
+		}
+		got := net.Members(p)
+		if got[0] != p {
+			t.Errorf("Members(%d) starts with %d", p, got[0])
+		}
+		sort.Ints(got)
+		if !equalInts(got, want) {
+			t.Errorf("Members(%d) = %v, want %v", p, got, want)
+		}
+	}
+}
+
+func equalInts(s1, s2 []int) bool {
+	if len(s1) != len(s2) {
+		return false
+	}
+	for i := range s1 {
+		if s1[i] != s2[i] {
+			return false
+		}
+	}
+	return true
+}
+
 func randPairs(n int) [][2]int {
 	src := rand.NewSource(time.Now().UnixNano())
 	r := rand.New(src)
@@ -95,3 +151,21 @@ func BenchmarkWeightedQuickUnion(b *testing.B) {
 		})
 	}
 }
+
+func TestDepth(t *testing.T) {
+	net := New(4)
+	net.Connect(0, 1)
+	net.Connect(2, 3)
+	net.Connect(1, 3)
+	root := net.Find(0)
+	if got := net.Depth(root); got != 0 {
+		t.Errorf("Depth(%d) = %d, want 0", root, got)
+	}
+	var maxDepth int
+	for p := 0; p < 4; p++ {
+		maxDepth = max(maxDepth, net.Depth(p))
+	}
+	if maxDepth != 2 {
+		t.Errorf("max depth = %d, want 2", maxDepth)
+	}
+}